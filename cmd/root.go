@@ -14,6 +14,15 @@ func Run() {
 	// print commands in help/usage text in the order they are declared
 	cobra.EnableCommandSorting = false
 
+	factory := cli.NewCommandFactory()
+	cobra.OnInitialize(factory.Setup)
+	defer factory.Close()
+
+	factory.Run(newRootCommand(factory))
+}
+
+// newRootCommand builds the root command along with every command of the CLI
+func newRootCommand(factory *cli.CommandFactory) *cobra.Command {
 	cmd := &cobra.Command{
 		Version:       cli.Version,
 		Use:           cli.Name,
@@ -23,10 +32,6 @@ func Run() {
 		SilenceUsage:  true,
 	}
 
-	factory := cli.NewCommandFactory()
-	cobra.OnInitialize(factory.Setup)
-	defer factory.Close()
-
 	cmd.Flags().SortFlags = false // ensures CLI help text displays global flags unsorted
 	factory.SetGlobalFlags(cmd.PersistentFlags())
 
@@ -36,9 +41,12 @@ func Run() {
 	cmd.AddCommand(factory.Build(commands.Pull))
 	cmd.AddCommand(factory.Build(commands.App))
 	cmd.AddCommand(factory.Build(commands.Secrets))
+	cmd.AddCommand(factory.Build(commands.Values))
 	cmd.AddCommand(factory.Build(commands.User))
 	cmd.AddCommand(factory.Build(commands.Whoami))
 	cmd.AddCommand(factory.Build(commands.Function))
+	cmd.AddCommand(factory.Build(commands.Deployments))
+	cmd.AddCommand(factory.Build(commands.Drafts))
 
-	factory.Run(cmd)
+	return cmd
 }
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRootCommand(t *testing.T) {
+	t.Run("should build every command without a flag clashing with the global flags", func(t *testing.T) {
+		root := newRootCommand(cli.NewCommandFactory())
+
+		var walk func(cmd *cobra.Command)
+		walk = func(cmd *cobra.Command) {
+			t.Run(cmd.CommandPath(), func(t *testing.T) {
+				assert.Nil(t, mergeFlags(cmd))
+			})
+			for _, c := range cmd.Commands() {
+				walk(c)
+			}
+		}
+		walk(root)
+	})
+}
+
+// mergeFlags merges the command's flags with the persistent flags of its parents,
+// as cobra does before running the command, which panics when two flags clash
+func mergeFlags(cmd *cobra.Command) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	cmd.LocalFlags()
+	return nil
+}
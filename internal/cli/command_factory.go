@@ -165,7 +165,7 @@ func (factory *CommandFactory) Run(cmd *cobra.Command) {
 		}
 
 		factory.ui.Print(logs...)
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
@@ -228,6 +228,14 @@ func (factory *CommandFactory) ensureUI() {
 	}
 }
 
+func exitCode(err error) int {
+	var e ExitCoder
+	if errors.As(err, &e) {
+		return e.ExitCode()
+	}
+	return 1
+}
+
 func handleUsage(cmd *cobra.Command, err error) {
 	if _, ok := errors.Unwrap(err).(DisableUsage); ok {
 		return
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+)
+
+const (
+	deploymentPollInterval = time.Second
+)
+
+// ErrDeploymentFailed is a deployment error where the Realm app changes failed to deploy
+type ErrDeploymentFailed struct {
+	DeploymentID string
+	Reason       string
+}
+
+func (err ErrDeploymentFailed) Error() string {
+	msg := fmt.Sprintf("deployment '%s' failed", err.DeploymentID)
+	if err.Reason != "" {
+		msg += ": " + err.Reason
+	}
+	return msg
+}
+
+// DisableUsage disables the usage printing when an error occurs
+func (err ErrDeploymentFailed) DisableUsage() struct{} { return struct{}{} }
+
+// WaitForDeployment polls the deployment until it has either succeeded or failed,
+// displaying a spinner with the provided message in the meantime
+func WaitForDeployment(ui terminal.UI, realmClient realm.Client, groupID, appID string, deployment realm.AppDeployment, message string) (realm.AppDeployment, error) {
+	s := terminal.NewSpinner(ui, message)
+
+	s.Start()
+	defer s.Stop()
+
+	for deployment.Status == realm.DeploymentStatusCreated || deployment.Status == realm.DeploymentStatusPending {
+		time.Sleep(deploymentPollInterval)
+
+		var err error
+		deployment, err = realmClient.Deployment(groupID, appID, deployment.ID)
+		if err != nil {
+			return realm.AppDeployment{}, err
+		}
+	}
+	return deployment, nil
+}
+
+// DraftDiffLogs renders the draft diff under the provided message along with each of its sections
+// which has changes, or renders the empty message should the draft hold no changes
+func DraftDiffLogs(diff realm.AppDraftDiff, message, emptyMessage string) []terminal.Log {
+	if !diff.HasChanges() {
+		return []terminal.Log{terminal.NewTextLog(emptyMessage)}
+	}
+
+	logs := []terminal.Log{terminal.NewListLog(message, diff.DiffList()...)}
+	if diff.HostingFilesDiff.HasChanges() {
+		logs = append(logs, terminal.NewListLog("With changes to your static hosting files...", diff.HostingFilesDiff.DiffList()...))
+	}
+	if diff.DependenciesDiff.HasChanges() {
+		logs = append(logs, terminal.NewListLog("With changes to your app dependencies...", diff.DependenciesDiff.DiffList()...))
+	}
+	if diff.GraphQLConfigDiff.HasChanges() {
+		logs = append(logs, terminal.NewListLog("With changes to your GraphQL configuration...", diff.GraphQLConfigDiff.DiffList()...))
+	}
+	if diff.SchemaOptionsDiff.HasChanges() {
+		logs = append(logs, terminal.NewListLog("With changes to your app schema...", diff.SchemaOptionsDiff.DiffList()...))
+	}
+	return logs
+}
+
+// CreateNewDraft creates a draft for the app. Should a draft already exist, it is shown and
+// discarded once confirmed, returning false if the user chooses to keep it
+func CreateNewDraft(ui terminal.UI, realmClient realm.Client, groupID, appID string) (realm.AppDraft, bool, error) {
+	draft, draftErr := realmClient.CreateDraft(groupID, appID)
+	if draftErr == nil {
+		return draft, true, nil
+	}
+
+	if err, ok := draftErr.(realm.ServerError); !ok || err.Code != realm.ErrCodeDraftAlreadyExists {
+		return realm.AppDraft{}, false, draftErr
+	}
+
+	existingDraft, existingDraftErr := realmClient.Draft(groupID, appID)
+	if existingDraftErr != nil {
+		return realm.AppDraft{}, false, existingDraftErr
+	}
+
+	if !ui.AutoConfirm() {
+		if err := printExistingDraft(ui, realmClient, groupID, appID, existingDraft.ID); err != nil {
+			return realm.AppDraft{}, false, err
+		}
+
+		proceed, proceedErr := ui.Confirm("Would you like to discard this draft?")
+		if proceedErr != nil {
+			return realm.AppDraft{}, false, proceedErr
+		}
+		if !proceed {
+			return realm.AppDraft{}, false, nil
+		}
+	}
+
+	if err := realmClient.DiscardDraft(groupID, appID, existingDraft.ID); err != nil {
+		return realm.AppDraft{}, false, err
+	}
+
+	draft, draftErr = realmClient.CreateDraft(groupID, appID)
+	return draft, true, draftErr
+}
+
+func printExistingDraft(ui terminal.UI, realmClient realm.Client, groupID, appID, draftID string) error {
+	diff, diffErr := realmClient.DiffDraft(groupID, appID, draftID)
+	if diffErr != nil {
+		return diffErr
+	}
+
+	ui.Print(DraftDiffLogs(diff, "The following draft already exists for your app...", "An empty draft already exists for your app")...)
+	return nil
+}
+
+// DeployDraftAndWait deploys the draft and waits for the deployment to complete. Should the
+// deployment fail, the draft is discarded once confirmed, while it is left in place should
+// the deployment fail to be polled
+func DeployDraftAndWait(ui terminal.UI, realmClient realm.Client, groupID, appID, draftID string) error {
+	deployment, err := realmClient.DeployDraft(groupID, appID, draftID)
+	if err != nil {
+		return err
+	}
+
+	deployment, err = WaitForDeployment(ui, realmClient, groupID, appID, deployment, "Deploying app changes...")
+	if err != nil {
+		// the deployment may still be running, so the draft is left in place
+		return err
+	}
+
+	if deployment.Status == realm.DeploymentStatusFailed {
+		ui.Print(terminal.NewWarningLog("Deployment failed: %s", deployment.StatusErrorMessage))
+
+		discard, err := ui.Confirm("Would you like to discard the draft created for your deployment?")
+		if err != nil {
+			return err
+		}
+		if discard {
+			if err := realmClient.DiscardDraft(groupID, appID, draftID); err != nil {
+				ui.Print(terminal.NewWarningLog("Failed to discard the draft created for your deployment"))
+			}
+		}
+		return ErrDeploymentFailed{DeploymentID: deployment.ID, Reason: deployment.StatusErrorMessage}
+	}
+
+	ui.Print(terminal.NewTextLog("Deployment complete"))
+	return nil
+}
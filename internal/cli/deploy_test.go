@@ -0,0 +1,430 @@
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestErrDeploymentFailed(t *testing.T) {
+	t.Run("should print the failure reason", func(t *testing.T) {
+		assert.Equal(t, "deployment 'id' failed: something went wrong", cli.ErrDeploymentFailed{DeploymentID: "id", Reason: "something went wrong"}.Error())
+		assert.Equal(t, "deployment 'id' failed", cli.ErrDeploymentFailed{DeploymentID: "id"}.Error())
+	})
+
+	t.Run("should disable usage", func(t *testing.T) {
+		var err error = cli.ErrDeploymentFailed{}
+
+		_, ok := err.(cli.DisableUsage)
+		assert.True(t, ok, "expected deployment failed error to disable usage")
+	})
+}
+
+func TestWaitForDeployment(t *testing.T) {
+	t.Run("should poll the deployment until it is no longer pending", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		var realmClient mock.RealmClient
+
+		var capturedDeploymentID string
+		realmClient.DeploymentFn = func(groupID, appID, deploymentID string) (realm.AppDeployment, error) {
+			capturedDeploymentID = deploymentID
+			return realm.AppDeployment{ID: deploymentID, Status: realm.DeploymentStatusFailed, StatusErrorMessage: "something went wrong"}, nil
+		}
+
+		deployment, err := cli.WaitForDeployment(ui, realmClient, "groupID", "appID", realm.AppDeployment{ID: "id", Status: realm.DeploymentStatusPending}, "Deploying...")
+		assert.Nil(t, err)
+		assert.Equal(t, "id", capturedDeploymentID)
+		assert.Equal(t, realm.AppDeployment{ID: "id", Status: realm.DeploymentStatusFailed, StatusErrorMessage: "something went wrong"}, deployment)
+	})
+
+	t.Run("should return the deployment as is when it is no longer pending", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		deployment, err := cli.WaitForDeployment(ui, mock.RealmClient{}, "groupID", "appID", realm.AppDeployment{ID: "id", Status: realm.DeploymentStatusSuccessful}, "Deploying...")
+		assert.Nil(t, err)
+		assert.Equal(t, realm.AppDeployment{ID: "id", Status: realm.DeploymentStatusSuccessful}, deployment)
+	})
+
+	t.Run("should return an error when the deployment cannot be polled", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		var realmClient mock.RealmClient
+		realmClient.DeploymentFn = func(groupID, appID, deploymentID string) (realm.AppDeployment, error) {
+			return realm.AppDeployment{}, errors.New("something bad happened")
+		}
+
+		_, err := cli.WaitForDeployment(ui, realmClient, "groupID", "appID", realm.AppDeployment{ID: "id", Status: realm.DeploymentStatusCreated}, "Deploying...")
+		assert.Equal(t, errors.New("something bad happened"), err)
+	})
+}
+
+func TestDraftDiffLogs(t *testing.T) {
+	t.Run("should render the empty message when the draft holds no changes", func(t *testing.T) {
+		logs := cli.DraftDiffLogs(realm.AppDraftDiff{}, "Changes...", "No changes")
+		assert.Equal(t, 1, len(logs))
+
+		msg, err := logs[0].Data.Message()
+		assert.Nil(t, err)
+		assert.Equal(t, "No changes", msg)
+	})
+
+	t.Run("should render the changes along with each section which has changes", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		ui.Print(cli.DraftDiffLogs(realm.AppDraftDiff{
+			Diffs:            []string{"diff1"},
+			HostingFilesDiff: realm.HostingFilesDiff{Added: []string{"/index.html"}},
+		}, "Changes...", "No changes")...)
+
+		assert.Equal(t, `Changes...
+  diff1
+With changes to your static hosting files...
+  added: /index.html
+`, out.String())
+	})
+}
+func TestCreateNewDraft(t *testing.T) {
+	t.Run("should create and return the draft when initially successful", func(t *testing.T) {
+		groupID, appID := "groupID", "appID"
+		testDraft := realm.AppDraft{ID: "id"}
+
+		realmClient := mock.RealmClient{}
+
+		var capturedGroupID, capturedAppID string
+		realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			capturedGroupID = groupID
+			capturedAppID = appID
+			return testDraft, nil
+		}
+
+		draft, proceed, err := cli.CreateNewDraft(nil, realmClient, groupID, appID)
+		assert.Nil(t, err)
+		assert.Equal(t, testDraft, draft)
+		assert.True(t, proceed, "expected draft to be created successfully")
+
+		t.Log("and should properly pass through the expected inputs")
+		assert.Equal(t, groupID, capturedGroupID)
+		assert.Equal(t, appID, capturedAppID)
+	})
+
+	t.Run("should return the error if client fails to create the draft for reasons other than it already exists", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return realm.AppDraft{}, errors.New("something bad happened while creating a draft")
+		}
+
+		_, _, err := cli.CreateNewDraft(nil, realmClient, "", "")
+		assert.Equal(t, errors.New("something bad happened while creating a draft"), err)
+	})
+
+	t.Run("with a client that fails to create a draft because it already exists", func(t *testing.T) {
+		errDraftAlreadyExists := realm.ServerError{Code: realm.ErrCodeDraftAlreadyExists, Message: "a draft already exists"}
+
+		realmClient := mock.RealmClient{}
+		realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return realm.AppDraft{}, errDraftAlreadyExists
+		}
+
+		t.Run("and fails to retrieve the existing draft should return the error", func(t *testing.T) {
+			realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+				return realm.AppDraft{}, errors.New("something bad happened while getting a draft")
+			}
+
+			_, _, err := cli.CreateNewDraft(nil, realmClient, "", "")
+			assert.Equal(t, errors.New("something bad happened while getting a draft"), err)
+		})
+
+		t.Run("and fails to diff the existing draft should return the error", func(t *testing.T) {
+			realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+				return realm.AppDraft{ID: "draftID"}, nil
+			}
+
+			var capturedDraftID string
+			realmClient.DiffDraftFn = func(groupID, appID, draftID string) (realm.AppDraftDiff, error) {
+				capturedDraftID = draftID
+				return realm.AppDraftDiff{}, errors.New("something bad happened while diffing the draft")
+			}
+
+			_, ui := mock.NewUI()
+
+			_, _, err := cli.CreateNewDraft(ui, realmClient, "", "")
+			assert.Equal(t, errors.New("something bad happened while diffing the draft"), err)
+			assert.Equal(t, "draftID", capturedDraftID)
+		})
+
+		t.Run("and successfully retrieves and diffs the existing draft", func(t *testing.T) {
+			draftID := "draftID"
+
+			realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+				return realm.AppDraft{ID: draftID}, nil
+			}
+
+			realmClient.DiffDraftFn = func(groupID, appID, draftID string) (realm.AppDraftDiff, error) {
+				return realm.AppDraftDiff{}, nil
+			}
+
+			t.Run("with a ui set to auto-confirm", func(t *testing.T) {
+				out := new(bytes.Buffer)
+				ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+				t.Run("and a client that fails to discard the draft should return the error", func(t *testing.T) {
+					var capturedDraftID string
+					realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
+						capturedDraftID = draftID
+						return errors.New("something bad happened while discarding the draft")
+					}
+
+					_, _, err := cli.CreateNewDraft(ui, realmClient, "", "")
+					assert.Equal(t, errors.New("something bad happened while discarding the draft"), err)
+
+					t.Log("and should properly pass through the expected inputs")
+					assert.Equal(t, draftID, capturedDraftID)
+				})
+
+				t.Run("and a client that successfully discards the existing draft", func(t *testing.T) {
+					realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
+						return nil
+					}
+
+					t.Run("but still fails to create a new draft should return the error", func(t *testing.T) {
+						realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+							return realm.AppDraft{}, errDraftAlreadyExists
+						}
+
+						_, _, err := cli.CreateNewDraft(ui, realmClient, "", "")
+						assert.Equal(t, errDraftAlreadyExists, err)
+					})
+
+					t.Run("and successfully creates a new draft should be successful", func(t *testing.T) {
+						testDraft := realm.AppDraft{ID: "id"}
+
+						realmClient := mock.RealmClient{}
+
+						realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+							return testDraft, nil
+						}
+
+						draft, proceed, err := cli.CreateNewDraft(nil, realmClient, "", "")
+						assert.Nil(t, err)
+						assert.Equal(t, testDraft, draft)
+						assert.True(t, proceed, "expected draft to be created successfully")
+					})
+				})
+			})
+
+			t.Run("should prompt the user to accept the diffed changes", func(t *testing.T) {
+				t.Run("and mark the draft as kept in command outputs if the user selects no", func(t *testing.T) {
+					_, console, _, ui, consoleErr := mock.NewVT10XConsole()
+					assert.Nil(t, consoleErr)
+					defer console.Close()
+
+					doneCh := make(chan (struct{}))
+					go func() {
+						defer close(doneCh)
+
+						console.ExpectString("Would you like to discard this draft?")
+						console.SendLine("")
+						console.ExpectEOF()
+					}()
+
+					draft, proceed, err := cli.CreateNewDraft(ui, realmClient, "", "")
+
+					console.Tty().Close() // flush the writers
+					<-doneCh              // wait for procedure to complete
+
+					assert.Nil(t, err)
+					assert.Equal(t, realm.AppDraft{}, draft)
+					assert.False(t, proceed, "expected draft to be rejected")
+				})
+
+				t.Run("and return a newly created draft if the user selects yes", func(t *testing.T) {
+					testDraft := realm.AppDraft{ID: "id"}
+
+					realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
+						return nil
+					}
+
+					realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+						return testDraft, nil
+					}
+
+					_, console, _, ui, consoleErr := mock.NewVT10XConsole()
+					assert.Nil(t, consoleErr)
+					defer console.Close()
+
+					doneCh := make(chan (struct{}))
+					go func() {
+						defer close(doneCh)
+
+						console.ExpectString("Would you like to discard this draft?")
+						console.SendLine("y")
+						console.ExpectEOF()
+					}()
+
+					draft, proceed, err := cli.CreateNewDraft(ui, realmClient, "", "")
+
+					console.Tty().Close() // flush the writers
+					<-doneCh              // wait for procedure to complete
+
+					assert.Nil(t, err)
+					assert.Equal(t, testDraft, draft)
+					assert.True(t, proceed, "expected draft to be created successfully")
+				})
+			})
+		})
+	})
+}
+
+func TestDeployDraftAndWait(t *testing.T) {
+	groupID, appID, draftID := "groupID", "appID", "draftID"
+	t.Run("should return an error with a client that fails to deploy the draft", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+
+		var capturedGroupID, capturedAppID, capturedDraftID string
+		realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+			capturedGroupID = groupID
+			capturedAppID = appID
+			capturedDraftID = draftID
+			return realm.AppDeployment{}, errors.New("something bad happened")
+		}
+
+		err := cli.DeployDraftAndWait(nil, realmClient, groupID, appID, draftID)
+		assert.Equal(t, errors.New("something bad happened"), err)
+
+		t.Log("and should properly pass through the expected inputs")
+		assert.Equal(t, groupID, capturedGroupID)
+		assert.Equal(t, appID, capturedAppID)
+		assert.Equal(t, draftID, capturedDraftID)
+	})
+
+	t.Run("with a client that successfully deploys a draft", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+			return realm.AppDeployment{ID: "id", Status: realm.DeploymentStatusCreated}, nil
+		}
+
+		t.Run("but fails to get the deployment should return the error and keep the draft", func(t *testing.T) {
+			realmClient.DeploymentFn = func(groupID, appID, deploymentID string) (realm.AppDeployment, error) {
+				return realm.AppDeployment{}, errors.New("something bad happened")
+			}
+
+			var discarded bool
+			realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
+				discarded = true
+				return nil
+			}
+
+			out := new(bytes.Buffer)
+			ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+			err := cli.DeployDraftAndWait(ui, realmClient, groupID, appID, draftID)
+			assert.Equal(t, errors.New("something bad happened"), err)
+			assert.Equal(t, "", out.String())
+			assert.False(t, discarded, "expected draft to be kept")
+		})
+
+		t.Run("and successfully retrieves the deployment should eventually succeed", func(t *testing.T) {
+			var polls int
+
+			realmClient.DeploymentFn = func(groupID, appID, deploymentID string) (realm.AppDeployment, error) {
+				status := realm.DeploymentStatusPending
+				if polls > 1 {
+					status = realm.DeploymentStatusSuccessful
+				}
+				polls++
+				return realm.AppDeployment{ID: deploymentID, Status: status}, nil
+			}
+
+			out, ui := mock.NewUI()
+
+			err := cli.DeployDraftAndWait(ui, realmClient, groupID, appID, draftID)
+			assert.Nil(t, err)
+
+			assert.Equal(t, "Deployment complete\n", out.String())
+		})
+
+		t.Run("but the deployment fails", func(t *testing.T) {
+			realmClient.DeploymentFn = func(groupID, appID, deploymentID string) (realm.AppDeployment, error) {
+				return realm.AppDeployment{
+					ID:                 deploymentID,
+					Status:             realm.DeploymentStatusFailed,
+					StatusErrorMessage: "something went wrong",
+				}, nil
+			}
+
+			for _, tc := range []struct {
+				description      string
+				discardDraftErr  error
+				expectedContents string
+			}{
+				{
+					description: "should discard the draft and return the failure reason",
+					expectedContents: strings.Join([]string{
+						"Deployment failed: something went wrong",
+						"",
+					}, "\n"),
+				},
+				{
+					description:     "and fails to discard the draft should print a warning message and return the failure reason",
+					discardDraftErr: errors.New("failed to discard draft"),
+					expectedContents: strings.Join([]string{
+						"Deployment failed: something went wrong",
+						"Failed to discard the draft created for your deployment",
+						"",
+					}, "\n"),
+				},
+			} {
+				t.Run(tc.description, func(t *testing.T) {
+					var capturedDraftID string
+					realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
+						capturedDraftID = draftID
+						return tc.discardDraftErr
+					}
+
+					out := new(bytes.Buffer)
+					ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+					err := cli.DeployDraftAndWait(ui, realmClient, groupID, appID, draftID)
+					assert.Equal(t, cli.ErrDeploymentFailed{DeploymentID: "id", Reason: "something went wrong"}, err)
+					assert.Equal(t, tc.expectedContents, out.String())
+					assert.Equal(t, draftID, capturedDraftID)
+				})
+			}
+
+			t.Run("should keep the draft if the user declines to discard it", func(t *testing.T) {
+				var discarded bool
+				realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
+					discarded = true
+					return nil
+				}
+
+				_, console, _, ui, consoleErr := mock.NewVT10XConsole()
+				assert.Nil(t, consoleErr)
+				defer console.Close()
+
+				doneCh := make(chan struct{})
+				go func() {
+					defer close(doneCh)
+					console.ExpectString("Would you like to discard the draft created for your deployment?")
+					console.SendLine("n")
+					console.ExpectEOF()
+				}()
+
+				err := cli.DeployDraftAndWait(ui, realmClient, groupID, appID, draftID)
+
+				console.Tty().Close() // flush the writers
+				<-doneCh              // wait for procedure to complete
+
+				assert.Equal(t, cli.ErrDeploymentFailed{DeploymentID: "id", Reason: "something went wrong"}, err)
+				assert.False(t, discarded, "expected draft to be kept")
+			})
+		})
+	})
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// set of known workspace errors
+var (
+	ErrWorkspaceNotFound = errors.New("failed to find a " + local.FileWorkspace.String() + " workspace manifest")
+	ErrWorkspaceConfirm  = errors.New("cannot confirm changes while running over every app in the workspace, re-run with '-y' to auto-confirm them")
+
+	errWorkspacePrompt = errors.New("cannot prompt for input while running over every app in the workspace")
+)
+
+// ErrWorkspaceFailed is a workspace run error where one or more apps have failed
+type ErrWorkspaceFailed struct {
+	Failed int
+	Total  int
+}
+
+func (err ErrWorkspaceFailed) Error() string {
+	return fmt.Sprintf("%d of %d workspace app(s) failed", err.Failed, err.Total)
+}
+
+// DisableUsage disables the usage printing when an error occurs
+func (err ErrWorkspaceFailed) DisableUsage() struct{} { return struct{}{} }
+
+// ErrWorkspaceAppNotLinked is a workspace app error where the remote app cannot be determined
+type ErrWorkspaceAppNotLinked struct {
+	Path string
+}
+
+func (err ErrWorkspaceAppNotLinked) Error() string {
+	return fmt.Sprintf("failed to determine the remote app of workspace app '%s', set its \"app\" in the workspace manifest", err.Path)
+}
+
+// LoadWorkspace finds the workspace the provided working directory is part of
+func LoadWorkspace(wd string) (local.Workspace, error) {
+	workspace, ok, err := local.FindWorkspace(wd)
+	if err != nil {
+		return local.Workspace{}, err
+	}
+	if !ok {
+		return local.Workspace{}, ErrWorkspaceNotFound
+	}
+	return workspace, nil
+}
+
+// RunWorkspace runs the provided func over every app of the workspace, with at most the
+// workspace's max concurrency running at once. Each app's output is buffered and printed
+// in manifest order, followed by a summary of the results of every app
+func RunWorkspace(ui terminal.UI, workspace local.Workspace, summary string, run func(ui terminal.UI, app local.WorkspaceApp) error) error {
+	type result struct {
+		ui  *workspaceUI
+		err error
+	}
+
+	results := make([]chan result, len(workspace.Apps))
+	for i := range results {
+		results[i] = make(chan result, 1)
+	}
+
+	sem := make(chan struct{}, workspace.MaxConcurrency())
+	for i, app := range workspace.Apps {
+		go func(app local.WorkspaceApp, resultCh chan<- result) {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			appUI := &workspaceUI{autoConfirm: ui.AutoConfirm()}
+			resultCh <- result{appUI, run(appUI, app)}
+		}(app, results[i])
+	}
+
+	rows := make([]map[string]interface{}, 0, len(workspace.Apps))
+
+	var failed int
+	for i, app := range workspace.Apps {
+		res := <-results[i]
+
+		ui.Print(terminal.NewTextLog("Workspace app: %s", app.Path))
+		ui.Print(res.ui.logs...)
+
+		status := "ok"
+		if res.err != nil {
+			failed++
+			status = res.err.Error()
+		}
+		rows = append(rows, map[string]interface{}{"App": app.Path, "Result": status})
+	}
+
+	ui.Print(terminal.NewTableLog(summary, []string{"App", "Result"}, rows...))
+
+	if failed > 0 {
+		return ErrWorkspaceFailed{failed, len(workspace.Apps)}
+	}
+	return nil
+}
+
+// workspaceUI is the terminal UI an app is run with as part of a workspace run,
+// which holds onto the app's output so it is not interleaved with that of other apps.
+// Apps cannot prompt for input, so any confirmation fails unless it is auto-confirmed
+type workspaceUI struct {
+	autoConfirm bool
+	logs        []terminal.Log
+}
+
+func (ui *workspaceUI) AutoConfirm() bool {
+	return ui.autoConfirm
+}
+
+func (ui *workspaceUI) Ask(answer interface{}, questions ...*survey.Question) error {
+	return errWorkspacePrompt
+}
+
+func (ui *workspaceUI) AskOne(answer interface{}, prompt survey.Prompt) error {
+	return errWorkspacePrompt
+}
+
+func (ui *workspaceUI) Confirm(format string, args ...interface{}) (bool, error) {
+	if !ui.autoConfirm {
+		return false, ErrWorkspaceConfirm
+	}
+	return true, nil
+}
+
+func (ui *workspaceUI) Print(logs ...terminal.Log) {
+	ui.logs = append(ui.logs, logs...)
+}
+
+// Buffered silences any spinners, as the app's output is held onto
+func (ui *workspaceUI) Buffered() bool {
+	return true
+}
@@ -0,0 +1,145 @@
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestLoadWorkspace(t *testing.T) {
+	t.Run("should load the workspace the working directory is part of", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("workspace")
+		assert.Nil(t, err)
+		defer teardown()
+
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, local.FileWorkspace.String()), []byte(`{"apps":[{"path":"auth"}]}`), 0666))
+
+		workspace, err := cli.LoadWorkspace(tmpDir)
+		assert.Nil(t, err)
+		assert.Equal(t, local.Workspace{RootDir: tmpDir, Apps: []local.WorkspaceApp{{Path: "auth"}}}, workspace)
+	})
+
+	t.Run("should return an error when there is no workspace manifest", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("workspace")
+		assert.Nil(t, err)
+		defer teardown()
+
+		_, err = cli.LoadWorkspace(tmpDir)
+		assert.Equal(t, cli.ErrWorkspaceNotFound, err)
+	})
+}
+
+func TestRunWorkspace(t *testing.T) {
+	workspace := local.Workspace{
+		Concurrency: 2,
+		Apps:        []local.WorkspaceApp{{Path: "auth"}, {Path: "sync"}, {Path: "web"}},
+	}
+
+	t.Run("should print the output of every app in order followed by a summary", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		err := cli.RunWorkspace(ui, workspace, "Ran workspace apps", func(ui terminal.UI, app local.WorkspaceApp) error {
+			if app.Path == "auth" {
+				// finish the first app last to ensure output is still ordered
+				time.Sleep(10 * time.Millisecond)
+			}
+			ui.Print(terminal.NewTextLog("Ran %s", app.Path))
+			if app.Path == "sync" {
+				return errors.New("something bad happened")
+			}
+			return nil
+		})
+		assert.Equal(t, cli.ErrWorkspaceFailed{Failed: 1, Total: 3}, err)
+		assert.Equal(t, "1 of 3 workspace app(s) failed", err.Error())
+
+		assert.Equal(t, `Workspace app: auth
+Ran auth
+Workspace app: sync
+Ran sync
+Workspace app: web
+Ran web
+Ran workspace apps
+  App   Result                
+  ----  ----------------------
+  auth  ok                    
+  sync  something bad happened
+  web   ok                    
+`, out.String())
+	})
+
+	t.Run("should run no more apps at once than the workspace concurrency", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		var mu sync.Mutex
+		var running, maxRunning int
+
+		err := cli.RunWorkspace(ui, workspace, "Ran workspace apps", func(ui terminal.UI, app local.WorkspaceApp) error {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.True(t, maxRunning <= 2, "expected at most 2 apps to run at once, but %d did", maxRunning)
+	})
+
+	t.Run("should not allow apps to prompt for input", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		err := cli.RunWorkspace(ui, local.Workspace{Apps: []local.WorkspaceApp{{Path: "auth"}}}, "Ran workspace apps", func(ui terminal.UI, app local.WorkspaceApp) error {
+			var answer string
+			return ui.AskOne(&answer, nil)
+		})
+		assert.Equal(t, cli.ErrWorkspaceFailed{Failed: 1, Total: 1}, err)
+	})
+	t.Run("should only allow apps to confirm when auto-confirm is set", func(t *testing.T) {
+		for _, tc := range []struct {
+			autoConfirm bool
+			expectedErr error
+		}{
+			{autoConfirm: false, expectedErr: cli.ErrWorkspaceConfirm},
+			{autoConfirm: true},
+		} {
+			ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: tc.autoConfirm}, new(bytes.Buffer))
+
+			var proceed bool
+			var confirmErr error
+			assert.Nil(t, cli.RunWorkspace(ui, local.Workspace{Apps: []local.WorkspaceApp{{Path: "auth"}}}, "Ran workspace apps", func(ui terminal.UI, app local.WorkspaceApp) error {
+				proceed, confirmErr = ui.Confirm("Are you sure?")
+				return nil
+			}))
+			assert.Equal(t, tc.expectedErr, confirmErr)
+			assert.Equal(t, tc.autoConfirm, proceed)
+		}
+	})
+
+	t.Run("should silence the spinners of apps", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		assert.Nil(t, cli.RunWorkspace(ui, local.Workspace{Apps: []local.WorkspaceApp{{Path: "auth"}}}, "Ran workspace apps", func(ui terminal.UI, app local.WorkspaceApp) error {
+			assert.True(t, terminal.NewSpinner(ui, "Running...").Writer == ioutil.Discard, "expected the spinner to be silenced")
+			return nil
+		}))
+		assert.False(t, terminal.NewSpinner(ui, "Running...").Writer == ioutil.Discard, "expected the spinner to write to the terminal")
+	})
+}
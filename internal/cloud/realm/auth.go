@@ -74,7 +74,7 @@ func (c *client) AuthProfile() (AuthProfile, error) {
 }
 
 func (c *client) getAuthToken(options api.RequestOptions) (string, error) {
-	session := c.authService.Session()
+	session := c.session()
 
 	if options.RefreshAuth {
 		if session.RefreshToken == "" {
@@ -93,11 +93,20 @@ func (c *client) getAuthToken(options api.RequestOptions) (string, error) {
 	return "", nil
 }
 
-func (c *client) refreshAuth() error {
+// refreshAuth refreshes the session whose access token was found to be expired,
+// unless another request has refreshed it in the meantime
+func (c *client) refreshAuth(expiredToken string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	if c.session().AccessToken != expiredToken {
+		return nil
+	}
+
 	res, resErr := c.do(
 		http.MethodPost,
 		authSessionPath,
-		api.RequestOptions{RefreshAuth: true},
+		api.RequestOptions{RefreshAuth: true, PreventRefresh: true},
 	)
 	if resErr != nil {
 		return resErr
@@ -112,6 +121,9 @@ func (c *client) refreshAuth() error {
 		return err
 	}
 
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
 	session := c.authService.Session()
 	session.AccessToken = s.AccessToken
 	c.authService.SetSession(session)
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"sync"
 
 	"github.com/10gen/realm-cli/internal/auth"
 	"github.com/10gen/realm-cli/internal/utils/api"
@@ -42,6 +43,7 @@ type Client interface {
 	DiscardDraft(groupID, appID, draftID string) error
 	Deployments(groupID, appID string) ([]AppDeployment, error)
 	Deployment(groupID, appID, deploymentID string) (AppDeployment, error)
+	RedeployDeployment(groupID, appID, deploymentID string) error
 	Draft(groupID, appID string) (AppDraft, error)
 
 	Secrets(groupID, appID string) ([]Secret, error)
@@ -49,6 +51,12 @@ type Client interface {
 	DeleteSecret(groupID, appID, secretID string) error
 	UpdateSecret(groupID, appID, secretID, name, value string) error
 
+	Values(groupID, appID string) ([]Value, error)
+	Value(groupID, appID, valueID string) (Value, error)
+	CreateValue(groupID, appID string, value Value) (Value, error)
+	UpdateValue(groupID, appID string, value Value) error
+	DeleteValue(groupID, appID, valueID string) error
+
 	CreateAPIKey(groupID, appID, apiKeyName string) (APIKey, error)
 	CreateUser(groupID, appID, email, password string) (User, error)
 	DeleteUser(groupID, appID, userID string) error
@@ -65,23 +73,29 @@ type Client interface {
 
 	Functions(groupID, appID string) ([]Function, error)
 	AppDebugExecuteFunction(groupID, appID, userID, name string, args []interface{}) (ExecutionResults, error)
+	AppDebugExecuteFunctionSource(groupID, appID, userID, source, evalSource string) (ExecutionResults, error)
 
 	Status() error
 }
 
 // NewClient creates a new Realm client
 func NewClient(baseURL string) Client {
-	return &client{baseURL, noopAuth{}}
+	return &client{baseURL: baseURL, authService: noopAuth{}}
 }
 
 // NewAuthClient creates a new Realm client capable of managing the user's session
 func NewAuthClient(baseURL string, authService auth.Service) Client {
-	return &client{baseURL, authService}
+	return &client{baseURL: baseURL, authService: authService}
 }
 
 type client struct {
 	baseURL     string
 	authService auth.Service
+
+	// the client may be shared by concurrent requests, so access to the auth service
+	// is guarded by authMu, and refreshMu ensures an expired session is refreshed only once
+	authMu    sync.RWMutex
+	refreshMu sync.Mutex
 }
 
 func (c *client) doJSON(method, path string, payload interface{}, options api.RequestOptions) (*http.Response, error) {
@@ -110,9 +124,11 @@ func (c *client) do(method, path string, options api.RequestOptions) (*http.Resp
 		req.Header.Set(api.HeaderContentType, options.ContentType)
 	}
 
-	if token, err := c.getAuthToken(options); err != nil {
+	token, err := c.getAuthToken(options)
+	if err != nil {
 		return nil, err
-	} else if token != "" {
+	}
+	if token != "" {
 		req.Header.Set(api.HeaderAuthorization, "Bearer "+token)
 	}
 
@@ -135,7 +151,10 @@ func (c *client) do(method, path string, options api.RequestOptions) (*http.Resp
 		return nil, err
 	}
 
-	if refreshErr := c.refreshAuth(); refreshErr != nil {
+	if refreshErr := c.refreshAuth(token); refreshErr != nil {
+		c.authMu.Lock()
+		defer c.authMu.Unlock()
+
 		c.authService.ClearSession()
 		if err := c.authService.Save(); err != nil {
 			return nil, ErrInvalidSession{}
@@ -148,6 +167,13 @@ func (c *client) do(method, path string, options api.RequestOptions) (*http.Resp
 	return c.do(method, path, options)
 }
 
+func (c *client) session() auth.Session {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+
+	return c.authService.Session()
+}
+
 type noopAuth struct{}
 
 func (sm noopAuth) ClearSession() {}
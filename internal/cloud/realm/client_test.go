@@ -0,0 +1,112 @@
+package realm_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/auth"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestRealmClientRefreshAuth(t *testing.T) {
+	t.Run("should refresh an expired session once when shared by concurrent requests", func(t *testing.T) {
+		var mu sync.Mutex
+		var refreshes int
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/admin/v3.0/auth/session" {
+				mu.Lock()
+				refreshes++
+				mu.Unlock()
+
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"access_token":"fresh"}`)) //nolint:errcheck
+				return
+			}
+
+			if r.Header.Get("Authorization") != "Bearer fresh" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error":"invalid session","error_code":"InvalidSession"}`)) //nolint:errcheck
+				return
+			}
+			w.Write([]byte(`[]`)) //nolint:errcheck
+		}))
+		defer server.Close()
+
+		authService := &unsafeAuthService{session: map[string]string{"access_token": "expired", "refresh_token": "refresh"}}
+		client := realm.NewAuthClient(server.URL, authService)
+
+		var wg sync.WaitGroup
+		errs := make([]error, 8)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, errs[i] = client.Functions("groupID", "appID")
+			}(i)
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, 1, refreshes)
+		assert.Equal(t, 1, authService.saves)
+		assert.Equal(t, auth.Session{AccessToken: "fresh", RefreshToken: "refresh"}, authService.Session())
+	})
+
+	t.Run("should clear the session rather than hang when the refresh token has expired", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid session","error_code":"InvalidSession"}`)) //nolint:errcheck
+		}))
+		defer server.Close()
+
+		authService := &unsafeAuthService{session: map[string]string{"access_token": "expired", "refresh_token": "expired"}}
+		client := realm.NewAuthClient(server.URL, authService)
+
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := client.Functions("groupID", "appID")
+			errCh <- err
+		}()
+
+		select {
+		case err := <-errCh:
+			assert.Equal(t, realm.ErrInvalidSession{}, err)
+			assert.Equal(t, auth.Session{}, authService.Session())
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the request to fail once the session cannot be refreshed")
+		}
+	})
+}
+
+// unsafeAuthService is an auth service which, like the CLI profile, is not safe for concurrent use
+type unsafeAuthService struct {
+	session map[string]string
+	saves   int
+}
+
+func (s *unsafeAuthService) ClearSession() { s.session = map[string]string{} }
+
+func (s *unsafeAuthService) Save() error {
+	s.saves++
+	return nil
+}
+
+func (s *unsafeAuthService) Session() auth.Session {
+	return auth.Session{AccessToken: s.session["access_token"], RefreshToken: s.session["refresh_token"]}
+}
+
+func (s *unsafeAuthService) SetSession(session auth.Session) {
+	s.session["access_token"] = session.AccessToken
+	s.session["refresh_token"] = session.RefreshToken
+}
+
+func (s *unsafeAuthService) User() auth.User { return auth.User{} }
+
+func (s *unsafeAuthService) SetUser(user auth.User) {}
@@ -9,14 +9,23 @@ import (
 )
 
 const (
-	deploymentsPathPattern = appPathPattern + "/deployments"
-	deploymentPathPattern  = deploymentsPathPattern + "/%s"
+	deploymentsPathPattern        = appPathPattern + "/deployments"
+	deploymentPathPattern         = deploymentsPathPattern + "/%s"
+	deploymentRedeployPathPattern = deploymentPathPattern + "/redeploy"
 )
 
 // AppDeployment is a Realm app deployment
 type AppDeployment struct {
-	ID     string           `json:"_id"`
-	Status DeploymentStatus `json:"status"`
+	ID                 string           `json:"_id"`
+	Name               string           `json:"name,omitempty"`
+	AppID              string           `json:"app_id,omitempty"`
+	DraftID            string           `json:"draft_id,omitempty"`
+	UserID             string           `json:"user_id,omitempty"`
+	Origin             string           `json:"origin,omitempty"`
+	Commit             string           `json:"commit,omitempty"`
+	DeployedAt         int64            `json:"deployed_at,omitempty"`
+	Status             DeploymentStatus `json:"status"`
+	StatusErrorMessage string           `json:"status_error_message,omitempty"`
 }
 
 // DeploymentStatus is the Realm application deployment status
@@ -71,3 +80,18 @@ func (c *client) Deployment(groupID, appID, deploymentID string) (AppDeployment,
 	}
 	return deployment, nil
 }
+
+func (c *client) RedeployDeployment(groupID, appID, deploymentID string) error {
+	res, resErr := c.do(
+		http.MethodPost,
+		fmt.Sprintf(deploymentRedeployPathPattern, groupID, appID, deploymentID),
+		api.RequestOptions{},
+	)
+	if resErr != nil {
+		return resErr
+	}
+	if res.StatusCode != http.StatusNoContent {
+		return api.ErrUnexpectedStatusCode{Action: "redeploy deployment", Actual: res.StatusCode}
+	}
+	return nil
+}
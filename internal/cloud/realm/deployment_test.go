@@ -20,6 +20,13 @@ func TestRealmDeployments(t *testing.T) {
 		assert.Equal(t, realm.ErrInvalidSession{}, err)
 	})
 
+	t.Run("should fail to redeploy without an auth client", func(t *testing.T) {
+		client := realm.NewClient(u.RealmServerURL())
+
+		err := client.RedeployDeployment(primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex())
+		assert.Equal(t, realm.ErrInvalidSession{}, err)
+	})
+
 	t.Run("with an active session", func(t *testing.T) {
 		client := newAuthClient(t)
 		groupID := u.CloudGroupID()
@@ -6,6 +6,46 @@ import (
 	"github.com/10gen/realm-cli/internal/terminal"
 )
 
+// DiffOperation is the operation a Realm app diff performs
+type DiffOperation string
+
+// set of supported diff operations
+const (
+	DiffOperationAdded    DiffOperation = "added"
+	DiffOperationRemoved  DiffOperation = "removed"
+	DiffOperationModified DiffOperation = "modified"
+)
+
+// set of diff components which live outside of the Realm app data,
+// or which are reported by the server without a specific component
+const (
+	DiffComponentApp          = "app"
+	DiffComponentDependencies = "dependencies"
+	DiffComponentHosting      = "hosting"
+)
+
+// Diff is a single change made to a component of a Realm app
+type Diff struct {
+	Component string        `json:"component"`
+	Operation DiffOperation `json:"operation"`
+	Path      string        `json:"path"`
+	Before    interface{}   `json:"before,omitempty"`
+	After     interface{}   `json:"after,omitempty"`
+}
+
+func (d Diff) String() string {
+	var symbol string
+	switch d.Operation {
+	case DiffOperationAdded:
+		symbol = "+"
+	case DiffOperationRemoved:
+		symbol = "-"
+	default:
+		symbol = "*"
+	}
+	return symbol + " " + d.Path
+}
+
 // AppDraftDiff are the diffs for a Realm app draft and its corresponding app
 type AppDraftDiff struct {
 	Diffs             []string          `json:"diffs"`
@@ -92,6 +132,37 @@ func (d DependenciesDiff) DiffList() []interface{} {
 	return diffs
 }
 
+// Diffs returns the diffs as a list of structured diffs
+func (d DependenciesDiff) Diffs() []Diff {
+	diffs := make([]Diff, 0, d.Len())
+	for _, dep := range d.Added {
+		diffs = append(diffs, Diff{
+			Component: DiffComponentDependencies,
+			Operation: DiffOperationAdded,
+			Path:      dep.Name,
+			After:     dep.Version,
+		})
+	}
+	for _, dep := range d.Deleted {
+		diffs = append(diffs, Diff{
+			Component: DiffComponentDependencies,
+			Operation: DiffOperationRemoved,
+			Path:      dep.Name,
+			Before:    dep.Version,
+		})
+	}
+	for _, dep := range d.Modified {
+		diffs = append(diffs, Diff{
+			Component: DiffComponentDependencies,
+			Operation: DiffOperationModified,
+			Path:      dep.Name,
+			Before:    dep.PreviousVersion,
+			After:     dep.Version,
+		})
+	}
+	return diffs
+}
+
 // Cap returns the dependencies diffs' total capacity
 func (d DependenciesDiff) Cap() int {
 	return d.Len() + 3
@@ -0,0 +1,36 @@
+package realm
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestDependenciesDiffDiffs(t *testing.T) {
+	diff := DependenciesDiff{
+		Added:    []DependencyData{{"twilio", "3.35.1"}},
+		Deleted:  []DependencyData{{"debug", "4.3.1"}},
+		Modified: []DependencyDiffData{{DependencyData: DependencyData{"underscore", "1.9.2"}, PreviousVersion: "1.9.1"}},
+	}
+
+	assert.Equal(t, []Diff{
+		{Component: DiffComponentDependencies, Operation: DiffOperationAdded, Path: "twilio", After: "3.35.1"},
+		{Component: DiffComponentDependencies, Operation: DiffOperationRemoved, Path: "debug", Before: "4.3.1"},
+		{Component: DiffComponentDependencies, Operation: DiffOperationModified, Path: "underscore", Before: "1.9.1", After: "1.9.2"},
+	}, diff.Diffs())
+}
+
+func TestDiffString(t *testing.T) {
+	for _, tc := range []struct {
+		diff     Diff
+		expected string
+	}{
+		{Diff{Operation: DiffOperationAdded, Path: "values/added"}, "+ values/added"},
+		{Diff{Operation: DiffOperationRemoved, Path: "values/removed"}, "- values/removed"},
+		{Diff{Operation: DiffOperationModified, Path: "values/modified/value"}, "* values/modified/value"},
+	} {
+		t.Run("should print a "+string(tc.diff.Operation)+" diff", func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.diff.String())
+		})
+	}
+}
@@ -10,8 +10,9 @@ import (
 
 // Routes for functions
 const (
-	FunctionsPattern               = appPathPattern + "/functions"
-	AppDebugExecuteFunctionPattern = appPathPattern + "/debug/execute_function"
+	FunctionsPattern                     = appPathPattern + "/functions"
+	AppDebugExecuteFunctionPattern       = appPathPattern + "/debug/execute_function"
+	AppDebugExecuteFunctionSourcePattern = appPathPattern + "/debug/execute_function_source"
 )
 
 type stats struct {
@@ -33,6 +34,30 @@ type Function struct {
 }
 
 func (c *client) AppDebugExecuteFunction(groupID, appID, userID, name string, args []interface{}) (ExecutionResults, error) {
+	return c.debugExecute(
+		fmt.Sprintf(AppDebugExecuteFunctionPattern, groupID, appID),
+		userID,
+		map[string]interface{}{
+			"name":      name,
+			"arguments": args,
+		},
+		"debug execute function",
+	)
+}
+
+func (c *client) AppDebugExecuteFunctionSource(groupID, appID, userID, source, evalSource string) (ExecutionResults, error) {
+	return c.debugExecute(
+		fmt.Sprintf(AppDebugExecuteFunctionSourcePattern, groupID, appID),
+		userID,
+		map[string]interface{}{
+			"source":      source,
+			"eval_source": evalSource,
+		},
+		"debug execute function source",
+	)
+}
+
+func (c *client) debugExecute(path, userID string, body interface{}, operation string) (ExecutionResults, error) {
 	query := map[string]string{}
 	if userID == "" {
 		query["run_as_system"] = "true"
@@ -41,18 +66,15 @@ func (c *client) AppDebugExecuteFunction(groupID, appID, userID, name string, ar
 	}
 	res, err := c.doJSON(
 		http.MethodPost,
-		fmt.Sprintf(AppDebugExecuteFunctionPattern, groupID, appID),
-		map[string]interface{}{
-			"name":      name,
-			"arguments": args,
-		},
+		path,
+		body,
 		api.RequestOptions{Query: query},
 	)
 	if err != nil {
 		return ExecutionResults{}, err
 	}
 	if res.StatusCode != http.StatusOK {
-		return ExecutionResults{}, api.ErrUnexpectedStatusCode{"debug execute function", res.StatusCode}
+		return ExecutionResults{}, api.ErrUnexpectedStatusCode{Action: operation, Actual: res.StatusCode}
 	}
 	defer res.Body.Close()
 
@@ -0,0 +1,65 @@
+package realm_test
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRealmValues(t *testing.T) {
+	u.SkipUnlessRealmServerRunning(t)
+
+	t.Run("should fail without an auth client", func(t *testing.T) {
+		client := realm.NewClient(u.RealmServerURL())
+
+		_, err := client.Values(primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex())
+		assert.Equal(t, realm.ErrInvalidSession{}, err)
+	})
+
+	t.Run("with an active session", func(t *testing.T) {
+		client := newAuthClient(t)
+		groupID := u.CloudGroupID()
+
+		testApp, teardown := setupTestApp(t, client, groupID, "values-test")
+		defer teardown()
+
+		t.Run("should have no values upon app initialization", func(t *testing.T) {
+			values, err := client.Values(groupID, testApp.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, 0, len(values))
+		})
+
+		t.Run("should create a value", func(t *testing.T) {
+			value, err := client.CreateValue(groupID, testApp.ID, realm.Value{Name: "valueName", Value: "eggcorn"})
+			assert.Nil(t, err)
+
+			t.Run("and get the app value", func(t *testing.T) {
+				found, err := client.Value(groupID, testApp.ID, value.ID)
+				assert.Nil(t, err)
+				assert.Equal(t, "valueName", found.Name)
+				assert.Equal(t, "eggcorn", found.Value)
+			})
+
+			t.Run("and should update the app value", func(t *testing.T) {
+				value.Value = map[string]interface{}{"eggcorn": true}
+				assert.Nil(t, client.UpdateValue(groupID, testApp.ID, value))
+
+				found, err := client.Value(groupID, testApp.ID, value.ID)
+				assert.Nil(t, err)
+				assert.Equal(t, map[string]interface{}{"eggcorn": true}, found.Value)
+			})
+
+			t.Run("and should delete the app value", func(t *testing.T) {
+				assert.Nil(t, client.DeleteValue(groupID, testApp.ID, value.ID))
+
+				values, err := client.Values(groupID, testApp.ID)
+				assert.Nil(t, err)
+				assert.Equal(t, 0, len(values))
+			})
+		})
+	})
+}
@@ -0,0 +1,234 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/atlas"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagCloneApp          = "app"
+	flagCloneAppUsage     = "the Realm app (name or id) to clone"
+	flagCloneProject      = "project"
+	flagCloneProjectUsage = "the MongoDB cloud project id to clone the Realm app into"
+	flagCloneName         = "name"
+	flagCloneNameShort    = "n"
+	flagCloneNameUsage    = "set the name of the cloned Realm app, defaults to the name of the Realm app being cloned"
+	flagCloneCluster      = "cluster"
+	flagCloneClusterUsage = "link a data source cluster to a cluster in the destination project, e.g. Cluster0=Sandbox"
+	flagCloneDryRun       = "dry-run"
+	flagCloneDryRunShort  = "x"
+	flagCloneDryRunUsage  = "include to show the Realm app that would be created without creating it"
+)
+
+type cloneInputs struct {
+	App      string
+	Project  string
+	Name     string
+	Clusters []string
+	DryRun   bool
+}
+
+func (i *cloneInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if i.App == "" {
+		if err := ui.AskOne(&i.App, &survey.Input{Message: "App Filter (clone from)"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clusterMapping parses the cluster flags into a mapping of source to destination cluster names
+func (i cloneInputs) clusterMapping() (map[string]string, error) {
+	mapping := make(map[string]string, len(i.Clusters))
+	for _, cluster := range i.Clusters {
+		parts := strings.SplitN(cluster, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid cluster link '%s', must be of the form <source>=<destination>", cluster)
+		}
+		mapping[parts[0]] = parts[1]
+	}
+	return mapping, nil
+}
+
+// CommandClone is the `app clone` command
+type CommandClone struct {
+	inputs cloneInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandClone) Flags(fs *pflag.FlagSet) {
+	fs.StringVar(&cmd.inputs.App, flagCloneApp, "", flagCloneAppUsage)
+	fs.StringVar(&cmd.inputs.Project, flagCloneProject, "", flagCloneProjectUsage)
+	fs.StringVarP(&cmd.inputs.Name, flagCloneName, flagCloneNameShort, "", flagCloneNameUsage)
+	fs.StringSliceVar(&cmd.inputs.Clusters, flagCloneCluster, []string{}, flagCloneClusterUsage)
+	fs.BoolVarP(&cmd.inputs.DryRun, flagCloneDryRun, flagCloneDryRunShort, false, flagCloneDryRunUsage)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandClone) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandClone) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	mapping, err := cmd.inputs.clusterMapping()
+	if err != nil {
+		return err
+	}
+
+	from, err := cli.ResolveApp(ui, clients.Realm, realm.AppFilter{App: cmd.inputs.App})
+	if err != nil {
+		return err
+	}
+
+	groupID := cmd.inputs.Project
+	if groupID == "" {
+		id, err := cli.ResolveGroupID(ui, clients.Atlas)
+		if err != nil {
+			return err
+		}
+		groupID = id
+	}
+
+	name := cmd.inputs.Name
+	if name == "" {
+		name = from.Name
+	}
+
+	_, zipPkg, err := clients.Realm.Export(from.GroupID, from.ID, realm.ExportRequest{IsTemplated: true})
+	if err != nil {
+		return err
+	}
+
+	appData, err := local.ParseAppZip(zipPkg)
+	if err != nil {
+		return err
+	}
+
+	clusters, err := clients.Atlas.Clusters(groupID)
+	if err != nil {
+		return err
+	}
+
+	links, err := resolveClusterLinks(ui, groupID, local.DataSourceClusters(appData), clusters, mapping)
+	if err != nil {
+		return err
+	}
+
+	linkItems := make([]interface{}, 0, len(links))
+	for _, cluster := range local.DataSourceClusters(appData) {
+		linkItems = append(linkItems, fmt.Sprintf("%s%s%s", cluster, terminal.DelimiterInline, links[cluster]))
+	}
+
+	if cmd.inputs.DryRun {
+		logs := []terminal.Log{
+			terminal.NewTextLog("A Realm app based on the Realm app '%s' would be created in project %s", from.ClientAppID, groupID),
+		}
+		if len(linkItems) > 0 {
+			logs = append(logs, terminal.NewListLog("The data source clusters would be linked as follows", linkItems...))
+		}
+		ui.Print(logs...)
+		return nil
+	}
+
+	to, err := clients.Realm.CreateApp(groupID, name, realm.AppMeta{Location: from.Location, DeploymentModel: from.DeploymentModel})
+	if err != nil {
+		return err
+	}
+
+	if err := importClone(ui, clients.Realm, to, appData, links); err != nil {
+		// the clone is incomplete, so the app created for it is deleted rather than left behind
+		if deleteErr := clients.Realm.DeleteApp(to.GroupID, to.ID); deleteErr != nil {
+			return fmt.Errorf("%w, and failed to delete the app '%s' created for the clone: %s", err, to.ClientAppID, deleteErr)
+		}
+		return err
+	}
+
+	headers := []string{"Info", "Details"}
+	rows := make([]map[string]interface{}, 0, 3+len(links))
+	rows = append(rows, map[string]interface{}{"Info": "Client App ID", "Details": to.ClientAppID})
+	rows = append(rows, map[string]interface{}{"Info": "Project", "Details": to.GroupID})
+	rows = append(rows, map[string]interface{}{"Info": "Realm UI", "Details": fmt.Sprintf("%s/groups/%s/apps/%s/dashboard", profile.RealmBaseURL(), to.GroupID, to.ID)})
+	for _, item := range linkItems {
+		rows = append(rows, map[string]interface{}{"Info": "Data Source (Cluster)", "Details": item})
+	}
+
+	ui.Print(terminal.NewTableLog(fmt.Sprintf("Successfully cloned %s", from.ClientAppID), headers, rows...))
+	return nil
+}
+
+// importClone imports the app data into a draft of the app created for the clone, with its
+// data sources linked to the clusters, and deploys it
+func importClone(ui terminal.UI, realmClient realm.Client, to realm.App, appData local.AppData, links map[string]string) error {
+	if err := local.PromoteAppData(appData, local.AppPromotion{Target: to, KeepEnvironment: true, Clusters: links}); err != nil {
+		return err
+	}
+
+	draft, proceed, err := cli.CreateNewDraft(ui, realmClient, to.GroupID, to.ID)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return errors.New("failed to create a draft for the clone")
+	}
+
+	if err := realmClient.Import(to.GroupID, to.ID, appData); err != nil {
+		return err
+	}
+	return cli.DeployDraftAndWait(ui, realmClient, to.GroupID, to.ID, draft.ID)
+}
+
+// resolveClusterLinks determines the destination project cluster each data source cluster
+// is linked to, falling back to a cluster of the same name or the project's only cluster
+// before prompting for one
+func resolveClusterLinks(ui terminal.UI, groupID string, sources []string, clusters []atlas.Cluster, mapping map[string]string) (map[string]string, error) {
+	clusterNames := make([]string, 0, len(clusters))
+	clusterSet := make(map[string]struct{}, len(clusters))
+	for _, cluster := range clusters {
+		clusterNames = append(clusterNames, cluster.Name)
+		clusterSet[cluster.Name] = struct{}{}
+	}
+
+	links := make(map[string]string, len(sources))
+	for _, source := range sources {
+		if target, ok := mapping[source]; ok {
+			if _, ok := clusterSet[target]; !ok {
+				return nil, fmt.Errorf("failed to find cluster '%s' in project %s", target, groupID)
+			}
+			links[source] = target
+			continue
+		}
+
+		if _, ok := clusterSet[source]; ok {
+			links[source] = source
+			continue
+		}
+
+		switch len(clusterNames) {
+		case 0:
+			return nil, fmt.Errorf("failed to find a cluster in project %s to link data source cluster '%s' to", groupID, source)
+		case 1:
+			links[source] = clusterNames[0]
+		default:
+			var target string
+			if err := ui.AskOne(&target, &survey.Select{
+				Message: fmt.Sprintf("Which cluster would you like to link data source cluster '%s' to?", source),
+				Options: clusterNames,
+			}); err != nil {
+				return nil, err
+			}
+			links[source] = target
+		}
+	}
+	return links, nil
+}
@@ -0,0 +1,247 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/atlas"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestAppCloneHandler(t *testing.T) {
+	srcApp := realm.App{
+		ID:          "srcID",
+		GroupID:     "srcGroupID",
+		ClientAppID: "eggcorn-abcde",
+		Name:        "eggcorn",
+		AppMeta:     realm.AppMeta{Location: realm.LocationIreland, DeploymentModel: realm.DeploymentModelLocal},
+	}
+	newApp := realm.App{
+		ID:          "newID",
+		GroupID:     "dstGroupID",
+		ClientAppID: "eggcorn-fghij",
+		Name:        "eggcorn",
+		AppMeta:     srcApp.AppMeta,
+	}
+
+	type createCall struct {
+		GroupID string
+		Name    string
+		Meta    realm.AppMeta
+	}
+
+	setupClients := func(clusters []atlas.Cluster, created *createCall, imported *local.AppData) cli.Clients {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{srcApp}, nil
+		}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			if !req.IsTemplated {
+				return "", nil, errors.New("expected a templated export")
+			}
+			zipPkg, err := u.NewZipReader(map[string]string{
+				"realm_config.json":                      `{"config_version":20210101,"app_id":"eggcorn-abcde","name":"eggcorn","location":"IE","deployment_model":"LOCAL"}`,
+				"data_sources/mongodb-atlas/config.json": `{"name":"mongodb-atlas","type":"mongodb-atlas","config":{"clusterName":"Cluster0"}}`,
+			})
+			return "eggcorn", zipPkg, err
+		}
+		realmClient.CreateAppFn = func(groupID, name string, meta realm.AppMeta) (realm.App, error) {
+			*created = createCall{groupID, name, meta}
+			return newApp, nil
+		}
+		realmClient.ImportFn = func(groupID, appID string, appData interface{}) error {
+			*imported = appData.(local.AppData)
+			return nil
+		}
+		realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return realm.AppDraft{ID: "draftID"}, nil
+		}
+		realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+			return realm.AppDeployment{ID: "deploymentID", Status: realm.DeploymentStatusSuccessful}, nil
+		}
+
+		atlasClient := mock.AtlasClient{}
+		atlasClient.ClustersFn = func(groupID string) ([]atlas.Cluster, error) {
+			return clusters, nil
+		}
+
+		return cli.Clients{Realm: realmClient, Atlas: atlasClient}
+	}
+
+	for _, tc := range []struct {
+		description     string
+		clusters        []atlas.Cluster
+		inputs          cloneInputs
+		expectedCluster string
+	}{
+		{
+			description:     "should keep a data source linked to a cluster of the same name",
+			clusters:        []atlas.Cluster{{Name: "Cluster0"}, {Name: "Cluster1"}},
+			inputs:          cloneInputs{App: "eggcorn", Project: "dstGroupID"},
+			expectedCluster: "Cluster0",
+		},
+		{
+			description:     "should link a data source to the only cluster in the project",
+			clusters:        []atlas.Cluster{{Name: "Sandbox"}},
+			inputs:          cloneInputs{App: "eggcorn", Project: "dstGroupID"},
+			expectedCluster: "Sandbox",
+		},
+		{
+			description:     "should link a data source to the cluster set by flag",
+			clusters:        []atlas.Cluster{{Name: "Cluster0"}, {Name: "Sandbox"}},
+			inputs:          cloneInputs{App: "eggcorn", Project: "dstGroupID", Clusters: []string{"Cluster0=Sandbox"}},
+			expectedCluster: "Sandbox",
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			profile := mock.NewProfile(t)
+
+			var created createCall
+			var imported local.AppData
+
+			out, ui := mock.NewUI()
+
+			cmd := &CommandClone{tc.inputs}
+			assert.Nil(t, cmd.Handler(profile, ui, setupClients(tc.clusters, &created, &imported)))
+
+			assert.Equal(t, createCall{"dstGroupID", "eggcorn", srcApp.AppMeta}, created)
+
+			v2, ok := imported.(*local.AppRealmConfigJSON)
+			assert.True(t, ok, "expected v2 app data to be imported")
+			assert.Equal(t, "eggcorn-fghij", v2.ID())
+			assert.Equal(t, map[string]interface{}{"clusterName": tc.expectedCluster}, v2.DataSources[0].Config["config"])
+
+			assert.True(t, strings.HasPrefix(out.String(), "Deployment complete\nSuccessfully cloned eggcorn-abcde\n"), "unexpected output: %s", out.String())
+			assert.True(t, strings.Contains(out.String(), "/groups/dstGroupID/apps/newID/dashboard"), "unexpected output: %s", out.String())
+			assert.True(t, strings.Contains(out.String(), "Cluster0 - "+tc.expectedCluster), "unexpected output: %s", out.String())
+		})
+	}
+
+	t.Run("should only show the app that would be created in a dry run", func(t *testing.T) {
+		var created createCall
+		var imported local.AppData
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandClone{cloneInputs{App: "eggcorn", Project: "dstGroupID", DryRun: true}}
+		assert.Nil(t, cmd.Handler(nil, ui, setupClients([]atlas.Cluster{{Name: "Sandbox"}}, &created, &imported)))
+
+		assert.Equal(t, `A Realm app based on the Realm app 'eggcorn-abcde' would be created in project dstGroupID
+The data source clusters would be linked as follows
+  Cluster0 - Sandbox
+`, out.String())
+		assert.Equal(t, createCall{}, created)
+		assert.Nil(t, imported)
+	})
+
+	t.Run("should return an error when the project has no clusters", func(t *testing.T) {
+		var created createCall
+		var imported local.AppData
+
+		_, ui := mock.NewUI()
+
+		cmd := &CommandClone{cloneInputs{App: "eggcorn", Project: "dstGroupID"}}
+
+		err := cmd.Handler(nil, ui, setupClients(nil, &created, &imported))
+		assert.Equal(t, errors.New("failed to find a cluster in project dstGroupID to link data source cluster 'Cluster0' to"), err)
+	})
+
+	t.Run("should return an error when the cluster set by flag does not exist", func(t *testing.T) {
+		var created createCall
+		var imported local.AppData
+
+		_, ui := mock.NewUI()
+
+		cmd := &CommandClone{cloneInputs{App: "eggcorn", Project: "dstGroupID", Clusters: []string{"Cluster0=Missing"}}}
+
+		err := cmd.Handler(nil, ui, setupClients([]atlas.Cluster{{Name: "Sandbox"}}, &created, &imported))
+		assert.Equal(t, errors.New("failed to find cluster 'Missing' in project dstGroupID"), err)
+	})
+
+	t.Run("should delete the created app when the import fails", func(t *testing.T) {
+		var created createCall
+		var imported local.AppData
+
+		_, ui := mock.NewUI()
+
+		clients := setupClients([]atlas.Cluster{{Name: "Cluster0"}}, &created, &imported)
+
+		realmClient := clients.Realm.(mock.RealmClient)
+		realmClient.ImportFn = func(groupID, appID string, appData interface{}) error {
+			return errors.New("something bad happened")
+		}
+
+		var deletedGroupID, deletedAppID string
+		realmClient.DeleteAppFn = func(groupID, appID string) error {
+			deletedGroupID, deletedAppID = groupID, appID
+			return nil
+		}
+		clients.Realm = realmClient
+
+		cmd := &CommandClone{cloneInputs{App: "eggcorn", Project: "dstGroupID"}}
+
+		err := cmd.Handler(nil, ui, clients)
+		assert.Equal(t, errors.New("something bad happened"), err)
+		assert.Equal(t, "dstGroupID", deletedGroupID)
+		assert.Equal(t, "newID", deletedAppID)
+
+		t.Run("and should report the created app when it cannot be deleted", func(t *testing.T) {
+			realmClient.DeleteAppFn = func(groupID, appID string) error {
+				return errors.New("failed to delete")
+			}
+			clients.Realm = realmClient
+
+			err := cmd.Handler(nil, ui, clients)
+			assert.Equal(t, "something bad happened, and failed to delete the app 'eggcorn-fghij' created for the clone: failed to delete", err.Error())
+		})
+	})
+
+	t.Run("should delete the created app when the deployment fails", func(t *testing.T) {
+		var created createCall
+		var imported local.AppData
+
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+		clients := setupClients([]atlas.Cluster{{Name: "Cluster0"}}, &created, &imported)
+
+		realmClient := clients.Realm.(mock.RealmClient)
+		realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+			return realm.AppDeployment{ID: "deploymentID", Status: realm.DeploymentStatusFailed, StatusErrorMessage: "something went wrong"}, nil
+		}
+		realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
+			return nil
+		}
+
+		var deletedAppID string
+		realmClient.DeleteAppFn = func(groupID, appID string) error {
+			deletedAppID = appID
+			return nil
+		}
+		clients.Realm = realmClient
+
+		cmd := &CommandClone{cloneInputs{App: "eggcorn", Project: "dstGroupID"}}
+
+		err := cmd.Handler(nil, ui, clients)
+		assert.Equal(t, cli.ErrDeploymentFailed{DeploymentID: "deploymentID", Reason: "something went wrong"}, err)
+		assert.Equal(t, "newID", deletedAppID)
+		assert.False(t, strings.Contains(out.String(), "Successfully cloned"), "unexpected output: %s", out.String())
+	})
+
+	t.Run("should return an error when a cluster link is malformed", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		cmd := &CommandClone{cloneInputs{App: "eggcorn", Clusters: []string{"Cluster0"}}}
+
+		err := cmd.Handler(nil, ui, cli.Clients{})
+		assert.Equal(t, errors.New("invalid cluster link 'Cluster0', must be of the form <source>=<destination>"), err)
+	})
+}
@@ -1,10 +1,14 @@
 package app
 
 import (
+	"archive/zip"
+	"errors"
+	"fmt"
 	"os"
 	"strings"
 
 	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
 	"github.com/10gen/realm-cli/internal/local"
 	"github.com/10gen/realm-cli/internal/terminal"
 
@@ -20,16 +24,32 @@ const (
 	flagIncludeHosting           = "include-hosting"
 	flagIncludeHostingShort      = "s"
 	flagIncludeHostingUsage      = "include to diff Realm app hosting changes as well"
+	flagAgainst                  = "against"
+	flagAgainstUsage             = "the path to a local Realm app directory or exported zip to diff against, without contacting the server"
+	flagAllDiff                  = "all"
+	flagAllDiffUsage             = "include to diff every Realm app listed in the realm-workspace.json workspace manifest"
+)
+
+var (
+	errDiffAllConflict = errors.New("cannot use --" + flagAllDiff + " with --" + flagLocalPathDiff + ", --app or --" + flagAgainst)
 )
 
 type diffInputs struct {
 	LocalPath           string
 	IncludeDependencies bool
 	IncludeHosting      bool
+	Against             string
+	All                 bool
 	cli.ProjectInputs
 }
 
 func (i *diffInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if i.All && (i.LocalPath != "" || i.App != "" || i.Against != "") {
+		return errDiffAllConflict
+	}
+	if i.Against != "" && i.IncludeDependencies {
+		return errors.New("cannot use --" + flagIncludeDependencies + " with --" + flagAgainst)
+	}
 	if i.LocalPath == "" {
 		i.LocalPath = profile.WorkingDirectory
 	}
@@ -48,6 +68,8 @@ func (cmd *CommandDiff) Flags(fs *pflag.FlagSet) {
 	fs.StringVar(&cmd.inputs.LocalPath, flagLocalPathDiff, "", flagLocalPathDiffUsage)
 	fs.BoolVarP(&cmd.inputs.IncludeDependencies, flagIncludeDependencies, flagIncludeDependenciesShort, false, flagIncludeDependenciesUsage)
 	fs.BoolVarP(&cmd.inputs.IncludeHosting, flagIncludeHosting, flagIncludeHostingShort, false, flagIncludeHostingUsage)
+	fs.StringVar(&cmd.inputs.Against, flagAgainst, "", flagAgainstUsage)
+	fs.BoolVar(&cmd.inputs.All, flagAllDiff, false, flagAllDiffUsage)
 }
 
 // Inputs is the command inputs
@@ -57,11 +79,55 @@ func (cmd *CommandDiff) Inputs() cli.InputResolver {
 
 // Handler is the command handler
 func (cmd *CommandDiff) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	if cmd.inputs.All {
+		return cmd.diffAll(profile, ui, clients)
+	}
+	return cmd.diff(profile, ui, clients)
+}
+
+// diffAll diffs every app of the workspace the working directory is part of
+func (cmd *CommandDiff) diffAll(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	workspace, err := cli.LoadWorkspace(profile.WorkingDirectory)
+	if err != nil {
+		return err
+	}
+
+	return cli.RunWorkspace(ui, workspace, "Workspace diff summary", func(ui terminal.UI, app local.WorkspaceApp) error {
+		appCmd := &CommandDiff{cmd.inputs}
+		appCmd.inputs.All = false
+		appCmd.inputs.LocalPath = workspace.AppDir(app)
+		appCmd.inputs.App = app.App
+		if app.Project != "" {
+			appCmd.inputs.Project = app.Project
+		}
+
+		if appCmd.inputs.App == "" {
+			appConfig, err := local.LoadAppConfig(appCmd.inputs.LocalPath)
+			if err != nil {
+				return err
+			}
+			if appConfig.RootDir == "" {
+				return errProjectNotFound{appCmd.inputs.LocalPath}
+			}
+			appCmd.inputs.App = appConfig.Option()
+		}
+		if appCmd.inputs.App == "" {
+			return cli.ErrWorkspaceAppNotLinked{Path: app.Path}
+		}
+		return appCmd.diff(profile, ui, clients)
+	})
+}
+
+func (cmd *CommandDiff) diff(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
 	app, err := local.LoadApp(cmd.inputs.LocalPath)
 	if err != nil {
 		return err
 	}
 
+	if cmd.inputs.Against != "" {
+		return cmd.diffAgainst(ui, app)
+	}
+
 	appToDiff, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
 	if err != nil {
 		return err
@@ -72,6 +138,10 @@ func (cmd *CommandDiff) Handler(profile *cli.Profile, ui terminal.UI, clients cl
 		return err
 	}
 
+	// the structured diffs are only for JSON output, so those of the app data are computed lazily
+	appDiffs := diffs
+	var structuredDiffs []realm.Diff
+
 	if cmd.inputs.IncludeDependencies {
 		uploadPath, err := local.PrepareDependencies(app, ui)
 		if err != nil {
@@ -84,6 +154,7 @@ func (cmd *CommandDiff) Handler(profile *cli.Profile, ui terminal.UI, clients cl
 			return err
 		}
 		diffs = append(diffs, dependenciesDiff.Strings()...)
+		structuredDiffs = append(structuredDiffs, dependenciesDiff.Diffs()...)
 	}
 
 	if cmd.inputs.IncludeHosting {
@@ -103,6 +174,7 @@ func (cmd *CommandDiff) Handler(profile *cli.Profile, ui terminal.UI, clients cl
 		}
 
 		diffs = append(diffs, hostingDiffs.Strings()...)
+		structuredDiffs = append(structuredDiffs, hostingDiffs.Diffs()...)
 	}
 
 	if len(diffs) == 0 {
@@ -111,10 +183,100 @@ func (cmd *CommandDiff) Handler(profile *cli.Profile, ui terminal.UI, clients cl
 		return nil
 	}
 
-	ui.Print(terminal.NewTextLog(
-		"The following reflects the proposed changes to your Realm app\n%s",
+	ui.Print(terminal.NewLazyDocumentLog(
+		"The following reflects the proposed changes to your Realm app",
 		strings.Join(diffs, "\n"),
+		func() (interface{}, error) {
+			appDataDiffs, err := local.DiffDeployedAppData(clients.Realm, appToDiff.GroupID, appToDiff.ID, app.AppData, appDiffs)
+			if err != nil {
+				return nil, err
+			}
+			return append(appDataDiffs, structuredDiffs...), nil
+		},
 	))
 
 	return nil
 }
+
+func (cmd *CommandDiff) diffAgainst(ui terminal.UI, app local.App) error {
+	if app.AppData == nil {
+		return errProjectNotFound{cmd.inputs.LocalPath}
+	}
+
+	againstApp, teardown, err := loadAgainstApp(cmd.inputs.Against)
+	if err != nil {
+		return err
+	}
+	defer teardown()
+
+	structuredDiffs, err := local.DiffAppData(againstApp.AppData, app.AppData)
+	if err != nil {
+		return err
+	}
+
+	diffs := make([]string, 0, len(structuredDiffs))
+	for _, diff := range structuredDiffs {
+		diffs = append(diffs, diff.String())
+	}
+
+	if cmd.inputs.IncludeHosting {
+		hosting, err := local.FindAppHosting(app.RootDir)
+		if err != nil {
+			return err
+		}
+
+		againstHosting, err := local.FindAppHosting(againstApp.RootDir)
+		if err != nil {
+			return err
+		}
+
+		hostingDiffs, err := hosting.DiffsWith(againstHosting)
+		if err != nil {
+			return err
+		}
+
+		diffs = append(diffs, hostingDiffs.Strings()...)
+		structuredDiffs = append(structuredDiffs, hostingDiffs.Diffs()...)
+	}
+
+	if len(diffs) == 0 {
+		// there are no diffs
+		ui.Print(terminal.NewTextLog("Local app is identical to %s", cmd.inputs.Against))
+		return nil
+	}
+
+	ui.Print(terminal.NewDocumentLog(
+		fmt.Sprintf("The following reflects the changes from %s to your Realm app", cmd.inputs.Against),
+		strings.Join(diffs, "\n"),
+		structuredDiffs,
+	))
+
+	return nil
+}
+
+// loadAgainstApp loads the app to diff against from either a directory or an exported zip
+func loadAgainstApp(path string) (local.App, func(), error) {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return local.App{}, nil, err
+	}
+
+	if fileInfo.IsDir() {
+		app, err := local.LoadApp(path)
+		if err != nil {
+			return local.App{}, nil, err
+		}
+		if app.AppData == nil {
+			return local.App{}, nil, errProjectNotFound{path}
+		}
+		return app, func() {}, nil
+	}
+
+	zipPkg, err := zip.OpenReader(path)
+	if err != nil {
+		return local.App{}, nil, err
+	}
+	defer zipPkg.Close()
+
+	return local.LoadAppZip(&zipPkg.Reader)
+}
@@ -1,12 +1,21 @@
 package app
 
 import (
+	"archive/zip"
+	"bytes"
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
 	"testing"
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
 	"github.com/10gen/realm-cli/internal/utils/api"
+	u "github.com/10gen/realm-cli/internal/utils/test"
 	"github.com/10gen/realm-cli/internal/utils/test/assert"
 	"github.com/10gen/realm-cli/internal/utils/test/mock"
 
@@ -88,6 +97,9 @@ func TestAppDiffHandler(t *testing.T) {
 			realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
 				return tc.expectedDiff, tc.expectedErr
 			}
+			realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+				return newDeployedAppZip(t)
+			}
 
 			cmd := &CommandDiff{inputs: tc.inputs}
 			assert.Equal(t, tc.expectedErr, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
@@ -114,6 +126,9 @@ func TestAppDiffHandler(t *testing.T) {
 		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
 			return []string{"diff1", "diff2"}, nil
 		}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			return newDeployedAppZip(t)
+		}
 
 		cmd := &CommandDiff{diffInputs{LocalPath: "testdata/dependencies", IncludeDependencies: true}}
 		assert.Equal(t, nil, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
@@ -145,6 +160,9 @@ Modified Dependencies
 		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
 			return []string{"diff1", "diff2"}, nil
 		}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			return newDeployedAppZip(t)
+		}
 
 		cmd := &CommandDiff{diffInputs{LocalPath: "testdata/dependencies", IncludeDependencies: true}}
 		assert.Equal(t, errors.New("realm client error"), cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
@@ -172,6 +190,9 @@ Modified Dependencies
 		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
 			return []string{"diff1", "diff2"}, nil
 		}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			return newDeployedAppZip(t)
+		}
 
 		cmd := &CommandDiff{diffInputs{LocalPath: "testdata/diff", IncludeHosting: true}}
 		assert.Equal(t, nil, cmd.Handler(profile, ui, cli.Clients{Realm: realmClient}))
@@ -187,4 +208,275 @@ Modified hosting files
   * /404.html
 `, out.String())
 	})
+
+	t.Run("with json output set should print the structured diffs as a single document", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{UseJSON: true}, out)
+
+		realmClient := mock.RealmClient{}
+
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return apps, nil
+		}
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			return []string{"diff1"}, nil
+		}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			zipPkg, err := u.NewZipReader(map[string]string{
+				"realm_config.json": `{"config_version":20210101,"app_id":"eggcorn-abcde","name":"eggcorn","location":"US-OR","deployment_model":"GLOBAL"}`,
+			})
+			return "eggcorn_20210101", zipPkg, err
+		}
+
+		cmd := &CommandDiff{diffInputs{LocalPath: "testdata/diff"}}
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, `{"time":"1989-06-22T01:23:45Z","level":"info","message":"The following reflects the proposed changes to your Realm app","doc":[{"component":"settings","operation":"modified","path":"location","before":"US-OR","after":"US-VA"}]}
+`, out.String())
+	})
+
+	t.Run("with json output set should report the server diffs which the app data does not show", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{UseJSON: true}, out)
+
+		realmClient := mock.RealmClient{}
+
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return apps, nil
+		}
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			return []string{"diff1"}, nil
+		}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			zipPkg, err := u.NewZipReader(map[string]string{
+				"realm_config.json": `{"config_version":20210101,"app_id":"eggcorn-abcde","name":"eggcorn","location":"US-VA","deployment_model":"GLOBAL"}`,
+			})
+			return "eggcorn_20210101", zipPkg, err
+		}
+
+		cmd := &CommandDiff{diffInputs{LocalPath: "testdata/diff"}}
+		assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, `{"time":"1989-06-22T01:23:45Z","level":"info","message":"The following reflects the proposed changes to your Realm app","doc":[{"component":"app","operation":"modified","path":"diff1"}]}
+`, out.String())
+	})
+
+	t.Run("with text output set should not export the deployed app", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		realmClient := mock.RealmClient{}
+
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return apps, nil
+		}
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			return []string{"diff1"}, nil
+		}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			t.Fatal("expected the deployed app not to be exported")
+			return "", nil, nil
+		}
+
+		cmd := &CommandDiff{diffInputs{LocalPath: "testdata/diff"}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, "The following reflects the proposed changes to your Realm app\ndiff1\n", out.String())
+	})
+}
+
+func newDeployedAppZip(t *testing.T) (string, *zip.Reader, error) {
+	t.Helper()
+	zipPkg, err := u.NewZipReader(map[string]string{
+		"realm_config.json": `{"config_version":20210101,"app_id":"eggcorn-abcde","name":"eggcorn","location":"US-VA","deployment_model":"GLOBAL"}`,
+	})
+	return "eggcorn_20210101", zipPkg, err
+}
+
+func TestAppDiffAgainstHandler(t *testing.T) {
+	t.Run("should diff the local app against another app directory", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("against")
+		assert.Nil(t, err)
+		defer teardown()
+
+		for name, contents := range map[string]string{
+			"realm_config.json":        `{"config_version":20210101,"app_id":"eggcorn-abcde","name":"eggcorn","location":"US-OR","deployment_model":"GLOBAL"}`,
+			"values/value.json":        `{"name":"value","value":"eggcorn"}`,
+			"hosting/files/index.html": "<html></html>",
+			"hosting/files/old.html":   "<html></html>",
+		} {
+			path := filepath.Join(tmpDir, name)
+			assert.Nil(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+			assert.Nil(t, ioutil.WriteFile(path, []byte(contents), 0666))
+		}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandDiff{diffInputs{LocalPath: "testdata/diff", IncludeHosting: true, Against: tmpDir}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{}))
+
+		assert.Equal(t, `The following reflects the changes from `+tmpDir+` to your Realm app
+* location
+- values/value
+New hosting files
+  + /404.html
+Removed hosting files
+  - /old.html
+Modified hosting files
+  * /index.html
+`, out.String())
+	})
+
+	t.Run("should diff the local app against an exported zip", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("against")
+		assert.Nil(t, err)
+		defer teardown()
+
+		zipPath := filepath.Join(tmpDir, "eggcorn_20210101.zip")
+		assert.Nil(t, writeZipFile(zipPath, map[string]string{
+			"realm_config.json": `{"config_version":20210101,"app_id":"eggcorn-abcde","name":"eggcorn","location":"US-OR","deployment_model":"GLOBAL"}`,
+		}))
+
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{UseJSON: true}, out)
+
+		cmd := &CommandDiff{diffInputs{LocalPath: "testdata/diff", Against: zipPath}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{}))
+
+		assert.Equal(t, `{"time":"1989-06-22T01:23:45Z","level":"info","message":"The following reflects the changes from `+zipPath+` to your Realm app","doc":[{"component":"settings","operation":"modified","path":"location","before":"US-OR","after":"US-VA"}]}
+`, out.String())
+	})
+
+	t.Run("should report when the local app is identical to the other app", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		cmd := &CommandDiff{diffInputs{LocalPath: "testdata/diff", IncludeHosting: true, Against: "testdata/diff"}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{}))
+
+		assert.Equal(t, "Local app is identical to testdata/diff\n", out.String())
+	})
+
+	t.Run("should return an error when the other app directory holds no app", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("against")
+		assert.Nil(t, err)
+		defer teardown()
+
+		_, ui := mock.NewUI()
+
+		cmd := &CommandDiff{diffInputs{LocalPath: "testdata/diff", Against: tmpDir}}
+		assert.Equal(t, errProjectNotFound{tmpDir}, cmd.Handler(nil, ui, cli.Clients{}))
+	})
+
+	t.Run("should return an error when the local app cannot be found", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("against")
+		assert.Nil(t, err)
+		defer teardown()
+
+		_, ui := mock.NewUI()
+
+		cmd := &CommandDiff{diffInputs{LocalPath: tmpDir, Against: "testdata/diff"}}
+		assert.Equal(t, errProjectNotFound{tmpDir}, cmd.Handler(nil, ui, cli.Clients{}))
+	})
+}
+
+func TestAppDiffAllHandler(t *testing.T) {
+	profile, teardown := mock.NewProfileFromTmpDir(t, "app_diff_all_test")
+	defer teardown()
+
+	assert.Nil(t, ioutil.WriteFile(
+		filepath.Join(profile.WorkingDirectory, local.FileWorkspace.String()),
+		[]byte(`{"apps":[{"path":"auth"},{"path":"sync","app":"sync-abcde","project":"syncGroupID"}]}`),
+		0666,
+	))
+	for _, name := range []string{"auth", "sync"} {
+		app := local.NewApp(
+			filepath.Join(profile.WorkingDirectory, name),
+			name+"-abcde",
+			name,
+			realm.LocationVirginia,
+			realm.DeploymentModelGlobal,
+			realm.DefaultAppConfigVersion,
+		)
+		assert.Nil(t, app.Write())
+	}
+
+	var filtersMu sync.Mutex
+	var filters []realm.AppFilter
+
+	realmClient := mock.RealmClient{}
+	realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+		filtersMu.Lock()
+		filters = append(filters, filter)
+		filtersMu.Unlock()
+		return []realm.App{{ID: filter.App, GroupID: "groupID"}}, nil
+	}
+	realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+		if appID == "auth-abcde" {
+			return []string{"diff1"}, nil
+		}
+		return nil, nil
+	}
+	realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+		return newDeployedAppZip(t)
+	}
+
+	out, ui := mock.NewUI()
+
+	cmd := &CommandDiff{diffInputs{All: true}}
+	assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: realmClient}))
+
+	assert.Equal(t, `Workspace app: auth
+The following reflects the proposed changes to your Realm app
+diff1
+Workspace app: sync
+Deployed app is identical to proposed version
+Workspace diff summary
+  App   Result
+  ----  ------
+  auth  ok    
+  sync  ok    
+`, out.String())
+
+	sort.Slice(filters, func(i, j int) bool { return filters[i].App < filters[j].App })
+	assert.Equal(t, []realm.AppFilter{{App: "auth-abcde"}, {GroupID: "syncGroupID", App: "sync-abcde"}}, filters)
+}
+
+func TestAppDiffInputs(t *testing.T) {
+	t.Run("should return an error when including dependencies in an offline diff", func(t *testing.T) {
+		inputs := diffInputs{LocalPath: "testdata/diff", IncludeDependencies: true, Against: "testdata/diff"}
+		assert.Equal(t, errors.New("cannot use --include-dependencies with --against"), inputs.Resolve(nil, nil))
+	})
+
+	t.Run("should return an error when diffing all apps along with an app to diff", func(t *testing.T) {
+		for _, inputs := range []diffInputs{
+			{All: true, LocalPath: "testdata/diff"},
+			{All: true, ProjectInputs: cli.ProjectInputs{App: "app1"}},
+			{All: true, Against: "testdata/diff"},
+		} {
+			assert.Equal(t, errDiffAllConflict, inputs.Resolve(nil, nil))
+		}
+	})
+}
+
+func writeZipFile(path string, files map[string]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for name, contents := range files {
+		zf, err := w.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := zf.Write([]byte(contents)); err != nil {
+			return err
+		}
+	}
+	return w.Close()
 }
@@ -0,0 +1,151 @@
+package app
+
+import (
+	"os"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagIncludeDependenciesDriftUsage = "include to check Realm app dependencies for drift as well"
+	flagIncludeHostingDriftUsage      = "include to check Realm app hosting for drift as well"
+)
+
+type driftInputs struct {
+	LocalPath           string
+	IncludeDependencies bool
+	IncludeHosting      bool
+	cli.ProjectInputs
+}
+
+func (i *driftInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if i.LocalPath == "" {
+		i.LocalPath = profile.WorkingDirectory
+	}
+	return nil
+}
+
+// driftReport describes how the deployed app differs from the local app,
+// where each change is reported going from the deployed app to the local app
+type driftReport struct {
+	GroupID     string       `json:"group_id"`
+	AppID       string       `json:"app_id"`
+	ClientAppID string       `json:"client_app_id"`
+	Drifted     bool         `json:"drifted"`
+	Config      []string     `json:"config"`
+	Changes     []realm.Diff `json:"changes"`
+}
+
+// CommandDrift is the `app drift` command
+type CommandDrift struct {
+	inputs driftInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandDrift) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+
+	fs.StringVar(&cmd.inputs.LocalPath, flagLocalPathDiff, "", flagLocalPathDiffUsage)
+	fs.BoolVarP(&cmd.inputs.IncludeDependencies, flagIncludeDependencies, flagIncludeDependenciesShort, false, flagIncludeDependenciesDriftUsage)
+	fs.BoolVarP(&cmd.inputs.IncludeHosting, flagIncludeHosting, flagIncludeHostingShort, false, flagIncludeHostingDriftUsage)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandDrift) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandDrift) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := local.LoadApp(cmd.inputs.LocalPath)
+	if err != nil {
+		return err
+	}
+	if app.AppData == nil {
+		return errProjectNotFound{cmd.inputs.LocalPath}
+	}
+
+	filter := cmd.inputs.Filter()
+	if filter.App == "" {
+		filter.App = app.Option()
+	}
+
+	appToCheck, err := cli.ResolveApp(ui, clients.Realm, filter)
+	if err != nil {
+		return err
+	}
+
+	report := driftReport{
+		GroupID:     appToCheck.GroupID,
+		AppID:       appToCheck.ID,
+		ClientAppID: appToCheck.ClientAppID,
+	}
+
+	config, err := clients.Realm.Diff(appToCheck.GroupID, appToCheck.ID, app.AppData)
+	if err != nil {
+		return err
+	}
+	report.Config = append([]string{}, config...)
+
+	deployedData, err := local.ExportAppData(clients.Realm, appToCheck.GroupID, appToCheck.ID, app.ConfigVersion())
+	if err != nil {
+		return err
+	}
+
+	configDiffs, err := local.DiffAppData(deployedData, app.AppData)
+	if err != nil {
+		return err
+	}
+
+	report.Changes = append([]realm.Diff{}, configDiffs...)
+
+	drifts := len(report.Config)
+
+	if cmd.inputs.IncludeDependencies {
+		uploadPath, err := local.PrepareDependencies(app, ui)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(uploadPath) //nolint:errcheck
+
+		dependenciesDiff, err := clients.Realm.DiffDependencies(appToCheck.GroupID, appToCheck.ID, uploadPath)
+		if err != nil {
+			return err
+		}
+		report.Changes = append(report.Changes, dependenciesDiff.Diffs()...)
+		drifts += dependenciesDiff.Len()
+	}
+
+	if cmd.inputs.IncludeHosting {
+		hosting, err := local.FindAppHosting(app.RootDir)
+		if err != nil {
+			return err
+		}
+
+		appAssets, err := clients.Realm.HostingAssets(appToCheck.GroupID, appToCheck.ID)
+		if err != nil {
+			return err
+		}
+
+		hostingDiffs, err := hosting.Diffs(profile.HostingAssetCachePath(), appToCheck.ID, appAssets)
+		if err != nil {
+			return err
+		}
+		report.Changes = append(report.Changes, hostingDiffs.Diffs()...)
+		drifts += hostingDiffs.Size()
+	}
+
+	report.Drifted = drifts > 0
+
+	ui.Print(terminal.NewJSONLog("Drift report for "+appToCheck.ClientAppID, report))
+
+	if report.Drifted {
+		return errAppDrifted{appToCheck.ClientAppID, drifts}
+	}
+	return nil
+}
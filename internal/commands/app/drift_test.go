@@ -0,0 +1,167 @@
+package app
+
+import (
+	"archive/zip"
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/api"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestAppDriftHandler(t *testing.T) {
+	deployedApp := realm.App{ID: "appID", GroupID: "groupID", ClientAppID: "eggcorn-abcde", Name: "eggcorn"}
+
+	t.Run("should report no drift when the deployed app matches the local app", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		var appFilter realm.AppFilter
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			appFilter = filter
+			return []realm.App{deployedApp}, nil
+		}
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			return nil, nil
+		}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			return newDeployedAppZip(t)
+		}
+
+		cmd := &CommandDrift{driftInputs{LocalPath: "testdata/diff"}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, realm.AppFilter{App: "eggcorn-abcde"}, appFilter)
+		assert.Equal(t, `Drift report for eggcorn-abcde
+{
+  "group_id": "groupID",
+  "app_id": "appID",
+  "client_app_id": "eggcorn-abcde",
+  "drifted": false,
+  "config": [],
+  "changes": []
+}
+`, out.String())
+	})
+
+	t.Run("should report drift and exit with a distinct code when the deployed app has changed", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		out, ui := mock.NewUI()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{deployedApp}, nil
+		}
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			return []string{"diff1"}, nil
+		}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			zipPkg, err := u.NewZipReader(map[string]string{
+				"realm_config.json": `{"config_version":20210101,"app_id":"eggcorn-abcde","name":"eggcorn","location":"US-OR","deployment_model":"GLOBAL"}`,
+			})
+			return "eggcorn_20210101", zipPkg, err
+		}
+		realmClient.HostingAssetsFn = func(groupID, appID string) ([]realm.HostingAsset, error) {
+			return []realm.HostingAsset{
+				{HostingAssetData: realm.HostingAssetData{FilePath: "/index.html", FileHash: "7785338f982ac81219ef449f4943ec89"}},
+				{
+					HostingAssetData: realm.HostingAssetData{FilePath: "/404.html", FileHash: "7785338f982ac81219ef449f4943ec89"},
+					Attrs:            realm.HostingAssetAttributes{{Name: api.HeaderContentLanguage, Value: "en-US"}},
+				},
+			}, nil
+		}
+
+		cmd := &CommandDrift{driftInputs{LocalPath: "testdata/diff", IncludeHosting: true}}
+
+		err := cmd.Handler(profile, ui, cli.Clients{Realm: realmClient})
+		assert.Equal(t, errAppDrifted{"eggcorn-abcde", 3}, err)
+		assert.Equal(t, "deployed app eggcorn-abcde has drifted from the local app with 3 difference(s)", err.Error())
+
+		var exitCoder cli.ExitCoder
+		assert.True(t, errors.As(err, &exitCoder), "expected drift error to provide an exit code")
+		assert.Equal(t, 2, exitCoder.ExitCode())
+
+		assert.Equal(t, `Drift report for eggcorn-abcde
+{
+  "group_id": "groupID",
+  "app_id": "appID",
+  "client_app_id": "eggcorn-abcde",
+  "drifted": true,
+  "config": [
+    "diff1"
+  ],
+  "changes": [
+    {
+      "component": "settings",
+      "operation": "modified",
+      "path": "location",
+      "before": "US-OR",
+      "after": "US-VA"
+    },
+    {
+      "component": "hosting",
+      "operation": "modified",
+      "path": "/404.html",
+      "before": {
+        "hash": "7785338f982ac81219ef449f4943ec89",
+        "attrs": [
+          {
+            "name": "Content-Language",
+            "value": "en-US"
+          }
+        ]
+      },
+      "after": {
+        "hash": "7785338f982ac81219ef449f4943ec89"
+      }
+    },
+    {
+      "component": "hosting",
+      "operation": "modified",
+      "path": "/index.html",
+      "before": {
+        "hash": "7785338f982ac81219ef449f4943ec89"
+      },
+      "after": {
+        "hash": "daad4fb706d494feb9014e131f6520d4",
+        "attrs": [
+          {
+            "name": "Content-Type",
+            "value": "text/html"
+          }
+        ]
+      }
+    }
+  ]
+}
+`, out.String())
+	})
+
+	t.Run("should return an error when the local app cannot be found", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		cmd := &CommandDrift{driftInputs{LocalPath: "testdata"}}
+		assert.Equal(t, errProjectNotFound{"testdata"}, cmd.Handler(nil, ui, cli.Clients{}))
+	})
+
+	t.Run("should return an error when the diff fails", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{deployedApp}, nil
+		}
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			return nil, errors.New("something bad happened")
+		}
+
+		cmd := &CommandDrift{driftInputs{LocalPath: "testdata/diff"}}
+		assert.Equal(t, errors.New("something bad happened"), cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+	})
+}
@@ -1,5 +1,7 @@
 package app
 
+import "fmt"
+
 type errProjectExists struct {
 	path string
 }
@@ -13,3 +15,30 @@ func (err errProjectExists) Error() string {
 }
 
 func (err errProjectExists) DisableUsage() struct{} { return struct{}{} }
+
+type errProjectNotFound struct {
+	path string
+}
+
+func (err errProjectNotFound) Error() string {
+	return "failed to find a Realm app at " + err.path
+}
+
+func (err errProjectNotFound) DisableUsage() struct{} { return struct{}{} }
+
+// exitCodeAppDrifted is the exit code used when the deployed app has drifted from the local app,
+// which sets it apart from a failure to check for drift
+const exitCodeAppDrifted = 2
+
+type errAppDrifted struct {
+	app    string
+	drifts int
+}
+
+func (err errAppDrifted) Error() string {
+	return fmt.Sprintf("deployed app %s has drifted from the local app with %d difference(s)", err.app, err.drifts)
+}
+
+func (err errAppDrifted) DisableUsage() struct{} { return struct{}{} }
+
+func (err errAppDrifted) ExitCode() int { return exitCodeAppDrifted }
@@ -14,4 +14,11 @@ func TestAppErrors(t *testing.T) {
 		_, ok := err.(cli.DisableUsage)
 		assert.True(t, ok, "expected project exists error to disable usage")
 	})
+
+	t.Run("err project not found should disable usage", func(t *testing.T) {
+		var err error = errProjectNotFound{}
+
+		_, ok := err.(cli.DisableUsage)
+		assert.True(t, ok, "expected project not found error to disable usage")
+	})
 }
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"path/filepath"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagLocalPathMigrate      = "local"
+	flagLocalPathMigrateUsage = "the local path to your Realm app"
+	flagToConfigVersion       = "to-config-version"
+	flagToConfigVersionUsage  = "the config version of the Realm app structure to migrate to; defaults to latest stable config version"
+)
+
+type migrateInputs struct {
+	LocalPath       string
+	ToConfigVersion realm.AppConfigVersion
+}
+
+func (i *migrateInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if i.LocalPath == "" {
+		i.LocalPath = profile.WorkingDirectory
+	}
+	if i.ToConfigVersion == realm.AppConfigVersionZero {
+		i.ToConfigVersion = realm.DefaultAppConfigVersion
+	}
+	return nil
+}
+
+// CommandMigrate is the `app migrate` command
+type CommandMigrate struct {
+	inputs migrateInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandMigrate) Flags(fs *pflag.FlagSet) {
+	fs.StringVar(&cmd.inputs.LocalPath, flagLocalPathMigrate, "", flagLocalPathMigrateUsage)
+	fs.Var(&cmd.inputs.ToConfigVersion, flagToConfigVersion, flagToConfigVersionUsage)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandMigrate) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandMigrate) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := local.LoadApp(cmd.inputs.LocalPath)
+	if err != nil {
+		return err
+	}
+	if app.AppData == nil {
+		return errProjectNotFound{cmd.inputs.LocalPath}
+	}
+
+	if err := local.CheckMigration(app, cmd.inputs.ToConfigVersion); err != nil {
+		return err
+	}
+
+	proceed, err := ui.Confirm(
+		"Are you sure you want to rewrite the app at '%s' from config version %s to config version %s?",
+		app.RootDir,
+		app.ConfigVersion(),
+		cmd.inputs.ToConfigVersion,
+	)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return nil
+	}
+
+	migrated, unmapped, err := local.MigrateApp(app, cmd.inputs.ToConfigVersion)
+	if err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully migrated app to config version %s", migrated.ConfigVersion()))
+
+	if len(unmapped) > 0 {
+		unmappedItems := make([]interface{}, len(unmapped))
+		for i, item := range unmapped {
+			unmappedItems[i] = item
+		}
+		ui.Print(
+			terminal.NewWarningLog("Some parts of the app could not be migrated and must be carried over by hand"),
+			terminal.NewListLog("Not migrated", unmappedItems...),
+			terminal.NewTextLog("The app files which hold them were moved to %s", filepath.Join(migrated.RootDir, local.NameUnmigrated)),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestAppMigrateHandler(t *testing.T) {
+	writeAppV1 := func(t *testing.T, dir string) {
+		t.Helper()
+		assert.Nil(t, ioutil.WriteFile(
+			filepath.Join(dir, local.FileConfig.String()),
+			[]byte(`{"config_version":20200603,"name":"eggcorn","location":"US-VA","deployment_model":"GLOBAL","hosting":{"enabled":true},"security":{"sessions":{"max_age":60}}}`),
+			0666,
+		))
+	}
+
+	t.Run("should migrate the local app and report what could not be migrated", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("migrate")
+		assert.Nil(t, err)
+		defer teardown()
+
+		writeAppV1(t, tmpDir)
+
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+		cmd := &CommandMigrate{migrateInputs{LocalPath: tmpDir, ToConfigVersion: realm.AppConfigVersion20210101}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{}))
+
+		assert.Equal(t, `Successfully migrated app to config version 20210101
+Some parts of the app could not be migrated and must be carried over by hand
+Not migrated
+  security setting 'sessions'
+The app files which hold them were moved to `+filepath.Join(tmpDir, local.NameUnmigrated)+`
+`, out.String())
+
+		app, err := local.LoadApp(tmpDir)
+		assert.Nil(t, err)
+		assert.Equal(t, realm.AppConfigVersion20210101, app.ConfigVersion())
+
+		v2, ok := app.AppData.(*local.AppRealmConfigJSON)
+		assert.True(t, ok, "expected app data to be v2")
+		assert.Equal(t, map[string]interface{}{"enabled": true}, v2.Hosting)
+
+		_, err = ioutil.ReadFile(filepath.Join(tmpDir, local.NameUnmigrated, local.FileConfig.String()))
+		assert.Nil(t, err)
+	})
+
+	t.Run("should leave the local app untouched when the user does not confirm", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("migrate")
+		assert.Nil(t, err)
+		defer teardown()
+
+		writeAppV1(t, tmpDir)
+
+		_, console, _, ui, consoleErr := mock.NewVT10XConsole()
+		assert.Nil(t, consoleErr)
+		defer console.Close()
+
+		doneCh := make(chan struct{})
+		go func() {
+			defer close(doneCh)
+			console.ExpectString("Are you sure you want to rewrite the app")
+			console.SendLine("n")
+			console.ExpectEOF()
+		}()
+
+		cmd := &CommandMigrate{migrateInputs{LocalPath: tmpDir, ToConfigVersion: realm.AppConfigVersion20210101}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{}))
+
+		console.Tty().Close() // flush the writers
+		<-doneCh              // wait for procedure to complete
+
+		app, err := local.LoadApp(tmpDir)
+		assert.Nil(t, err)
+		assert.Equal(t, realm.AppConfigVersion20200603, app.ConfigVersion())
+	})
+
+	t.Run("should return an error before prompting when the local app is already at the config version", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		cmd := &CommandMigrate{migrateInputs{LocalPath: "testdata/diff", ToConfigVersion: realm.AppConfigVersion20210101}}
+
+		err := cmd.Handler(nil, ui, cli.Clients{})
+		assert.Equal(t, errors.New("cannot migrate app from config version 20210101 to config version 20210101"), err)
+	})
+
+	t.Run("should return an error when no local app can be found", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("migrate")
+		assert.Nil(t, err)
+		defer teardown()
+
+		_, ui := mock.NewUI()
+
+		cmd := &CommandMigrate{migrateInputs{LocalPath: tmpDir}}
+		assert.Equal(t, errProjectNotFound{tmpDir}, cmd.Handler(nil, ui, cli.Clients{}))
+	})
+}
+
+func TestAppMigrateInputs(t *testing.T) {
+	t.Run("should default to the latest stable config version", func(t *testing.T) {
+		inputs := migrateInputs{LocalPath: "testdata/diff"}
+		assert.Nil(t, inputs.Resolve(nil, nil))
+		assert.Equal(t, realm.DefaultAppConfigVersion, inputs.ToConfigVersion)
+	})
+}
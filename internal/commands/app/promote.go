@@ -0,0 +1,221 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+	"github.com/10gen/realm-cli/internal/utils/flags"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagPromoteFrom         = "from"
+	flagPromoteFromUsage    = "the Realm app (name or id) to promote the configuration of"
+	flagPromoteTo           = "to"
+	flagPromoteToUsage      = "the Realm app (name or id) to promote the configuration to"
+	flagPromoteEnvironment  = "environment"
+	flagPromoteEnvUsage     = "the environment of the Realm app being promoted to, e.g. qa or production, which defaults to its deployed environment"
+	flagPromoteMapping      = "mapping"
+	flagPromoteMappingUsage = "the path to a JSON file of target specific data source cluster names and values"
+	flagPromoteDryRun       = "dry-run"
+	flagPromoteDryRunShort  = "x"
+	flagPromoteDryRunUsage  = "include to show the changes without promoting them"
+	flagPromoteProject      = "project"
+	flagPromoteProjectUsage = "the MongoDB cloud project id"
+)
+
+var (
+	errPromoteSameApp = errors.New("cannot promote a Realm app to itself")
+)
+
+type promoteInputs struct {
+	Project     string
+	From        string
+	To          string
+	Environment string
+	Mapping     string
+	DryRun      bool
+}
+
+func (i *promoteInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if i.From == "" {
+		if err := ui.AskOne(&i.From, &survey.Input{Message: "App Filter (promote from)"}); err != nil {
+			return err
+		}
+	}
+
+	if i.To == "" {
+		if err := ui.AskOne(&i.To, &survey.Input{Message: "App Filter (promote to)"}); err != nil {
+			return err
+		}
+	}
+
+	if i.From == i.To {
+		return errPromoteSameApp
+	}
+
+	if i.Mapping != "" {
+		mapping, err := homedir.Expand(i.Mapping)
+		if err != nil {
+			return err
+		}
+		i.Mapping = mapping
+	}
+	return nil
+}
+
+// promoteMapping is the file of target specific pieces swapped into a promoted app
+type promoteMapping struct {
+	Environment string                 `json:"environment,omitempty"`
+	Clusters    map[string]string      `json:"clusters,omitempty"`
+	Values      map[string]interface{} `json:"values,omitempty"`
+}
+
+// CommandPromote is the `app promote` command
+type CommandPromote struct {
+	inputs promoteInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandPromote) Flags(fs *pflag.FlagSet) {
+	fs.StringVar(&cmd.inputs.From, flagPromoteFrom, "", flagPromoteFromUsage)
+	fs.StringVar(&cmd.inputs.To, flagPromoteTo, "", flagPromoteToUsage)
+	fs.StringVar(&cmd.inputs.Environment, flagPromoteEnvironment, "", flagPromoteEnvUsage)
+	fs.StringVar(&cmd.inputs.Mapping, flagPromoteMapping, "", flagPromoteMappingUsage)
+	fs.BoolVarP(&cmd.inputs.DryRun, flagPromoteDryRun, flagPromoteDryRunShort, false, flagPromoteDryRunUsage)
+
+	fs.StringVar(&cmd.inputs.Project, flagPromoteProject, "", flagPromoteProjectUsage)
+	flags.MarkHidden(fs, flagPromoteProject)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandPromote) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandPromote) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	mapping, err := cmd.loadMapping()
+	if err != nil {
+		return err
+	}
+
+	from, err := cli.ResolveApp(ui, clients.Realm, realm.AppFilter{GroupID: cmd.inputs.Project, App: cmd.inputs.From})
+	if err != nil {
+		return err
+	}
+
+	to, err := cli.ResolveApp(ui, clients.Realm, realm.AppFilter{GroupID: cmd.inputs.Project, App: cmd.inputs.To})
+	if err != nil {
+		return err
+	}
+
+	if from.ID == to.ID {
+		return errPromoteSameApp
+	}
+
+	appData, err := local.ExportAppData(clients.Realm, from.GroupID, from.ID, realm.DefaultAppConfigVersion)
+	if err != nil {
+		return err
+	}
+
+	// the target app keeps its deployed environment unless one is set by flag or mapping
+	environment := mapping.Environment
+	if environment == "" {
+		description, err := clients.Realm.AppDescription(to.GroupID, to.ID)
+		if err != nil {
+			return err
+		}
+		environment = description.Environment
+	}
+
+	if err := local.PromoteAppData(appData, local.AppPromotion{
+		Target:      to,
+		Environment: environment,
+		Clusters:    mapping.Clusters,
+		Values:      mapping.Values,
+	}); err != nil {
+		return err
+	}
+
+	diffs, err := clients.Realm.Diff(to.GroupID, to.ID, appData)
+	if err != nil {
+		return err
+	}
+
+	if len(diffs) == 0 {
+		ui.Print(terminal.NewTextLog("App %s is identical to the promoted version of %s, nothing to do", to.ClientAppID, from.ClientAppID))
+		return nil
+	}
+
+	// the structured diffs of the promoted app data are only computed for JSON output
+	ui.Print(terminal.NewLazyDocumentLog(
+		fmt.Sprintf("The following reflects the changes to promote %s to %s", from.ClientAppID, to.ClientAppID),
+		strings.Join(diffs, "\n"),
+		func() (interface{}, error) {
+			return local.DiffDeployedAppData(clients.Realm, to.GroupID, to.ID, appData, diffs)
+		},
+	))
+
+	if cmd.inputs.DryRun {
+		ui.Print(terminal.NewTextLog("To promote these changes, you must omit the 'dry-run' flag to proceed"))
+		return nil
+	}
+
+	proceed, err := ui.Confirm("Are you sure you want to promote %s to %s?", from.ClientAppID, to.ClientAppID)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return nil
+	}
+
+	draft, proceed, err := cli.CreateNewDraft(ui, clients.Realm, to.GroupID, to.ID)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return nil
+	}
+
+	if err := clients.Realm.Import(to.GroupID, to.ID, appData); err != nil {
+		return err
+	}
+
+	if err := cli.DeployDraftAndWait(ui, clients.Realm, to.GroupID, to.ID, draft.ID); err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully promoted %s to %s", from.ClientAppID, to.ClientAppID))
+	return nil
+}
+
+// loadMapping reads the mapping file, if one is set, and applies the environment set by flag over it
+func (cmd *CommandPromote) loadMapping() (promoteMapping, error) {
+	var mapping promoteMapping
+
+	if cmd.inputs.Mapping != "" {
+		data, err := ioutil.ReadFile(cmd.inputs.Mapping)
+		if err != nil {
+			return promoteMapping{}, fmt.Errorf("failed to read mapping file: %s", err)
+		}
+		if err := json.Unmarshal(data, &mapping); err != nil {
+			return promoteMapping{}, fmt.Errorf("failed to parse mapping file at %s: %s", cmd.inputs.Mapping, err)
+		}
+	}
+
+	if cmd.inputs.Environment != "" {
+		mapping.Environment = cmd.inputs.Environment
+	}
+	return mapping, nil
+}
@@ -0,0 +1,265 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestAppPromoteHandler(t *testing.T) {
+	devApp := realm.App{ID: "devID", GroupID: "groupID", ClientAppID: "dev-abcde", Name: "dev"}
+	prodApp := realm.App{
+		ID:          "prodID",
+		GroupID:     "groupID",
+		ClientAppID: "prod-abcde",
+		Name:        "prod",
+		AppMeta:     realm.AppMeta{Location: realm.LocationVirginia, DeploymentModel: realm.DeploymentModelGlobal},
+	}
+
+	appZips := map[string]map[string]string{
+		devApp.ID: {
+			"realm_config.json":                      `{"config_version":20210101,"app_id":"dev-abcde","name":"dev","location":"US-VA","deployment_model":"GLOBAL","environment":"development"}`,
+			"data_sources/mongodb-atlas/config.json": `{"name":"mongodb-atlas","type":"mongodb-atlas","config":{"clusterName":"dev-cluster"}}`,
+			"values/apiUrl.json":                     `{"name":"apiUrl","value":"https://dev","from_secret":false}`,
+		},
+		prodApp.ID: {
+			"realm_config.json": `{"config_version":20210101,"app_id":"prod-abcde","name":"prod","location":"US-VA","deployment_model":"GLOBAL"}`,
+		},
+	}
+
+	setupClient := func(imported *local.AppData) mock.RealmClient {
+		realmClient := mock.RealmClient{}
+		realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return realm.AppDraft{ID: "draftID"}, nil
+		}
+		realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+			return realm.AppDeployment{ID: "deploymentID", Status: realm.DeploymentStatusSuccessful}, nil
+		}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			switch filter.App {
+			case "dev":
+				return []realm.App{devApp}, nil
+			case "prod":
+				return []realm.App{prodApp}, nil
+			}
+			return nil, nil
+		}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			zipPkg, err := u.NewZipReader(appZips[appID])
+			return appID, zipPkg, err
+		}
+		realmClient.AppDescriptionFn = func(groupID, appID string) (realm.AppDescription, error) {
+			return realm.AppDescription{ClientAppID: "prod-abcde", Environment: "production"}, nil
+		}
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			return []string{"diff1"}, nil
+		}
+		realmClient.ImportFn = func(groupID, appID string, appData interface{}) error {
+			*imported = appData.(local.AppData)
+			return nil
+		}
+		return realmClient
+	}
+
+	t.Run("should promote the source app with the target specific pieces swapped in", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("promote")
+		assert.Nil(t, err)
+		defer teardown()
+
+		mappingPath := filepath.Join(tmpDir, "mapping.json")
+		assert.Nil(t, ioutil.WriteFile(mappingPath, []byte(`{
+			"environment": "staging",
+			"clusters": {"dev-cluster": "prod-cluster"},
+			"values": {"apiUrl": "https://prod"}
+		}`), 0666))
+
+		var imported local.AppData
+
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+		cmd := &CommandPromote{promoteInputs{From: "dev", To: "prod", Environment: "production", Mapping: mappingPath}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: setupClient(&imported)}))
+
+		assert.True(t, strings.HasPrefix(out.String(), "The following reflects the changes to promote dev-abcde to prod-abcde\ndiff1\n"), "unexpected output: %s", out.String())
+		assert.True(t, strings.HasSuffix(out.String(), "Deployment complete\nSuccessfully promoted dev-abcde to prod-abcde\n"), "unexpected output: %s", out.String())
+
+		v2, ok := imported.(*local.AppRealmConfigJSON)
+		assert.True(t, ok, "expected v2 app data to be imported")
+		assert.Equal(t, "prod-abcde", v2.ID())
+		assert.Equal(t, "prod", v2.Name())
+		assert.Equal(t, "production", v2.Environment)
+		assert.Equal(t, map[string]interface{}{"clusterName": "prod-cluster"}, v2.DataSources[0].Config["config"])
+		assert.Equal(t, "https://prod", v2.Values[0]["value"])
+	})
+
+	t.Run("should keep the deployed environment of the target app when none is set", func(t *testing.T) {
+		var imported local.AppData
+
+		realmClient := setupClient(&imported)
+
+		var describedAppID string
+		realmClient.AppDescriptionFn = func(groupID, appID string) (realm.AppDescription, error) {
+			describedAppID = appID
+			return realm.AppDescription{ClientAppID: "prod-abcde", Environment: "production"}, nil
+		}
+
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, new(bytes.Buffer))
+
+		cmd := &CommandPromote{promoteInputs{From: "dev", To: "prod"}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		v2, ok := imported.(*local.AppRealmConfigJSON)
+		assert.True(t, ok, "expected v2 app data to be imported")
+		assert.Equal(t, "production", v2.Environment)
+		assert.Equal(t, "prodID", describedAppID)
+
+		t.Run("and should clear the environment when the target app has none", func(t *testing.T) {
+			realmClient.AppDescriptionFn = func(groupID, appID string) (realm.AppDescription, error) {
+				return realm.AppDescription{ClientAppID: "prod-abcde"}, nil
+			}
+
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+			v2, ok := imported.(*local.AppRealmConfigJSON)
+			assert.True(t, ok, "expected v2 app data to be imported")
+			assert.Equal(t, "", v2.Environment)
+		})
+	})
+
+	t.Run("should import the promoted app into a draft and deploy it", func(t *testing.T) {
+		var imported local.AppData
+
+		realmClient := setupClient(&imported)
+
+		var exportedAppIDs []string
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			exportedAppIDs = append(exportedAppIDs, appID)
+			zipPkg, err := u.NewZipReader(appZips[appID])
+			return appID, zipPkg, err
+		}
+
+		var calls []string
+		realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			calls = append(calls, "create draft "+appID)
+			return realm.AppDraft{ID: "draftID"}, nil
+		}
+		realmClient.ImportFn = func(groupID, appID string, appData interface{}) error {
+			calls = append(calls, "import "+appID)
+			return nil
+		}
+		realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+			calls = append(calls, "deploy "+draftID)
+			return realm.AppDeployment{ID: "deploymentID", Status: realm.DeploymentStatusSuccessful}, nil
+		}
+
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, new(bytes.Buffer))
+
+		cmd := &CommandPromote{promoteInputs{From: "dev", To: "prod"}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, []string{"create draft prodID", "import prodID", "deploy draftID"}, calls)
+
+		t.Log("and should not export the target app to diff it outside of JSON output")
+		assert.Equal(t, []string{"devID"}, exportedAppIDs)
+	})
+
+	t.Run("should return an error when the deployment fails", func(t *testing.T) {
+		var imported local.AppData
+
+		realmClient := setupClient(&imported)
+		realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+			return realm.AppDeployment{ID: "deploymentID", Status: realm.DeploymentStatusFailed, StatusErrorMessage: "something went wrong"}, nil
+		}
+		realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
+			return nil
+		}
+
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+		cmd := &CommandPromote{promoteInputs{From: "dev", To: "prod"}}
+
+		err := cmd.Handler(nil, ui, cli.Clients{Realm: realmClient})
+		assert.Equal(t, cli.ErrDeploymentFailed{DeploymentID: "deploymentID", Reason: "something went wrong"}, err)
+		assert.False(t, strings.Contains(out.String(), "Successfully promoted"), "unexpected output: %s", out.String())
+	})
+
+	t.Run("should only show the changes in a dry run", func(t *testing.T) {
+		var imported local.AppData
+
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+		cmd := &CommandPromote{promoteInputs{From: "dev", To: "prod", DryRun: true}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: setupClient(&imported)}))
+
+		assert.True(t, strings.HasSuffix(out.String(), "To promote these changes, you must omit the 'dry-run' flag to proceed\n"), "unexpected output: %s", out.String())
+		assert.Nil(t, imported)
+	})
+
+	t.Run("should not import anything when the target app is identical", func(t *testing.T) {
+		var imported local.AppData
+
+		realmClient := setupClient(&imported)
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			return nil, nil
+		}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandPromote{promoteInputs{From: "dev", To: "prod"}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.Equal(t, "App prod-abcde is identical to the promoted version of dev-abcde, nothing to do\n", out.String())
+		assert.Nil(t, imported)
+	})
+
+	t.Run("should return an error when a mapped value does not exist in the source app", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("promote")
+		assert.Nil(t, err)
+		defer teardown()
+
+		mappingPath := filepath.Join(tmpDir, "mapping.json")
+		assert.Nil(t, ioutil.WriteFile(mappingPath, []byte(`{"values": {"missing": 1}}`), 0666))
+
+		var imported local.AppData
+
+		cmd := &CommandPromote{promoteInputs{From: "dev", To: "prod", Mapping: mappingPath}}
+
+		err = cmd.Handler(nil, nil, cli.Clients{Realm: setupClient(&imported)})
+		assert.Equal(t, errors.New("failed to find values in app to promote: missing"), err)
+	})
+
+	t.Run("should return an error when both filters resolve to the same app", func(t *testing.T) {
+		var imported local.AppData
+
+		realmClient := setupClient(&imported)
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{devApp}, nil
+		}
+
+		cmd := &CommandPromote{promoteInputs{From: "dev", To: "dev-abcde"}}
+
+		err := cmd.Handler(nil, nil, cli.Clients{Realm: realmClient})
+		assert.Equal(t, errPromoteSameApp, err)
+	})
+}
+
+func TestAppPromoteInputs(t *testing.T) {
+	t.Run("should return an error when promoting an app to itself", func(t *testing.T) {
+		inputs := promoteInputs{From: "dev", To: "dev"}
+		assert.Equal(t, errPromoteSameApp, inputs.Resolve(nil, nil))
+	})
+}
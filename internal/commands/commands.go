@@ -3,6 +3,8 @@ package commands
 import (
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/commands/app"
+	"github.com/10gen/realm-cli/internal/commands/deployment"
+	"github.com/10gen/realm-cli/internal/commands/draft"
 	"github.com/10gen/realm-cli/internal/commands/function"
 	"github.com/10gen/realm-cli/internal/commands/login"
 	"github.com/10gen/realm-cli/internal/commands/logout"
@@ -10,6 +12,7 @@ import (
 	"github.com/10gen/realm-cli/internal/commands/push"
 	"github.com/10gen/realm-cli/internal/commands/secrets"
 	"github.com/10gen/realm-cli/internal/commands/user"
+	"github.com/10gen/realm-cli/internal/commands/values"
 	"github.com/10gen/realm-cli/internal/commands/whoami"
 )
 
@@ -55,7 +58,15 @@ realm-cli login --profile <profile-name>`,
 		Help: `Updates a remote Realm app with your local directory. First, input a Realm app
 that you would like changes pushed to. This input can be either the App ID or
 Name of an existing Realm app you would like to update, or the name of a new
-Realm app you would like to create. Changes pushed are automatically deployed.`,
+Realm app you would like to create. Changes pushed are automatically deployed.
+
+Use --all to push every Realm app listed in the realm-workspace.json workspace
+manifest found in or above your current working directory. As the apps are
+pushed at once, --all requires -y unless combined with --dry-run.
+
+Use --plan-out to save the computed changes to a plan file without pushing them,
+and --plan to later push exactly those changes. A plan is refused if either the
+local or the remote Realm app has changed since it was made.`,
 	}
 
 	Pull = cli.CommandDefinition{
@@ -66,7 +77,11 @@ Realm app you would like to create. Changes pushed are automatically deployed.`,
 		Help: `Updates your local directory with a remote Realm app by pulling changes from the
 latter into the former. Input a Realm app that you would like to have changes
 pulled from. If applicable, hosting files and/or dependencies associated with
-your Realm app will be exported as well.`,
+your Realm app will be exported as well.
+
+Use --all to export every Realm app listed in the realm-workspace.json workspace
+manifest found in or above your current working directory. As the apps are
+exported at once, --all requires -y unless combined with --dry-run.`,
 	}
 
 	App = cli.CommandDefinition{
@@ -119,7 +134,66 @@ you do not specify a '--remote' flag, the CLI will create a default Realm app.`,
 				Help: `Displays file-by-file differences between the latest version of your Realm app
 and your local directory. If you have more than one Realm app, you will be
 prompted to select a Realm app that you would like to display from a list of all
-Realm apps associated with your user profile.`,
+Realm apps associated with your user profile.
+
+Use --all to diff every Realm app listed in the realm-workspace.json workspace
+manifest found in or above your current working directory.`,
+			},
+			{
+				Command:     &app.CommandDrift{},
+				Use:         "drift",
+				Display:     "app drift",
+				Description: "Check whether your deployed Realm app has drifted from your local directory",
+				Help: `Compares the latest version of your Realm app with your local directory and prints
+a JSON report of every difference found. Changes made to the deployed app
+outside of your local directory, such as in the Realm UI, show up as drift.
+
+Use --include-dependencies and --include-hosting to check your Realm app
+dependencies and hosting files as well.
+
+Exits with code 2 when the deployed app has drifted, and with code 1 when the
+check itself fails, which makes this command suitable for scheduled jobs.`,
+			},
+			{
+				Command:     &app.CommandMigrate{},
+				Use:         "migrate",
+				Display:     "app migrate",
+				Description: "Migrate your local Realm app to a newer config version",
+				Help: `Rewrites the Realm app in your local directory using the app structure of a newer
+config version. This command only affects your local environment and does not
+contact the Realm servers. Anything which could not be carried over to the new
+app structure is reported so that it can be migrated by hand, and the files
+which hold it are moved to the "unmigrated" directory of your app rather than
+removed.`,
+			},
+			{
+				Command:     &app.CommandPromote{},
+				Use:         "promote",
+				Display:     "app promote",
+				Description: "Promote the configuration of one Realm app to another",
+				Help: `Exports the configuration of the Realm app specified by --from and imports it
+into the Realm app specified by --to, such as when promoting changes from
+development to staging to production. The target app keeps its own app id, name,
+location, deployment model and environment. Use --environment to set the target
+environment instead, and --mapping to point to a JSON file of target specific data source cluster
+names and values, shaped like:
+  {"clusters": {"dev-cluster": "prod-cluster"}, "values": {"apiUrl": "https://..."}}
+
+The changes are shown before they are imported into a draft of the target app,
+which is then deployed as it is by push; use --dry-run to only show them.`,
+			},
+			{
+				Command:     &app.CommandClone{},
+				Use:         "clone",
+				Display:     "app clone",
+				Description: "Clone a Realm app into another project",
+				Help: `Creates a new Realm app in the project specified by --project from a templated
+export of the Realm app specified by --app. The new app keeps the name, location
+and deployment model of the source app unless --name is set.
+
+Data sources are re-linked to clusters in the destination project: a cluster with
+the same name is kept, otherwise the project's only cluster is used, or you are
+prompted to select one. Use --cluster <source>=<destination> to link them yourself.`,
 			},
 			{
 				Command:     &app.CommandDelete{},
@@ -233,6 +307,167 @@ be modified.`,
 				Description: "Delete a secret from your Realm app",
 				Help:        `Removes a secret from your Realm app.`,
 			},
+			{
+				Command:     &secrets.CommandImport{},
+				Use:         "import",
+				Display:     "secrets import",
+				Description: "Import secrets into your Realm app from a file",
+				Help: `Creates the secrets defined in a .env or .json file which are missing from your
+Realm app. Secrets which already exist are only updated when --overwrite is
+set. Use --dry-run to review the changes before making them.`,
+			},
+			{
+				Command:     &secrets.CommandExport{},
+				Use:         "export",
+				Display:     "secrets export",
+				Description: "Export the secret names of your Realm app as a template file",
+				Help: `Writes the names of your Realm app's secrets with empty values to a .env or
+.json file, which can be filled in and imported with "secrets import". Secret
+values cannot be read back from Realm, so --names-only must be set.`,
+			},
+		},
+	}
+
+	Values = cli.CommandDefinition{
+		Use:         "values",
+		Aliases:     []string{"value"},
+		Description: "Manage the values of your Realm app",
+		SubCommands: []cli.CommandDefinition{
+			{
+				Command:     &values.CommandCreate{},
+				Use:         "create",
+				Display:     "values create",
+				Description: "Create a value in your Realm app",
+				Help: `Adds a new value to your Realm app. You will be prompted to name your value
+and define its contents, unless you back the value with a secret instead.`,
+			},
+			{
+				Command:     &values.CommandList{},
+				Use:         "list",
+				Aliases:     []string{"ls"},
+				Display:     "values list",
+				Description: "List the values in your Realm app",
+				Help: `Displays a list of your Realm app's values. Values backed by a secret show the
+name of that secret rather than its contents.`,
+			},
+			{
+				Command:     &values.CommandUpdate{},
+				Use:         "update",
+				Display:     "values update",
+				Description: "Update a value in your Realm app",
+				Help: `Modifies the contents of a value in your Realm app. The name of the value cannot
+be modified.`,
+			},
+			{
+				Command:     &values.CommandDelete{},
+				Use:         "delete",
+				Display:     "values delete",
+				Description: "Delete a value from your Realm app",
+				Help:        `Removes a value from your Realm app.`,
+			},
+			{
+				Command:     &values.CommandPull{},
+				Use:         "pull",
+				Display:     "values pull",
+				Description: "Pull a value of your Realm app into your local app",
+				Help: `Writes the deployed value to the values directory of your local Realm app,
+without exporting the rest of the app.`,
+			},
+			{
+				Command:     &values.CommandPush{},
+				Use:         "push",
+				Display:     "values push",
+				Description: "Push a value of your local app to your Realm app",
+				Help: `Creates or updates the deployed value from the values directory of your local
+Realm app, without importing the rest of the app.`,
+			},
+		},
+	}
+
+	Deployments = cli.CommandDefinition{
+		Use:         "deployments",
+		Aliases:     []string{"deployment"},
+		Description: "Manage the deployments of your Realm app",
+		SubCommands: []cli.CommandDefinition{
+			{
+				Command:     &deployment.CommandList{},
+				Use:         "list",
+				Aliases:     []string{"ls"},
+				Display:     "deployments list",
+				Description: "List the deployments of your Realm app",
+				Help: `Displays a list of your Realm app's deployments, most recent first. Each
+deployment shows its status, when it was deployed, and the origin and draft it
+was deployed from.`,
+			},
+			{
+				Command:     &deployment.CommandDescribe{},
+				Use:         "describe",
+				Display:     "deployments describe",
+				Description: "View the details of a deployment of your Realm app",
+				Help: `Displays information about a deployment of your Realm app. If you do not specify
+a deployment, you will be prompted to select one from a list of your Realm app's
+deployments.`,
+			},
+			{
+				Command:     &deployment.CommandRedeploy{},
+				Use:         "redeploy",
+				Display:     "deployments redeploy",
+				Description: "Redeploy a deployment of your Realm app",
+				Help: `Deploys the configuration of an existing deployment to your Realm app again. If
+you do not specify a deployment, you will be prompted to select one from a list
+of your Realm app's deployments.`,
+			},
+			{
+				Command:     &deployment.CommandRollback{},
+				Use:         "rollback",
+				Display:     "deployments rollback",
+				Description: "Roll back your Realm app to a previous successful deployment",
+				Help: `Redeploys an earlier successful deployment of your Realm app. Specify the
+deployment to roll back to with '--to'; if you do not, the CLI will roll back to
+the last successful deployment before the current one.`,
+			},
+		},
+	}
+
+	Drafts = cli.CommandDefinition{
+		Use:         "drafts",
+		Aliases:     []string{"draft"},
+		Description: "Manage the draft of your Realm app",
+		SubCommands: []cli.CommandDefinition{
+			{
+				Command:     &draft.CommandShow{},
+				Use:         "show",
+				Display:     "drafts show",
+				Description: "View the draft of your Realm app",
+				Help: `Displays the draft of your Realm app, if one exists, along with the number of
+changes it holds. A draft may be left behind by a session in the Realm UI or by
+an import which did not finish.`,
+			},
+			{
+				Command:     &draft.CommandDiff{},
+				Use:         "diff",
+				Display:     "drafts diff",
+				Description: "Show the changes held by the draft of your Realm app",
+				Help: `Displays the changes the draft of your Realm app would make once deployed,
+including changes to its static hosting files, dependencies, GraphQL
+configuration and schema.`,
+			},
+			{
+				Command:     &draft.CommandDiscard{},
+				Use:         "discard",
+				Display:     "drafts discard",
+				Description: "Discard the draft of your Realm app",
+				Help: `Discards the draft of your Realm app along with all of the changes it holds.
+Your deployed Realm app is not affected.`,
+			},
+			{
+				Command:     &draft.CommandDeploy{},
+				Use:         "deploy",
+				Display:     "drafts deploy",
+				Description: "Deploy the draft of your Realm app",
+				Help: `Displays the changes held by the draft of your Realm app and, once confirmed,
+deploys them and waits for the deployment to finish.`,
+			},
 		},
 	}
 
@@ -247,6 +482,66 @@ following will be displayed:
  - A list of logs, if present
  - The function result as a document
  - A list of error logs, if present
+
+Use --source to run the function source of a local Javascript file on your
+deployed Realm app instead, with real services and data, but without creating a
+draft or deployment. The file must set exports to a function, which is called
+with the provided args.
+
+Use --local to run a function of your local Realm app in a Node subprocess
+instead of on your deployed Realm app, so changes can be tried before they are
+pushed. The function runs with a stubbed context: context.values reads your
+local values (values which refer to secrets are not available),
+context.environment holds the values of your app environment or of
+--environment, and context.functions.execute runs your other local functions.
+Provide a Javascript module with --services to mock the services returned by
+context.services.get.
+
+By default the type of each arg is guessed. Use --args-file to read the args
+from a JSON array in a file, or --args - to read them from stdin. Use --strict
+to parse each arg as Extended JSON instead, so types such as dates, ObjectIds
+and 64-bit integers are kept exactly. Use --ejson to print the function result
+as either canonical or relaxed Extended JSON.
 `,
+		SubCommands: []cli.CommandDefinition{
+			{
+				Command:     &function.CommandCreate{},
+				Use:         "create",
+				Display:     "function create",
+				Description: "Create a function in your local Realm app",
+				Help: `Writes a new function to your local Realm app in the layout of its config
+version. For config version 2 and above, the function config is appended to
+functions/config.json and its source is written to functions/<name>.js.
+Otherwise, the function config and source are written to
+functions/<name>/config.json and functions/<name>/source.js.
+
+Use --template to start the function source from one of the following:
+ - default: a function which returns its arg
+ - endpoint: the handler of an HTTPS endpoint
+ - trigger: the handler of a database trigger
+ - auth: a custom function authentication provider
+
+Push your local Realm app to deploy the function.`,
+			},
+			{
+				Command:     &function.CommandBench{},
+				Use:         "bench",
+				Display:     "function bench",
+				Description: "Measure the latency and throughput of a function of your Realm app",
+				Help: `Runs a function of your deployed Realm app the number of times set by
+--requests, with up to --concurrency runs in flight at once, and reports:
+ - The number of runs which failed, along with their errors
+ - The time taken for all runs and the number of runs per second
+ - The min, mean, p50, p95, p99 and max of both the round trip time of each run
+   and the execution time reported by your Realm app
+ - A histogram of the round trip times
+
+A warm-up run is made before any of the measured runs, which is left out of the
+report so that any session refresh or cold start does not skew the results.
+
+The function args are provided the same way as they are with "function run".
+Use --output-format json to print the report as JSON.`,
+			},
+		},
 	}
 )
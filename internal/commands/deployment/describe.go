@@ -0,0 +1,55 @@
+package deployment
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/spf13/pflag"
+)
+
+// CommandDescribe is the `deployments describe` command
+type CommandDescribe struct {
+	inputs deploymentInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandDescribe) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+	fs.StringVarP(&cmd.inputs.deployment, flagDeployment, flagDeploymentShort, "", flagDeploymentUsageDescribe)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandDescribe) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandDescribe) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	var deployment realm.AppDeployment
+	if cmd.inputs.deployment != "" {
+		deployment, err = clients.Realm.Deployment(app.GroupID, app.ID, cmd.inputs.deployment)
+		if err != nil {
+			return err
+		}
+	} else {
+		deployments, err := clients.Realm.Deployments(app.GroupID, app.ID)
+		if err != nil {
+			return err
+		}
+		sortByMostRecent(deployments)
+
+		deployment, err = cmd.inputs.resolveDeployment(ui, deployments, "Which deployment would you like to describe?")
+		if err != nil {
+			return err
+		}
+	}
+
+	ui.Print(terminal.NewJSONLog("Deployment description", deployment))
+	return nil
+}
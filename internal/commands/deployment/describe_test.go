@@ -0,0 +1,105 @@
+package deployment
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestDeploymentsDescribeHandler(t *testing.T) {
+	t.Run("should describe the specified deployment", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{testApp}, nil
+		}
+
+		var capturedGroupID, capturedAppID, capturedDeploymentID string
+		realmClient.DeploymentFn = func(groupID, appID, deploymentID string) (realm.AppDeployment, error) {
+			capturedGroupID = groupID
+			capturedAppID = appID
+			capturedDeploymentID = deploymentID
+			return testDeployments[0], nil
+		}
+
+		cmd := &CommandDescribe{deploymentInputs{deployment: "deployment1"}}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+		assert.Equal(t, `Deployment description
+{
+  "_id": "deployment1",
+  "draft_id": "draft1",
+  "origin": "UI",
+  "deployed_at": 1600000000,
+  "status": "successful"
+}
+`, out.String())
+
+		t.Log("and should properly pass through the expected inputs")
+		assert.Equal(t, testApp.GroupID, capturedGroupID)
+		assert.Equal(t, testApp.ID, capturedAppID)
+		assert.Equal(t, "deployment1", capturedDeploymentID)
+	})
+
+	t.Run("should return an error", func(t *testing.T) {
+		for _, tc := range []struct {
+			description string
+			inputs      deploymentInputs
+			setupClient func() realm.Client
+			expectedErr error
+		}{
+			{
+				description: "when resolving the app fails",
+				setupClient: func() realm.Client {
+					realmClient := mock.RealmClient{}
+					realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+						return nil, errors.New("something bad happened")
+					}
+					return realmClient
+				},
+				expectedErr: errors.New("something bad happened"),
+			},
+			{
+				description: "when finding the deployment fails",
+				inputs:      deploymentInputs{deployment: "deployment1"},
+				setupClient: func() realm.Client {
+					realmClient := mock.RealmClient{}
+					realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+						return []realm.App{testApp}, nil
+					}
+					realmClient.DeploymentFn = func(groupID, appID, deploymentID string) (realm.AppDeployment, error) {
+						return realm.AppDeployment{}, errors.New("something bad happened")
+					}
+					return realmClient
+				},
+				expectedErr: errors.New("something bad happened"),
+			},
+			{
+				description: "when no deployment is specified and the app has no deployments",
+				setupClient: func() realm.Client {
+					realmClient := mock.RealmClient{}
+					realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+						return []realm.App{testApp}, nil
+					}
+					realmClient.DeploymentsFn = func(groupID, appID string) ([]realm.AppDeployment, error) {
+						return nil, nil
+					}
+					return realmClient
+				},
+				expectedErr: errNoDeployments,
+			},
+		} {
+			t.Run(tc.description, func(t *testing.T) {
+				cmd := &CommandDescribe{tc.inputs}
+
+				err := cmd.Handler(nil, nil, cli.Clients{Realm: tc.setupClient()})
+				assert.Equal(t, tc.expectedErr, err)
+			})
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package deployment
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	errNoDeployments             = errors.New("no deployments found for app")
+	errNoPreviousDeployment      = errors.New("failed to find a successful deployment prior to the current one")
+	errDeploymentNotSuccessful   = errors.New("can only roll back to a successful deployment")
+	errDeploymentAlreadyDeployed = errors.New("deployment is already the current deployment")
+)
+
+type errDeploymentNotFound struct {
+	deploymentID string
+}
+
+func (err errDeploymentNotFound) Error() string {
+	return fmt.Sprintf("failed to find deployment '%s'", err.deploymentID)
+}
+
+func (err errDeploymentNotFound) DisableUsage() struct{} { return struct{}{} }
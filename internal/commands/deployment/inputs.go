@@ -0,0 +1,65 @@
+package deployment
+
+import (
+	"fmt"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// Flag names and usages across the deployments commands
+const (
+	flagDeployment              = "deployment"
+	flagDeploymentShort         = "d"
+	flagDeploymentUsageDescribe = "the id of the deployment to describe"
+	flagDeploymentUsageRedeploy = "the id of the deployment to redeploy"
+
+	flagTo      = "to"
+	flagToUsage = "the id of the successful deployment to roll back to, defaults to the last successful deployment before the current one"
+)
+
+type deploymentInputs struct {
+	cli.ProjectInputs
+	deployment string
+}
+
+func (i *deploymentInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
+
+func (i deploymentInputs) resolveDeployment(ui terminal.UI, deployments []realm.AppDeployment, message string) (realm.AppDeployment, error) {
+	if i.deployment != "" {
+		return findDeployment(deployments, i.deployment)
+	}
+
+	if len(deployments) == 0 {
+		return realm.AppDeployment{}, errNoDeployments
+	}
+
+	deploymentsByOption := make(map[string]realm.AppDeployment, len(deployments))
+	options := make([]string, len(deployments))
+	for i, deployment := range deployments {
+		option := displayDeploymentOption(deployment)
+
+		options[i] = option
+		deploymentsByOption[option] = deployment
+	}
+
+	var selection string
+	if err := ui.AskOne(&selection, &survey.Select{Message: message, Options: options}); err != nil {
+		return realm.AppDeployment{}, fmt.Errorf("failed to select deployment: %s", err)
+	}
+	return deploymentsByOption[selection], nil
+}
+
+func findDeployment(deployments []realm.AppDeployment, deploymentID string) (realm.AppDeployment, error) {
+	for _, deployment := range deployments {
+		if deployment.ID == deploymentID {
+			return deployment, nil
+		}
+	}
+	return realm.AppDeployment{}, errDeploymentNotFound{deploymentID}
+}
@@ -0,0 +1,60 @@
+package deployment
+
+import (
+	"fmt"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/spf13/pflag"
+)
+
+// CommandList is the `deployments list` command
+type CommandList struct {
+	inputs listInputs
+}
+
+type listInputs struct {
+	cli.ProjectInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandList) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandList) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandList) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	deployments, err := clients.Realm.Deployments(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(deployments) == 0 {
+		ui.Print(terminal.NewTextLog("No available deployments to show"))
+		return nil
+	}
+
+	sortByMostRecent(deployments)
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Found %d deployments", len(deployments)),
+		tableHeaders(),
+		tableRows(deployments)...,
+	))
+	return nil
+}
+
+func (i *listInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
@@ -0,0 +1,125 @@
+package deployment
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+var (
+	testApp = realm.App{
+		ID:          "appID",
+		GroupID:     "projectID",
+		ClientAppID: "eggcorn-abcde",
+		Name:        "eggcorn",
+	}
+
+	testDeployments = []realm.AppDeployment{
+		{ID: "deployment1", Status: realm.DeploymentStatusSuccessful, DeployedAt: 1600000000, Origin: "UI", DraftID: "draft1"},
+		{ID: "deployment3", Status: realm.DeploymentStatusFailed, DeployedAt: 1600000200, Origin: "CLI", DraftID: "draft3"},
+		{ID: "deployment2", Status: realm.DeploymentStatusSuccessful, DeployedAt: 1600000100, Origin: "CLI", DraftID: "draft2"},
+	}
+)
+
+func TestDeploymentsListHandler(t *testing.T) {
+	for _, tc := range []struct {
+		description    string
+		deployments    []realm.AppDeployment
+		expectedOutput string
+	}{
+		{
+			description:    "should list no deployments with no app deployments found",
+			expectedOutput: "No available deployments to show\n",
+		},
+		{
+			description: "should list the deployments found for the app with the most recent first",
+			deployments: append([]realm.AppDeployment{}, testDeployments...),
+			expectedOutput: strings.Join(
+				[]string{
+					"Found 3 deployments",
+					"  ID           Status      Deployed At                    Origin  Draft ID",
+					"  -----------  ----------  -----------------------------  ------  --------",
+					"  deployment3  failed      2020-09-13 12:30:00 +0000 UTC  CLI     draft3  ",
+					"  deployment2  successful  2020-09-13 12:28:20 +0000 UTC  CLI     draft2  ",
+					"  deployment1  successful  2020-09-13 12:26:40 +0000 UTC  UI      draft1  ",
+					"",
+				},
+				"\n",
+			),
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			out, ui := mock.NewUI()
+
+			realmClient := mock.RealmClient{}
+			realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+				return []realm.App{testApp}, nil
+			}
+
+			var capturedGroupID, capturedAppID string
+			realmClient.DeploymentsFn = func(groupID, appID string) ([]realm.AppDeployment, error) {
+				capturedGroupID = groupID
+				capturedAppID = appID
+				return tc.deployments, nil
+			}
+
+			cmd := &CommandList{listInputs{cli.ProjectInputs{
+				Project: testApp.GroupID,
+				App:     testApp.ID,
+			}}}
+
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+			assert.Equal(t, tc.expectedOutput, out.String())
+
+			t.Log("and should properly pass through the expected inputs")
+			assert.Equal(t, testApp.GroupID, capturedGroupID)
+			assert.Equal(t, testApp.ID, capturedAppID)
+		})
+	}
+
+	t.Run("should return an error", func(t *testing.T) {
+		for _, tc := range []struct {
+			description string
+			setupClient func() realm.Client
+			expectedErr error
+		}{
+			{
+				description: "when resolving the app fails",
+				setupClient: func() realm.Client {
+					realmClient := mock.RealmClient{}
+					realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+						return nil, errors.New("something bad happened")
+					}
+					return realmClient
+				},
+				expectedErr: errors.New("something bad happened"),
+			},
+			{
+				description: "when finding the deployments fails",
+				setupClient: func() realm.Client {
+					realmClient := mock.RealmClient{}
+					realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+						return []realm.App{testApp}, nil
+					}
+					realmClient.DeploymentsFn = func(groupID, appID string) ([]realm.AppDeployment, error) {
+						return nil, errors.New("something bad happened")
+					}
+					return realmClient
+				},
+				expectedErr: errors.New("something bad happened"),
+			},
+		} {
+			t.Run(tc.description, func(t *testing.T) {
+				cmd := &CommandList{}
+
+				err := cmd.Handler(nil, nil, cli.Clients{Realm: tc.setupClient()})
+				assert.Equal(t, tc.expectedErr, err)
+			})
+		}
+	})
+}
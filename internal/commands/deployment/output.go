@@ -0,0 +1,53 @@
+package deployment
+
+import (
+	"sort"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+)
+
+const (
+	headerID         = "ID"
+	headerStatus     = "Status"
+	headerDeployedAt = "Deployed At"
+	headerOrigin     = "Origin"
+	headerDraftID    = "Draft ID"
+)
+
+func tableHeaders() []string {
+	return []string{headerID, headerStatus, headerDeployedAt, headerOrigin, headerDraftID}
+}
+
+func tableRows(deployments []realm.AppDeployment) []map[string]interface{} {
+	rows := make([]map[string]interface{}, 0, len(deployments))
+	for _, deployment := range deployments {
+		rows = append(rows, map[string]interface{}{
+			headerID:         deployment.ID,
+			headerStatus:     deployment.Status,
+			headerDeployedAt: deployedAt(deployment),
+			headerOrigin:     deployment.Origin,
+			headerDraftID:    deployment.DraftID,
+		})
+	}
+	return rows
+}
+
+func deployedAt(deployment realm.AppDeployment) string {
+	if deployment.DeployedAt == 0 {
+		return "n/a"
+	}
+	return time.Unix(deployment.DeployedAt, 0).UTC().String()
+}
+
+func displayDeploymentOption(deployment realm.AppDeployment) string {
+	return deployment.ID + terminal.DelimiterInline + string(deployment.Status) + terminal.DelimiterInline + deployedAt(deployment)
+}
+
+// sortByMostRecent sorts the deployments so the most recently deployed comes first
+func sortByMostRecent(deployments []realm.AppDeployment) {
+	sort.SliceStable(deployments, func(i, j int) bool {
+		return deployments[i].DeployedAt > deployments[j].DeployedAt
+	})
+}
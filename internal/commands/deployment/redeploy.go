@@ -0,0 +1,58 @@
+package deployment
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/spf13/pflag"
+)
+
+// CommandRedeploy is the `deployments redeploy` command
+type CommandRedeploy struct {
+	inputs deploymentInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandRedeploy) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+	fs.StringVarP(&cmd.inputs.deployment, flagDeployment, flagDeploymentShort, "", flagDeploymentUsageRedeploy)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandRedeploy) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandRedeploy) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	deployments, err := clients.Realm.Deployments(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+	sortByMostRecent(deployments)
+
+	deployment, err := cmd.inputs.resolveDeployment(ui, deployments, "Which deployment would you like to redeploy?")
+	if err != nil {
+		return err
+	}
+
+	proceed, err := ui.Confirm("Are you sure you want to redeploy deployment '%s'?", deployment.ID)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return nil
+	}
+
+	if err := clients.Realm.RedeployDeployment(app.GroupID, app.ID, deployment.ID); err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully redeployed deployment: %s", deployment.ID))
+	return nil
+}
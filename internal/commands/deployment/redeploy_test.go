@@ -0,0 +1,86 @@
+package deployment
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestDeploymentsRedeployHandler(t *testing.T) {
+	t.Run("should redeploy the specified deployment", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{testApp}, nil
+		}
+		realmClient.DeploymentsFn = func(groupID, appID string) ([]realm.AppDeployment, error) {
+			return append([]realm.AppDeployment{}, testDeployments...), nil
+		}
+
+		var capturedGroupID, capturedAppID, capturedDeploymentID string
+		realmClient.RedeployDeploymentFn = func(groupID, appID, deploymentID string) error {
+			capturedGroupID = groupID
+			capturedAppID = appID
+			capturedDeploymentID = deploymentID
+			return nil
+		}
+
+		cmd := &CommandRedeploy{deploymentInputs{deployment: "deployment2"}}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+		assert.Equal(t, "Successfully redeployed deployment: deployment2\n", out.String())
+
+		t.Log("and should properly pass through the expected inputs")
+		assert.Equal(t, testApp.GroupID, capturedGroupID)
+		assert.Equal(t, testApp.ID, capturedAppID)
+		assert.Equal(t, "deployment2", capturedDeploymentID)
+	})
+
+	t.Run("should return an error", func(t *testing.T) {
+		for _, tc := range []struct {
+			description string
+			deployment  string
+			redeployErr error
+			expectedErr error
+		}{
+			{
+				description: "when the deployment cannot be found",
+				deployment:  "deployment4",
+				expectedErr: errDeploymentNotFound{"deployment4"},
+			},
+			{
+				description: "when the redeploy fails",
+				deployment:  "deployment1",
+				redeployErr: errors.New("something bad happened"),
+				expectedErr: errors.New("something bad happened"),
+			},
+		} {
+			t.Run(tc.description, func(t *testing.T) {
+				ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, new(bytes.Buffer))
+
+				realmClient := mock.RealmClient{}
+				realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+					return []realm.App{testApp}, nil
+				}
+				realmClient.DeploymentsFn = func(groupID, appID string) ([]realm.AppDeployment, error) {
+					return append([]realm.AppDeployment{}, testDeployments...), nil
+				}
+				realmClient.RedeployDeploymentFn = func(groupID, appID, deploymentID string) error {
+					return tc.redeployErr
+				}
+
+				cmd := &CommandRedeploy{deploymentInputs{deployment: tc.deployment}}
+
+				err := cmd.Handler(nil, ui, cli.Clients{Realm: realmClient})
+				assert.Equal(t, tc.expectedErr, err)
+			})
+		}
+	})
+}
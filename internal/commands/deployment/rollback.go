@@ -0,0 +1,106 @@
+package deployment
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/spf13/pflag"
+)
+
+// CommandRollback is the `deployments rollback` command
+type CommandRollback struct {
+	inputs rollbackInputs
+}
+
+type rollbackInputs struct {
+	cli.ProjectInputs
+	to string
+}
+
+// Flags is the command flags
+func (cmd *CommandRollback) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+	fs.StringVar(&cmd.inputs.to, flagTo, "", flagToUsage)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandRollback) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandRollback) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	deployments, err := clients.Realm.Deployments(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+	sortByMostRecent(deployments)
+
+	deployment, err := cmd.inputs.resolveTarget(deployments)
+	if err != nil {
+		return err
+	}
+
+	proceed, err := ui.Confirm("Are you sure you want to roll back your app to deployment '%s' (deployed at %s)?", deployment.ID, deployedAt(deployment))
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return nil
+	}
+
+	if err := clients.Realm.RedeployDeployment(app.GroupID, app.ID, deployment.ID); err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully rolled back app to deployment: %s", deployment.ID))
+	return nil
+}
+
+func (i *rollbackInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
+
+// resolveTarget finds the deployment to roll back to from the provided deployments,
+// which are expected to be sorted with the most recent deployment first
+func (i rollbackInputs) resolveTarget(deployments []realm.AppDeployment) (realm.AppDeployment, error) {
+	current, hasCurrent := currentDeployment(deployments)
+
+	if i.to != "" {
+		deployment, err := findDeployment(deployments, i.to)
+		if err != nil {
+			return realm.AppDeployment{}, err
+		}
+		if deployment.Status != realm.DeploymentStatusSuccessful {
+			return realm.AppDeployment{}, errDeploymentNotSuccessful
+		}
+		if hasCurrent && deployment.ID == current.ID {
+			return realm.AppDeployment{}, errDeploymentAlreadyDeployed
+		}
+		return deployment, nil
+	}
+
+	for _, deployment := range deployments {
+		if deployment.Status != realm.DeploymentStatusSuccessful || deployment.ID == current.ID {
+			continue
+		}
+		return deployment, nil
+	}
+	return realm.AppDeployment{}, errNoPreviousDeployment
+}
+
+// currentDeployment finds the most recent successful deployment
+func currentDeployment(deployments []realm.AppDeployment) (realm.AppDeployment, bool) {
+	for _, deployment := range deployments {
+		if deployment.Status == realm.DeploymentStatusSuccessful {
+			return deployment, true
+		}
+	}
+	return realm.AppDeployment{}, false
+}
@@ -0,0 +1,115 @@
+package deployment
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestDeploymentsRollbackHandler(t *testing.T) {
+	for _, tc := range []struct {
+		description        string
+		to                 string
+		expectedDeployment string
+	}{
+		{
+			description:        "should roll back to the last successful deployment before the current one by default",
+			expectedDeployment: "deployment1",
+		},
+		{
+			description:        "should roll back to the specified deployment",
+			to:                 "deployment1",
+			expectedDeployment: "deployment1",
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+			realmClient := mock.RealmClient{}
+			realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+				return []realm.App{testApp}, nil
+			}
+			realmClient.DeploymentsFn = func(groupID, appID string) ([]realm.AppDeployment, error) {
+				return append([]realm.AppDeployment{}, testDeployments...), nil
+			}
+
+			var capturedDeploymentID string
+			realmClient.RedeployDeploymentFn = func(groupID, appID, deploymentID string) error {
+				capturedDeploymentID = deploymentID
+				return nil
+			}
+
+			cmd := &CommandRollback{rollbackInputs{to: tc.to}}
+
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+			assert.Equal(t, "Successfully rolled back app to deployment: "+tc.expectedDeployment+"\n", out.String())
+			assert.Equal(t, tc.expectedDeployment, capturedDeploymentID)
+		})
+	}
+
+	t.Run("should return an error", func(t *testing.T) {
+		for _, tc := range []struct {
+			description string
+			to          string
+			deployments []realm.AppDeployment
+			redeployErr error
+			expectedErr error
+		}{
+			{
+				description: "when the specified deployment cannot be found",
+				to:          "deployment4",
+				deployments: testDeployments,
+				expectedErr: errDeploymentNotFound{"deployment4"},
+			},
+			{
+				description: "when the specified deployment was not successful",
+				to:          "deployment3",
+				deployments: testDeployments,
+				expectedErr: errDeploymentNotSuccessful,
+			},
+			{
+				description: "when the specified deployment is the current deployment",
+				to:          "deployment2",
+				deployments: testDeployments,
+				expectedErr: errDeploymentAlreadyDeployed,
+			},
+			{
+				description: "when there is no previous successful deployment",
+				deployments: testDeployments[1:],
+				expectedErr: errNoPreviousDeployment,
+			},
+			{
+				description: "when the redeploy fails",
+				deployments: testDeployments,
+				redeployErr: errors.New("something bad happened"),
+				expectedErr: errors.New("something bad happened"),
+			},
+		} {
+			t.Run(tc.description, func(t *testing.T) {
+				ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, new(bytes.Buffer))
+
+				realmClient := mock.RealmClient{}
+				realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+					return []realm.App{testApp}, nil
+				}
+				realmClient.DeploymentsFn = func(groupID, appID string) ([]realm.AppDeployment, error) {
+					return append([]realm.AppDeployment{}, tc.deployments...), nil
+				}
+				realmClient.RedeployDeploymentFn = func(groupID, appID, deploymentID string) error {
+					return tc.redeployErr
+				}
+
+				cmd := &CommandRollback{rollbackInputs{to: tc.to}}
+
+				err := cmd.Handler(nil, ui, cli.Clients{Realm: realmClient})
+				assert.Equal(t, tc.expectedErr, err)
+			})
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package draft
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/spf13/pflag"
+)
+
+// CommandDeploy is the `drafts deploy` command
+type CommandDeploy struct {
+	inputs draftInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandDeploy) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandDeploy) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandDeploy) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	draft, ok, err := findDraft(clients.Realm, app)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return realm.ErrDraftNotFound
+	}
+
+	diff, err := clients.Realm.DiffDraft(app.GroupID, app.ID, draft.ID)
+	if err != nil {
+		return err
+	}
+	ui.Print(cli.DraftDiffLogs(diff, msgDraftChanges, msgDraftEmpty)...)
+
+	proceed, err := ui.Confirm("Are you sure you want to deploy draft '%s'?", draft.ID)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return nil
+	}
+
+	deployment, err := clients.Realm.DeployDraft(app.GroupID, app.ID, draft.ID)
+	if err != nil {
+		return err
+	}
+
+	deployment, err = cli.WaitForDeployment(ui, clients.Realm, app.GroupID, app.ID, deployment, "Deploying draft...")
+	if err != nil {
+		return err
+	}
+
+	if deployment.Status == realm.DeploymentStatusFailed {
+		return cli.ErrDeploymentFailed{DeploymentID: deployment.ID, Reason: deployment.StatusErrorMessage}
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully deployed draft: %s", draft.ID))
+	return nil
+}
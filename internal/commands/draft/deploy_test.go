@@ -0,0 +1,122 @@
+package draft
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestDraftsDeployHandler(t *testing.T) {
+	t.Run("should show the changes and deploy the draft of the app", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{testApp}, nil
+		}
+		realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return testDraft, nil
+		}
+		realmClient.DiffDraftFn = func(groupID, appID, draftID string) (realm.AppDraftDiff, error) {
+			return realm.AppDraftDiff{Diffs: []string{"diff1"}}, nil
+		}
+
+		var capturedGroupID, capturedAppID, capturedDraftID string
+		realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+			capturedGroupID = groupID
+			capturedAppID = appID
+			capturedDraftID = draftID
+			return realm.AppDeployment{ID: "deploymentID", Status: realm.DeploymentStatusSuccessful}, nil
+		}
+
+		cmd := &CommandDeploy{}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+		assert.Equal(t, `The draft of your app holds the following changes...
+  diff1
+Successfully deployed draft: draftID
+`, out.String())
+
+		t.Log("and should properly pass through the expected inputs")
+		assert.Equal(t, testApp.GroupID, capturedGroupID)
+		assert.Equal(t, testApp.ID, capturedAppID)
+		assert.Equal(t, testDraft.ID, capturedDraftID)
+	})
+
+	t.Run("should return an error", func(t *testing.T) {
+		for _, tc := range []struct {
+			description string
+			setupClient func() realm.Client
+			expectedErr error
+		}{
+			{
+				description: "when the app has no draft",
+				setupClient: func() realm.Client {
+					realmClient := mock.RealmClient{}
+					realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+						return []realm.App{testApp}, nil
+					}
+					realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+						return realm.AppDraft{}, realm.ErrDraftNotFound
+					}
+					return realmClient
+				},
+				expectedErr: realm.ErrDraftNotFound,
+			},
+			{
+				description: "when deploying the draft fails",
+				setupClient: func() realm.Client {
+					realmClient := mock.RealmClient{}
+					realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+						return []realm.App{testApp}, nil
+					}
+					realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+						return testDraft, nil
+					}
+					realmClient.DiffDraftFn = func(groupID, appID, draftID string) (realm.AppDraftDiff, error) {
+						return realm.AppDraftDiff{}, nil
+					}
+					realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+						return realm.AppDeployment{}, errors.New("something bad happened")
+					}
+					return realmClient
+				},
+				expectedErr: errors.New("something bad happened"),
+			},
+			{
+				description: "when the deployment fails",
+				setupClient: func() realm.Client {
+					realmClient := mock.RealmClient{}
+					realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+						return []realm.App{testApp}, nil
+					}
+					realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+						return testDraft, nil
+					}
+					realmClient.DiffDraftFn = func(groupID, appID, draftID string) (realm.AppDraftDiff, error) {
+						return realm.AppDraftDiff{}, nil
+					}
+					realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+						return realm.AppDeployment{ID: "deploymentID", Status: realm.DeploymentStatusFailed, StatusErrorMessage: "something went wrong"}, nil
+					}
+					return realmClient
+				},
+				expectedErr: cli.ErrDeploymentFailed{DeploymentID: "deploymentID", Reason: "something went wrong"},
+			},
+		} {
+			t.Run(tc.description, func(t *testing.T) {
+				ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, new(bytes.Buffer))
+
+				cmd := &CommandDeploy{}
+
+				assert.Equal(t, tc.expectedErr, cmd.Handler(nil, ui, cli.Clients{Realm: tc.setupClient()}))
+			})
+		}
+	})
+}
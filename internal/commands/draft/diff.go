@@ -0,0 +1,48 @@
+package draft
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/spf13/pflag"
+)
+
+// CommandDiff is the `drafts diff` command
+type CommandDiff struct {
+	inputs draftInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandDiff) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandDiff) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandDiff) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	draft, ok, err := findDraft(clients.Realm, app)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		ui.Print(terminal.NewTextLog(msgNoDraft))
+		return nil
+	}
+
+	diff, err := clients.Realm.DiffDraft(app.GroupID, app.ID, draft.ID)
+	if err != nil {
+		return err
+	}
+
+	ui.Print(cli.DraftDiffLogs(diff, msgDraftChanges, msgDraftEmpty)...)
+	return nil
+}
@@ -0,0 +1,116 @@
+package draft
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestDraftsDiffHandler(t *testing.T) {
+	for _, tc := range []struct {
+		description    string
+		diff           realm.AppDraftDiff
+		expectedOutput string
+	}{
+		{
+			description:    "should report an empty draft",
+			expectedOutput: "The draft of your app is empty\n",
+		},
+		{
+			description: "should show every section of the draft diff which has changes",
+			diff: realm.AppDraftDiff{
+				Diffs: []string{"diff1", "diff2"},
+				HostingFilesDiff: realm.HostingFilesDiff{
+					Added:   []string{"hosting_added1"},
+					Deleted: []string{"hosting_deleted1"},
+				},
+				DependenciesDiff: realm.DependenciesDiff{
+					Added: []realm.DependencyData{{Name: "dep_added1", Version: "v1"}},
+				},
+				GraphQLConfigDiff: realm.GraphQLConfigDiff{
+					FieldDiffs: []realm.FieldDiff{{Field: "gql_field1", PreviousValue: "previous", UpdatedValue: "updated"}},
+				},
+				SchemaOptionsDiff: realm.SchemaOptionsDiff{
+					RestValidationDiffs: []realm.FieldDiff{{Field: "rest_validation_field1", PreviousValue: "old", UpdatedValue: "new"}},
+				},
+			},
+			expectedOutput: strings.Join(
+				[]string{
+					"The draft of your app holds the following changes...",
+					"  diff1",
+					"  diff2",
+					"With changes to your static hosting files...",
+					"  added: hosting_added1",
+					"  deleted: hosting_deleted1",
+					"With changes to your app dependencies...",
+					"  + dep_added1@v1",
+					"With changes to your GraphQL configuration...",
+					"  gql_field1: previous -> updated",
+					"With changes to your app schema...",
+					"  rest_validation_field1: old -> new",
+					"",
+				},
+				"\n",
+			),
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			out, ui := mock.NewUI()
+
+			realmClient := mock.RealmClient{}
+			realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+				return []realm.App{testApp}, nil
+			}
+			realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+				return testDraft, nil
+			}
+			realmClient.DiffDraftFn = func(groupID, appID, draftID string) (realm.AppDraftDiff, error) {
+				return tc.diff, nil
+			}
+
+			cmd := &CommandDiff{}
+
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+			assert.Equal(t, tc.expectedOutput, out.String())
+		})
+	}
+
+	t.Run("should report when the app has no draft", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{testApp}, nil
+		}
+		realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return realm.AppDraft{}, realm.ErrDraftNotFound
+		}
+
+		cmd := &CommandDiff{}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+		assert.Equal(t, "No draft exists for your app\n", out.String())
+	})
+
+	t.Run("should return an error when diffing the draft fails", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{testApp}, nil
+		}
+		realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return testDraft, nil
+		}
+		realmClient.DiffDraftFn = func(groupID, appID, draftID string) (realm.AppDraftDiff, error) {
+			return realm.AppDraftDiff{}, errors.New("something bad happened")
+		}
+
+		cmd := &CommandDiff{}
+
+		assert.Equal(t, errors.New("something bad happened"), cmd.Handler(nil, nil, cli.Clients{Realm: realmClient}))
+	})
+}
@@ -0,0 +1,55 @@
+package draft
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/spf13/pflag"
+)
+
+// CommandDiscard is the `drafts discard` command
+type CommandDiscard struct {
+	inputs draftInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandDiscard) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandDiscard) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandDiscard) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	draft, ok, err := findDraft(clients.Realm, app)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		ui.Print(terminal.NewTextLog(msgNoDraft))
+		return nil
+	}
+
+	proceed, err := ui.Confirm("Are you sure you want to discard draft '%s'?", draft.ID)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return nil
+	}
+
+	if err := clients.Realm.DiscardDraft(app.GroupID, app.ID, draft.ID); err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully discarded draft: %s", draft.ID))
+	return nil
+}
@@ -0,0 +1,85 @@
+package draft
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestDraftsDiscardHandler(t *testing.T) {
+	t.Run("should discard the draft of the app", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{testApp}, nil
+		}
+		realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return testDraft, nil
+		}
+
+		var capturedGroupID, capturedAppID, capturedDraftID string
+		realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
+			capturedGroupID = groupID
+			capturedAppID = appID
+			capturedDraftID = draftID
+			return nil
+		}
+
+		cmd := &CommandDiscard{}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+		assert.Equal(t, "Successfully discarded draft: draftID\n", out.String())
+
+		t.Log("and should properly pass through the expected inputs")
+		assert.Equal(t, testApp.GroupID, capturedGroupID)
+		assert.Equal(t, testApp.ID, capturedAppID)
+		assert.Equal(t, testDraft.ID, capturedDraftID)
+	})
+
+	t.Run("should do nothing when the app has no draft", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{testApp}, nil
+		}
+		realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return realm.AppDraft{}, realm.ErrDraftNotFound
+		}
+		realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
+			t.Fatal("expected no draft to be discarded")
+			return nil
+		}
+
+		cmd := &CommandDiscard{}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+		assert.Equal(t, "No draft exists for your app\n", out.String())
+	})
+
+	t.Run("should return an error when discarding the draft fails", func(t *testing.T) {
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, new(bytes.Buffer))
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{testApp}, nil
+		}
+		realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return testDraft, nil
+		}
+		realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
+			return errors.New("something bad happened")
+		}
+
+		cmd := &CommandDiscard{}
+
+		assert.Equal(t, errors.New("something bad happened"), cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+	})
+}
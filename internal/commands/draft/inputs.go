@@ -0,0 +1,27 @@
+package draft
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+)
+
+type draftInputs struct {
+	cli.ProjectInputs
+}
+
+func (i *draftInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
+
+// findDraft finds the draft of the app, returning a boolean indicating if the app has a draft
+func findDraft(realmClient realm.Client, app realm.App) (realm.AppDraft, bool, error) {
+	draft, err := realmClient.Draft(app.GroupID, app.ID)
+	if err == realm.ErrDraftNotFound {
+		return realm.AppDraft{}, false, nil
+	}
+	if err != nil {
+		return realm.AppDraft{}, false, err
+	}
+	return draft, true, nil
+}
@@ -0,0 +1,7 @@
+package draft
+
+const (
+	msgNoDraft      = "No draft exists for your app"
+	msgDraftChanges = "The draft of your app holds the following changes..."
+	msgDraftEmpty   = "The draft of your app is empty"
+)
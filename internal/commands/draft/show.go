@@ -0,0 +1,51 @@
+package draft
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/spf13/pflag"
+)
+
+// CommandShow is the `drafts show` command
+type CommandShow struct {
+	inputs draftInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandShow) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandShow) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandShow) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	draft, ok, err := findDraft(clients.Realm, app)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		ui.Print(terminal.NewTextLog(msgNoDraft))
+		return nil
+	}
+
+	diff, err := clients.Realm.DiffDraft(app.GroupID, app.ID, draft.ID)
+	if err != nil {
+		return err
+	}
+
+	ui.Print(
+		terminal.NewJSONLog("Draft description", draft),
+		terminal.NewTextLog("The draft holds %d change(s)", diff.Len()),
+	)
+	return nil
+}
@@ -0,0 +1,93 @@
+package draft
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+var (
+	testApp = realm.App{
+		ID:          "appID",
+		GroupID:     "projectID",
+		ClientAppID: "eggcorn-abcde",
+		Name:        "eggcorn",
+	}
+
+	testDraft = realm.AppDraft{ID: "draftID"}
+)
+
+func TestDraftsShowHandler(t *testing.T) {
+	t.Run("should show the draft of the app", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{testApp}, nil
+		}
+
+		var capturedGroupID, capturedAppID, capturedDraftID string
+		realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			capturedGroupID = groupID
+			capturedAppID = appID
+			return testDraft, nil
+		}
+		realmClient.DiffDraftFn = func(groupID, appID, draftID string) (realm.AppDraftDiff, error) {
+			capturedDraftID = draftID
+			return realm.AppDraftDiff{
+				Diffs:            []string{"diff1", "diff2"},
+				HostingFilesDiff: realm.HostingFilesDiff{Added: []string{"/index.html"}},
+			}, nil
+		}
+
+		cmd := &CommandShow{}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+		assert.Equal(t, `Draft description
+{
+  "_id": "draftID"
+}
+The draft holds 3 change(s)
+`, out.String())
+
+		t.Log("and should properly pass through the expected inputs")
+		assert.Equal(t, testApp.GroupID, capturedGroupID)
+		assert.Equal(t, testApp.ID, capturedAppID)
+		assert.Equal(t, testDraft.ID, capturedDraftID)
+	})
+
+	t.Run("should report when the app has no draft", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{testApp}, nil
+		}
+		realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return realm.AppDraft{}, realm.ErrDraftNotFound
+		}
+
+		cmd := &CommandShow{}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+		assert.Equal(t, "No draft exists for your app\n", out.String())
+	})
+
+	t.Run("should return an error when finding the draft fails", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{testApp}, nil
+		}
+		realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return realm.AppDraft{}, errors.New("something bad happened")
+		}
+
+		cmd := &CommandShow{}
+
+		assert.Equal(t, errors.New("something bad happened"), cmd.Handler(nil, nil, cli.Clients{Realm: realmClient}))
+	})
+}
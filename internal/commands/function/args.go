@@ -0,0 +1,204 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// argsStdin is the --args value which reads the function args from stdin
+	argsStdin = "-"
+
+	ejsonCanonical = "canonical"
+	ejsonRelaxed   = "relaxed"
+)
+
+// argsSource describes where the function args are read from, for display while the function runs
+func (i inputs) argsSource() string {
+	if i.ArgsFile != "" {
+		return "from " + i.ArgsFile
+	}
+	if len(i.Args) == 1 && i.Args[0] == argsStdin {
+		return "from stdin"
+	}
+	return fmt.Sprintf("%s", i.Args)
+}
+
+// resolveArgs resolves the function args from either the --args flags, an args file or stdin.
+// Args parsed as Extended JSON are returned in the canonical or relaxed format as specified
+func (i inputs) resolveArgs(canonical bool) ([]interface{}, error) {
+	if i.ArgsFile != "" {
+		data, err := ioutil.ReadFile(i.ArgsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read args file: %s", err)
+		}
+		return parseArgsArray(data, i.Strict, canonical)
+	}
+
+	if len(i.Args) == 1 && i.Args[0] == argsStdin {
+		data, err := ioutil.ReadAll(i.stdin())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read args from stdin: %s", err)
+		}
+		return parseArgsArray(data, i.Strict, canonical)
+	}
+
+	if i.Strict {
+		args := make([]interface{}, 0, len(i.Args))
+		for _, arg := range i.Args {
+			value, err := ejsonValue([]byte(arg), canonical)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse arg '%s' as Extended JSON: %s", arg, err)
+			}
+			args = append(args, value)
+		}
+		return args, nil
+	}
+
+	return parseArgs(i.Args)
+}
+
+func (i inputs) stdin() io.Reader {
+	if i.in != nil {
+		return i.in
+	}
+	return os.Stdin
+}
+
+// parseArgsArray parses the function args from a JSON array, where each arg is kept exactly as written
+// unless strict is set, in which case each arg is parsed as Extended JSON
+func parseArgsArray(data []byte, strict, canonical bool) ([]interface{}, error) {
+	var rawArgs []json.RawMessage
+	if err := json.Unmarshal(data, &rawArgs); err != nil {
+		return nil, fmt.Errorf("args must be a JSON array: %s", err)
+	}
+
+	args := make([]interface{}, 0, len(rawArgs))
+	for i, rawArg := range rawArgs {
+		if !strict {
+			args = append(args, rawArg)
+			continue
+		}
+		value, err := ejsonValue(rawArg, canonical)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse arg at position %d as Extended JSON: %s", i+1, err)
+		}
+		args = append(args, value)
+	}
+	return args, nil
+}
+
+// parseArgs parses the function args by guessing the type of each arg
+func parseArgs(rawArgs []string) ([]interface{}, error) {
+	args := make([]interface{}, 0, len(rawArgs))
+	for _, arg := range rawArgs {
+		if isJSON(arg) {
+			var argNew interface{}
+			if err := json.Unmarshal([]byte(arg), &argNew); err != nil {
+				return nil, err
+			}
+			args = append(args, argNew)
+			continue
+		}
+
+		if isInt(arg) {
+			num, err := strconv.Atoi(arg)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, num)
+			continue
+		}
+
+		if isFloat(arg) {
+			num, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, num)
+			continue
+		}
+
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+func isJSON(data string) bool {
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &obj); err == nil {
+		return true
+	}
+
+	var list []interface{}
+	if err := json.Unmarshal([]byte(data), &list); err == nil {
+		return true
+	}
+
+	return false
+}
+
+func isInt(data string) bool {
+	if _, err := strconv.Atoi(data); err != nil {
+		return false
+	}
+	return true
+}
+
+func isFloat(data string) bool {
+	if _, err := strconv.ParseFloat(data, 64); err != nil {
+		return false
+	}
+	return true
+}
+
+// ejsonValue parses the Extended JSON value and returns it in the canonical or relaxed format
+func ejsonValue(data []byte, canonical bool) (json.RawMessage, error) {
+	// Extended JSON can only be parsed as a document, so the value is wrapped in one
+	wrapped := make([]byte, 0, len(data)+6)
+	wrapped = append(wrapped, `{"v":`...)
+	wrapped = append(wrapped, data...)
+	wrapped = append(wrapped, '}')
+
+	var doc bson.D
+	if err := bson.UnmarshalExtJSON(wrapped, false, &doc); err != nil {
+		return nil, err
+	}
+
+	out, err := bson.MarshalExtJSON(doc, canonical, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var value struct {
+		V json.RawMessage `json:"v"`
+	}
+	if err := json.Unmarshal(out, &value); err != nil {
+		return nil, err
+	}
+	return value.V, nil
+}
+
+// ejsonResult returns the function result in the provided Extended JSON format
+func ejsonResult(result interface{}, format string) (interface{}, error) {
+	if format == "" {
+		return result, nil
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := ejsonValue(data, format == ejsonCanonical)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format result as Extended JSON: %s", err)
+	}
+	return value, nil
+}
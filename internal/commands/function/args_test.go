@@ -0,0 +1,117 @@
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestResolveArgs(t *testing.T) {
+	for _, tc := range []struct {
+		description  string
+		inputs       inputs
+		canonical    bool
+		expectedArgs string
+	}{
+		{
+			description:  "should guess the type of each arg by default",
+			inputs:       inputs{Args: []string{"42", "1.5", "eggcorn", `{"a":1}`}},
+			expectedArgs: `[42,1.5,"eggcorn",{"a":1}]`,
+		},
+		{
+			description:  "should parse each arg as canonical extended json in strict mode",
+			inputs:       inputs{Args: []string{`"42"`, "42", `{"$date":"2021-01-01T00:00:00Z"}`}, Strict: true},
+			canonical:    true,
+			expectedArgs: `["42",{"$numberInt":"42"},{"$date":{"$numberLong":"1609459200000"}}]`,
+		},
+		{
+			description:  "should parse each arg as relaxed extended json in strict mode",
+			inputs:       inputs{Args: []string{`"42"`, "42", `{"$date":"2021-01-01T00:00:00Z"}`}, Strict: true},
+			expectedArgs: `["42",42,{"$date":"2021-01-01T00:00:00Z"}]`,
+		},
+		{
+			description:  "should keep the args of an args file exactly as written",
+			inputs:       inputs{ArgsFile: "testdata/args.json"},
+			canonical:    true,
+			expectedArgs: `["42",9007199254740993,{"createdAt":{"$date":"2021-01-01T00:00:00Z"},"_id":{"$oid":"5f1b2c3d4e5f6a7b8c9d0e1f"}}]`,
+		},
+		{
+			description:  "should parse the args of an args file as extended json in strict mode",
+			inputs:       inputs{ArgsFile: "testdata/args.json", Strict: true},
+			canonical:    true,
+			expectedArgs: `["42",{"$numberLong":"9007199254740993"},{"createdAt":{"$date":{"$numberLong":"1609459200000"}},"_id":{"$oid":"5f1b2c3d4e5f6a7b8c9d0e1f"}}]`,
+		},
+		{
+			description:  "should read the args from stdin",
+			inputs:       inputs{Args: []string{"-"}, Strict: true, in: strings.NewReader(`["42", 42]`)},
+			canonical:    true,
+			expectedArgs: `["42",{"$numberInt":"42"}]`,
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			args, err := tc.inputs.resolveArgs(tc.canonical)
+			assert.Nil(t, err)
+
+			data, err := json.Marshal(args)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedArgs, string(data))
+		})
+	}
+
+	t.Run("should return an error when an arg is not valid extended json in strict mode", func(t *testing.T) {
+		_, err := inputs{Args: []string{"eggcorn"}, Strict: true}.resolveArgs(true)
+		assert.Equal(t, errors.New("failed to parse arg 'eggcorn' as Extended JSON: invalid JSON input. Position: 5. Character: e"), err)
+	})
+
+	t.Run("should return an error when the args are not a json array", func(t *testing.T) {
+		_, err := inputs{Args: []string{"-"}, in: strings.NewReader(`{"a":1}`)}.resolveArgs(true)
+		assert.NotNil(t, err)
+		assert.True(t, strings.HasPrefix(err.Error(), "args must be a JSON array: "), "expected args error, but got: %s", err)
+	})
+}
+
+func TestArgsSource(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		inputs      inputs
+		expected    string
+	}{
+		{"should describe the args flags", inputs{Args: []string{"1", `"two"`}}, `[1 "two"]`},
+		{"should describe the args file", inputs{ArgsFile: "args.json"}, "from args.json"},
+		{"should describe stdin", inputs{Args: []string{"-"}}, "from stdin"},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.inputs.argsSource())
+		})
+	}
+}
+
+func TestEJSONResult(t *testing.T) {
+	result := map[string]interface{}{
+		"count":     map[string]interface{}{"$numberInt": "3"},
+		"createdAt": map[string]interface{}{"$date": map[string]interface{}{"$numberLong": "1609459200000"}},
+	}
+
+	t.Run("should leave the result as is without a format", func(t *testing.T) {
+		out, err := ejsonResult(result, "")
+		assert.Nil(t, err)
+		assert.Equal(t, result, out)
+	})
+
+	for _, tc := range []struct {
+		format   string
+		expected string
+	}{
+		{ejsonCanonical, `{"count":{"$numberInt":"3"},"createdAt":{"$date":{"$numberLong":"1609459200000"}}}`},
+		{ejsonRelaxed, `{"count":3,"createdAt":{"$date":"2021-01-01T00:00:00Z"}}`},
+	} {
+		t.Run("should format the result as "+tc.format+" extended json", func(t *testing.T) {
+			out, err := ejsonResult(result, tc.format)
+			assert.Nil(t, err)
+			assert.Equal(t, json.RawMessage(tc.expected), out)
+		})
+	}
+}
@@ -0,0 +1,373 @@
+package function
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/briandowns/spinner"
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagRequests      = "requests"
+	flagRequestsShort = "n"
+	flagRequestsUsage = "the number of times to run the function"
+
+	flagConcurrency      = "concurrency"
+	flagConcurrencyShort = "c"
+	flagConcurrencyUsage = "the number of function runs to have in flight at once"
+
+	defaultRequests    = 10
+	defaultConcurrency = 1
+
+	benchHistogramBuckets = 10
+	benchHistogramWidth   = 40
+)
+
+var (
+	errBenchRequests    = errors.New("--" + flagRequests + " must be greater than 0")
+	errBenchConcurrency = errors.New("--" + flagConcurrency + " must be greater than 0")
+)
+
+type benchInputs struct {
+	inputs
+	Requests    int
+	Concurrency int
+}
+
+func (i *benchInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if i.Requests <= 0 {
+		return errBenchRequests
+	}
+	if i.Concurrency <= 0 {
+		return errBenchConcurrency
+	}
+	if i.Concurrency > i.Requests {
+		i.Concurrency = i.Requests
+	}
+	return i.inputs.Resolve(profile, ui)
+}
+
+// CommandBench is the `function bench` command
+type CommandBench struct {
+	inputs benchInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandBench) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+
+	fs.StringVar(&cmd.inputs.Name, flagFunctionName, "", flagFunctionNameUsage)
+	fs.StringArrayVar(&cmd.inputs.Args, flagFunctionArgs, nil, flagFunctionArgsUsage)
+	fs.StringVar(&cmd.inputs.ArgsFile, flagArgsFile, "", flagArgsFileUsage)
+	fs.BoolVar(&cmd.inputs.Strict, flagStrict, false, flagStrictUsage)
+	fs.StringVar(&cmd.inputs.User, flagAsUser, "", flagAsUserUsage)
+	fs.IntVarP(&cmd.inputs.Requests, flagRequests, flagRequestsShort, defaultRequests, flagRequestsUsage)
+	fs.IntVarP(&cmd.inputs.Concurrency, flagConcurrency, flagConcurrencyShort, defaultConcurrency, flagConcurrencyUsage)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandBench) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandBench) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	args, err := cmd.inputs.resolveArgs(true)
+	if err != nil {
+		return err
+	}
+
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	function, err := cmd.inputs.ResolveFunction(ui, clients.Realm, app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	s := spinner.New(terminal.SpinnerCircles, 250*time.Millisecond)
+	s.Suffix = fmt.Sprintf(" Running function %s %d time(s) with a concurrency of %d...", function.Name, cmd.inputs.Requests, cmd.inputs.Concurrency)
+
+	runBench := func() ([]benchSample, time.Duration) {
+		s.Start()
+		defer s.Stop()
+
+		return runBenchSamples(cmd.inputs.Requests, cmd.inputs.Concurrency, func() (realm.ExecutionResults, error) {
+			return clients.Realm.AppDebugExecuteFunction(app.GroupID, app.ID, cmd.inputs.User, function.Name, args)
+		})
+	}
+
+	samples, elapsed := runBench()
+
+	report := newBenchReport(function.Name, cmd.inputs.Concurrency, samples, elapsed)
+
+	ui.Print(terminal.NewDocumentLog(
+		"Benchmark of function "+function.Name,
+		report.String(),
+		report,
+	))
+	return nil
+}
+
+// benchSample is the outcome of a single function run
+type benchSample struct {
+	RoundTrip time.Duration
+	Execution time.Duration
+	Err       error
+}
+
+// runBenchSamples runs execute the number of requests times, with up to concurrency runs in flight at once,
+// and returns the sample of each run along with the time taken for all runs.
+// A warm-up run is first made on its own, so that an expired session is refreshed before any runs are made
+// at once, which is left out of both the samples and the time taken so that it does not skew the results
+func runBenchSamples(requests, concurrency int, execute func() (realm.ExecutionResults, error)) ([]benchSample, time.Duration) {
+	samples := make([]benchSample, requests)
+
+	run := func(n int) {
+		runStart := time.Now()
+		res, err := execute()
+		samples[n] = benchSample{RoundTrip: time.Since(runStart), Err: err}
+		if err == nil {
+			// the execution time is reported by the server as a duration, such as "12.3ms"
+			if execution, err := time.ParseDuration(res.Stats.ExecutionTime); err == nil {
+				samples[n].Execution = execution
+			}
+		}
+	}
+
+	execute() //nolint:errcheck
+
+	start := time.Now()
+
+	queue := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range queue {
+				run(n)
+			}
+		}()
+	}
+
+	for n := 0; n < requests; n++ {
+		queue <- n
+	}
+	close(queue)
+	wg.Wait()
+
+	return samples, time.Since(start)
+}
+
+// benchReport is the summary of a function benchmark, where all durations are in milliseconds
+type benchReport struct {
+	Function    string              `json:"function"`
+	Requests    int                 `json:"requests"`
+	Concurrency int                 `json:"concurrency"`
+	Errors      int                 `json:"errors"`
+	ErrorCounts map[string]int      `json:"error_counts,omitempty"`
+	Duration    float64             `json:"duration_ms"`
+	Throughput  float64             `json:"requests_per_second"`
+	RoundTrip   benchLatency        `json:"round_trip"`
+	Execution   benchLatency        `json:"execution"`
+	Histogram   []benchHistogramBin `json:"histogram"`
+}
+
+// benchLatency is the distribution of a set of durations
+type benchLatency struct {
+	Min  float64 `json:"min_ms"`
+	Mean float64 `json:"mean_ms"`
+	P50  float64 `json:"p50_ms"`
+	P95  float64 `json:"p95_ms"`
+	P99  float64 `json:"p99_ms"`
+	Max  float64 `json:"max_ms"`
+}
+
+// benchHistogramBin is the number of round trip times which fall within the bin's range
+type benchHistogramBin struct {
+	From  float64 `json:"from_ms"`
+	To    float64 `json:"to_ms"`
+	Count int     `json:"count"`
+}
+
+func newBenchReport(name string, concurrency int, samples []benchSample, elapsed time.Duration) benchReport {
+	report := benchReport{
+		Function:    name,
+		Requests:    len(samples),
+		Concurrency: concurrency,
+		Duration:    milliseconds(elapsed),
+		Histogram:   []benchHistogramBin{},
+	}
+	if elapsed > 0 {
+		report.Throughput = round(float64(len(samples)) / elapsed.Seconds())
+	}
+
+	var roundTrips, executions []time.Duration
+	for _, sample := range samples {
+		if sample.Err != nil {
+			if report.ErrorCounts == nil {
+				report.ErrorCounts = map[string]int{}
+			}
+			report.Errors++
+			report.ErrorCounts[sample.Err.Error()]++
+			continue
+		}
+		roundTrips = append(roundTrips, sample.RoundTrip)
+		if sample.Execution > 0 {
+			executions = append(executions, sample.Execution)
+		}
+	}
+
+	report.RoundTrip = newBenchLatency(roundTrips)
+	report.Execution = newBenchLatency(executions)
+	if len(roundTrips) > 0 {
+		report.Histogram = newBenchHistogram(roundTrips, benchHistogramBuckets)
+	}
+	return report
+}
+
+func newBenchLatency(durations []time.Duration) benchLatency {
+	if len(durations) == 0 {
+		return benchLatency{}
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	return benchLatency{
+		Min:  milliseconds(sorted[0]),
+		Mean: milliseconds(total / time.Duration(len(sorted))),
+		P50:  milliseconds(percentile(sorted, 50)),
+		P95:  milliseconds(percentile(sorted, 95)),
+		P99:  milliseconds(percentile(sorted, 99)),
+		Max:  milliseconds(sorted[len(sorted)-1]),
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// newBenchHistogram splits the range of durations into the number of equally sized bins
+func newBenchHistogram(durations []time.Duration, bins int) []benchHistogramBin {
+	min, max := durations[0], durations[0]
+	for _, d := range durations {
+		if d < min {
+			min = d
+		}
+		if d > max {
+			max = d
+		}
+	}
+
+	if min == max {
+		return []benchHistogramBin{{From: milliseconds(min), To: milliseconds(max), Count: len(durations)}}
+	}
+
+	width := float64(max-min) / float64(bins)
+
+	histogram := make([]benchHistogramBin, bins)
+	for i := range histogram {
+		histogram[i].From = milliseconds(min + time.Duration(width*float64(i)))
+		histogram[i].To = milliseconds(min + time.Duration(width*float64(i+1)))
+	}
+	histogram[bins-1].To = milliseconds(max)
+
+	for _, d := range durations {
+		bin := int(float64(d-min) / width)
+		if bin >= bins {
+			bin = bins - 1
+		}
+		histogram[bin].Count++
+	}
+	return histogram
+}
+
+func (r benchReport) String() string {
+	var sb strings.Builder
+
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Requests:\t%d (concurrency %d)\n", r.Requests, r.Concurrency)
+	fmt.Fprintf(tw, "Errors:\t%d\n", r.Errors)
+	fmt.Fprintf(tw, "Duration:\t%.3fms\n", r.Duration)
+	fmt.Fprintf(tw, "Throughput:\t%.3f requests/s\n", r.Throughput)
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "Latency (ms)\tmin\tmean\tp50\tp95\tp99\tmax")
+	for _, row := range []struct {
+		name    string
+		latency benchLatency
+	}{
+		{"round trip", r.RoundTrip},
+		{"execution", r.Execution},
+	} {
+		l := row.latency
+		fmt.Fprintf(tw, "%s\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\n", row.name, l.Min, l.Mean, l.P50, l.P95, l.P99, l.Max)
+	}
+	tw.Flush() //nolint:errcheck
+
+	if len(r.Histogram) > 0 {
+		var maxCount int
+		for _, bin := range r.Histogram {
+			if bin.Count > maxCount {
+				maxCount = bin.Count
+			}
+		}
+
+		sb.WriteString("\nRound trip histogram (ms)\n")
+		tw = tabwriter.NewWriter(&sb, 0, 0, 1, ' ', tabwriter.AlignRight)
+		for _, bin := range r.Histogram {
+			bar := strings.Repeat("#", bin.Count*benchHistogramWidth/maxCount)
+			fmt.Fprintf(tw, "  %.3f\t-\t%.3f\t | %s %d\n", bin.From, bin.To, bar, bin.Count)
+		}
+		tw.Flush() //nolint:errcheck
+	}
+
+	if len(r.ErrorCounts) > 0 {
+		messages := make([]string, 0, len(r.ErrorCounts))
+		for message := range r.ErrorCounts {
+			messages = append(messages, message)
+		}
+		sort.Strings(messages)
+
+		sb.WriteString("\nErrors\n")
+		for _, message := range messages {
+			fmt.Fprintf(&sb, "  %d x %s\n", r.ErrorCounts[message], message)
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func milliseconds(d time.Duration) float64 {
+	return round(float64(d) / float64(time.Millisecond))
+}
+
+func round(f float64) float64 {
+	return math.Round(f*1000) / 1000
+}
@@ -0,0 +1,190 @@
+package function
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestFunctionBenchInputs(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		inputs      benchInputs
+		expectedErr error
+	}{
+		{
+			description: "should return an error when requests is not positive",
+			inputs:      benchInputs{inputs: inputs{Name: "test"}, Concurrency: 1},
+			expectedErr: errors.New("--requests must be greater than 0"),
+		},
+		{
+			description: "should return an error when concurrency is not positive",
+			inputs:      benchInputs{inputs: inputs{Name: "test"}, Requests: 1},
+			expectedErr: errors.New("--concurrency must be greater than 0"),
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, tc.inputs.Resolve(mock.NewProfile(t), nil))
+		})
+	}
+
+	t.Run("should limit the concurrency to the number of requests", func(t *testing.T) {
+		i := benchInputs{inputs: inputs{Name: "test"}, Requests: 2, Concurrency: 8}
+		assert.Nil(t, i.Resolve(mock.NewProfile(t), nil))
+		assert.Equal(t, 2, i.Concurrency)
+	})
+}
+
+func TestFunctionBenchHandler(t *testing.T) {
+	t.Run("should run the function the number of requests times and report the errors", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID", Name: "test-app"}}, nil
+		}
+		rc.FunctionsFn = func(groupID, appID string) ([]realm.Function, error) {
+			return []realm.Function{{Name: "test"}}, nil
+		}
+
+		var mu sync.Mutex
+		var calls int
+		var capturedArgs [][]interface{}
+		rc.AppDebugExecuteFunctionFn = func(groupID, appID, userID, name string, args []interface{}) (realm.ExecutionResults, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			calls++
+			capturedArgs = append(capturedArgs, args)
+			if calls%4 == 0 {
+				return realm.ExecutionResults{}, errors.New("something bad happened")
+			}
+
+			var res realm.ExecutionResults
+			res.Stats.ExecutionTime = "5ms"
+			return res, nil
+		}
+
+		cmd := CommandBench{benchInputs{
+			inputs: inputs{
+				ProjectInputs: cli.ProjectInputs{App: "test-app"},
+				Name:          "test",
+				Args:          []string{"1"},
+			},
+			Requests:    8,
+			Concurrency: 3,
+		}}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: rc}))
+
+		assert.Equal(t, 9, calls)
+		for _, args := range capturedArgs {
+			assert.Equal(t, []interface{}{1}, args)
+		}
+
+		for _, line := range []string{
+			"Benchmark of function test\n",
+			"Requests:    8 (concurrency 3)\n",
+			"Errors:      2\n",
+			"execution     5.000  5.000  5.000  5.000  5.000  5.000\n",
+			"Round trip histogram (ms)\n",
+			"\nErrors\n  2 x something bad happened",
+		} {
+			assert.True(t, strings.Contains(out.String(), line), "expected output to contain %q, but got:\n%s", line, out.String())
+		}
+	})
+}
+
+func TestFunctionBenchRunSamples(t *testing.T) {
+	t.Run("should make a warm-up run on its own before making runs at once", func(t *testing.T) {
+		var mu sync.Mutex
+		var running, maxRunning, calls int
+		var firstDone, overlapped bool
+
+		samples, _ := runBenchSamples(6, 3, func() (realm.ExecutionResults, error) {
+			mu.Lock()
+			calls++
+			call := calls
+			if call > 1 && !firstDone {
+				overlapped = true
+			}
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			if call == 1 {
+				firstDone = true
+			}
+			mu.Unlock()
+			if call == 1 {
+				return realm.ExecutionResults{}, errors.New("session expired")
+			}
+			return realm.ExecutionResults{}, nil
+		})
+
+		assert.Equal(t, 6, len(samples))
+		assert.Equal(t, 7, calls)
+		assert.False(t, overlapped, "expected the warm-up run to complete before any other run started")
+		for _, sample := range samples {
+			assert.Nil(t, sample.Err)
+		}
+		assert.True(t, maxRunning <= 3, "expected at most 3 runs at once, but %d were", maxRunning)
+	})
+}
+
+func TestFunctionBenchReport(t *testing.T) {
+	samples := make([]benchSample, 0, 101)
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, benchSample{
+			RoundTrip: time.Duration(i) * time.Millisecond,
+			Execution: time.Duration(i) * 500 * time.Microsecond,
+		})
+	}
+	samples = append(samples, benchSample{Err: errors.New("something bad happened")})
+
+	report := newBenchReport("test", 4, samples, 2*time.Second)
+
+	assert.Equal(t, "test", report.Function)
+	assert.Equal(t, 101, report.Requests)
+	assert.Equal(t, 4, report.Concurrency)
+	assert.Equal(t, 1, report.Errors)
+	assert.Equal(t, map[string]int{"something bad happened": 1}, report.ErrorCounts)
+	assert.Equal(t, 2000.0, report.Duration)
+	assert.Equal(t, 50.5, report.Throughput)
+	assert.Equal(t, benchLatency{Min: 1, Mean: 50.5, P50: 50, P95: 95, P99: 99, Max: 100}, report.RoundTrip)
+	assert.Equal(t, benchLatency{Min: 0.5, Mean: 25.25, P50: 25, P95: 47.5, P99: 49.5, Max: 50}, report.Execution)
+
+	assert.Equal(t, 10, len(report.Histogram))
+	assert.Equal(t, benchHistogramBin{From: 1, To: 10.9, Count: 10}, report.Histogram[0])
+	assert.Equal(t, benchHistogramBin{From: 90.1, To: 100, Count: 10}, report.Histogram[9])
+
+	var total int
+	for _, bin := range report.Histogram {
+		total += bin.Count
+	}
+	assert.Equal(t, 100, total)
+
+	t.Run("should report a single histogram bin when every round trip takes the same time", func(t *testing.T) {
+		report := newBenchReport("test", 1, []benchSample{{RoundTrip: time.Millisecond}, {RoundTrip: time.Millisecond}}, time.Second)
+		assert.Equal(t, []benchHistogramBin{{From: 1, To: 1, Count: 2}}, report.Histogram)
+	})
+
+	t.Run("should report no latency when every run fails", func(t *testing.T) {
+		report := newBenchReport("test", 1, []benchSample{{Err: errors.New("something bad happened")}}, time.Second)
+		assert.Equal(t, benchLatency{}, report.RoundTrip)
+		assert.Equal(t, []benchHistogramBin{}, report.Histogram)
+	})
+}
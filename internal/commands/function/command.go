@@ -1,13 +1,16 @@
 package function
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
+	"io/ioutil"
+	"strings"
 	"time"
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
 	"github.com/10gen/realm-cli/internal/terminal"
 
 	"github.com/briandowns/spinner"
@@ -35,7 +38,14 @@ func (cmd *Command) Flags(fs *pflag.FlagSet) {
 
 	fs.StringVar(&cmd.inputs.Name, flagFunctionName, "", flagFunctionNameUsage)
 	fs.StringArrayVar(&cmd.inputs.Args, flagFunctionArgs, nil, flagFunctionArgsUsage)
+	fs.StringVar(&cmd.inputs.ArgsFile, flagArgsFile, "", flagArgsFileUsage)
+	fs.BoolVar(&cmd.inputs.Strict, flagStrict, false, flagStrictUsage)
+	fs.StringVar(&cmd.inputs.EJSON, flagEJSON, "", flagEJSONUsage)
 	fs.StringVar(&cmd.inputs.User, flagAsUser, "", flagAsUserUsage)
+	fs.StringVar(&cmd.inputs.Source, flagSource, "", flagSourceUsage)
+	fs.StringVar(&cmd.inputs.LocalPath, flagLocalPath, "", flagLocalPathUsage)
+	fs.StringVar(&cmd.inputs.Environment, flagEnvironment, "", flagEnvironmentUsage)
+	fs.StringVar(&cmd.inputs.Services, flagServices, "", flagServicesUsage)
 }
 
 // Inputs is the command inputs
@@ -43,83 +53,60 @@ func (cmd *Command) Inputs() cli.InputResolver {
 	return &cmd.inputs
 }
 
-func isJSON(data string) bool {
-	var obj map[string]interface{}
-	if err := json.Unmarshal([]byte(data), &obj); err == nil {
-		return true
-	}
-	var list []interface{}
-	if err := json.Unmarshal([]byte(data), &list); err == nil {
-		return true
-	}
-	return false
-}
-
-func isInt(data string) bool {
-	if _, err := strconv.Atoi(data); err != nil {
-		return false
-	}
-	return true
-}
-
-func isFloat(data string) bool {
-	if _, err := strconv.ParseFloat(data, 64); err != nil {
-		return false
-	}
-	return true
-}
-
 // Handler is the command handler
 func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
-	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if cmd.inputs.LocalPath != "" {
+		return cmd.runLocal(ui)
+	}
+
+	args, err := cmd.inputs.resolveArgs(true)
 	if err != nil {
 		return err
 	}
 
-	function, err := cmd.inputs.ResolveFunction(ui, clients.Realm, app.GroupID, app.ID)
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
 	if err != nil {
 		return err
 	}
 
-	args := make([]interface{}, 0, len(cmd.inputs.Args))
-	if cmd.inputs.Args != nil {
-		for _, arg := range cmd.inputs.Args {
-			if isJSON(arg) {
-				var argNew interface{}
-				if err := json.Unmarshal([]byte(arg), &argNew); err != nil {
-					return err
-				}
-				args = append(args, argNew)
-				continue
-			}
-			if isInt(arg) {
-				num, err := strconv.Atoi(arg)
-				if err != nil {
-					return err
-				}
-				args = append(args, num)
-				continue
-			}
-			if isFloat(arg) {
-				num, err := strconv.ParseFloat(arg, 64)
-				if err != nil {
-					return err
-				}
-				args = append(args, num)
-				continue
-			}
-			args = append(args, arg)
+	var running string
+	var execute func() (realm.ExecutionResults, error)
+
+	if cmd.inputs.Source != "" {
+		source, err := ioutil.ReadFile(cmd.inputs.Source)
+		if err != nil {
+			return fmt.Errorf("failed to read function source: %s", err)
+		}
+
+		evalSource, err := sourceEvalExpression(args, cmd.inputs.Strict)
+		if err != nil {
+			return err
+		}
+
+		running = "function source " + cmd.inputs.Source
+		execute = func() (realm.ExecutionResults, error) {
+			return clients.Realm.AppDebugExecuteFunctionSource(app.GroupID, app.ID, cmd.inputs.User, string(source), evalSource)
+		}
+	} else {
+		function, err := cmd.inputs.ResolveFunction(ui, clients.Realm, app.GroupID, app.ID)
+		if err != nil {
+			return err
+		}
+
+		running = "function " + cmd.inputs.Name
+		execute = func() (realm.ExecutionResults, error) {
+			return clients.Realm.AppDebugExecuteFunction(app.GroupID, app.ID, cmd.inputs.User, function.Name, args)
 		}
 	}
 
 	s := spinner.New(terminal.SpinnerCircles, 250*time.Millisecond)
-	s.Suffix = fmt.Sprintf(" Running function %s with args %s...", cmd.inputs.Name, cmd.inputs.Args)
+	s.Suffix = fmt.Sprintf(" Running %s with args %s...", running, cmd.inputs.argsSource())
 
 	runFunction := func() (realm.ExecutionResults, error) {
 		s.Start()
 		defer s.Stop()
 
-		return clients.Realm.AppDebugExecuteFunction(app.GroupID, app.ID, cmd.inputs.User, function.Name, args)
+		return execute()
 	}
 
 	response, err := runFunction()
@@ -132,7 +119,88 @@ func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Cl
 	if response.ErrorLogs != nil {
 		ui.Print(terminal.NewJSONLog("Error Logs", response.ErrorLogs))
 	}
-	ui.Print(terminal.NewJSONLog("Result", response.Result))
 
+	return cmd.printResult(ui, response.Result)
+}
+
+// sourceEvalExpression returns the expression which calls the exported function of ad-hoc source with the args,
+// where Extended JSON args are parsed with the function runtime's EJSON so their types are kept
+func sourceEvalExpression(args []interface{}, ejson bool) (string, error) {
+	if ejson {
+		data, err := json.Marshal(args)
+		if err != nil {
+			return "", err
+		}
+		literal, err := json.Marshal(string(data))
+		if err != nil {
+			return "", err
+		}
+		return "exports.apply(null, EJSON.parse(" + string(literal) + "))", nil
+	}
+
+	exprs := make([]string, len(args))
+	for i, arg := range args {
+		expr, err := json.Marshal(arg)
+		if err != nil {
+			return "", err
+		}
+		exprs[i] = string(expr)
+	}
+	return "exports(" + strings.Join(exprs, ", ") + ")", nil
+}
+
+// runLocal runs the function of the local app in a Node subprocess, with a stubbed context
+func (cmd *Command) runLocal(ui terminal.UI) error {
+	// the local runner has no Extended JSON support, so args are passed in the relaxed format
+	args, err := cmd.inputs.resolveArgs(false)
+	if err != nil {
+		return err
+	}
+
+	app, err := local.LoadApp(cmd.inputs.LocalPath)
+	if err != nil {
+		return err
+	}
+	if app.AppData == nil {
+		return fmt.Errorf("failed to find a Realm app at %s", cmd.inputs.LocalPath)
+	}
+
+	run, err := local.NewFunctionRun(app, cmd.inputs.Name, args, cmd.inputs.Environment)
+	if err != nil {
+		return err
+	}
+	run.ServicesPath = cmd.inputs.Services
+
+	runner, err := local.NewFunctionRunner()
+	if err != nil {
+		return err
+	}
+
+	res, err := runner.Run(context.Background(), run)
+	if err != nil {
+		return err
+	}
+
+	if len(res.Logs) > 0 {
+		logs := make([]interface{}, len(res.Logs))
+		for i, log := range res.Logs {
+			logs[i] = log
+		}
+		ui.Print(terminal.NewListLog("Logs", logs...))
+	}
+	if res.Error != "" {
+		return fmt.Errorf("function '%s' failed: %s", cmd.inputs.Name, res.Error)
+	}
+
+	return cmd.printResult(ui, res.Result)
+}
+
+func (cmd *Command) printResult(ui terminal.UI, result interface{}) error {
+	result, err := ejsonResult(result, cmd.inputs.EJSON)
+	if err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewJSONLog("Result", result))
 	return nil
 }
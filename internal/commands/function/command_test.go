@@ -2,7 +2,10 @@ package function
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"os/exec"
+	"strings"
 	"testing"
 
 	"github.com/10gen/realm-cli/internal/cli"
@@ -199,3 +202,222 @@ func TestFunctionHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestFunctionHandlerLocal(t *testing.T) {
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("node is required to run functions locally")
+	}
+
+	t.Run("should run the local function with a stubbed context", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		cmd := Command{inputs{LocalPath: "testdata/local", Name: "greet", Args: []string{"eggcorn"}}}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{}))
+		assert.Equal(t, `Logs
+  greeting eggcorn
+Result
+{
+  "apiURL": "http://localhost:8080",
+  "environment": "development",
+  "greeting": "Hello, eggcorn",
+  "sum": 3
+}
+`, out.String())
+	})
+
+	t.Run("should run the local function with the specified environment", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		cmd := Command{inputs{LocalPath: "testdata/local", Name: "greet", Args: []string{"eggcorn"}, Environment: "production"}}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{}))
+		assert.True(t, strings.Contains(out.String(), `"apiURL": "https://api.example.com"`), "expected the production environment values to be used")
+		assert.True(t, strings.Contains(out.String(), `"environment": "production"`), "expected the production environment to be used")
+	})
+
+	t.Run("should run the local function with mocked services", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		cmd := Command{inputs{LocalPath: "testdata/local", Name: "lookup", Args: []string{"eggcorn"}, Services: "testdata/services.js"}}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{}))
+		assert.Equal(t, `Result
+{
+  "age": 42,
+  "name": "eggcorn"
+}
+`, out.String())
+	})
+
+	t.Run("should return an error when the local function uses services without a mock", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		cmd := Command{inputs{LocalPath: "testdata/local", Name: "lookup", Args: []string{"eggcorn"}}}
+
+		assert.Equal(t,
+			errors.New("function 'lookup' failed: cannot use service 'mongodb-atlas' when running locally without a services mock"),
+			cmd.Handler(nil, ui, cli.Clients{}),
+		)
+	})
+
+	t.Run("should return an error when the function does not exist locally", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		cmd := Command{inputs{LocalPath: "testdata/local", Name: "missing"}}
+
+		assert.Equal(t, errors.New("failed to find function 'missing' in the local app"), cmd.Handler(nil, ui, cli.Clients{}))
+	})
+}
+
+func TestFunctionHandlerSource(t *testing.T) {
+	t.Run("should run the function source on the app without deploying it", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID", Name: "test-app"}}, nil
+		}
+
+		var capturedGroupID, capturedAppID, capturedUserID, capturedSource, capturedEvalSource string
+		rc.AppDebugExecuteFunctionSourceFn = func(groupID, appID, userID, source, evalSource string) (realm.ExecutionResults, error) {
+			capturedGroupID = groupID
+			capturedAppID = appID
+			capturedUserID = userID
+			capturedSource = source
+			capturedEvalSource = evalSource
+			return realm.ExecutionResults{
+				Result:    map[string]interface{}{"$numberInt": "3"},
+				Logs:      []string{"counted users"},
+				ErrorLogs: []string{"slow query"},
+			}, nil
+		}
+
+		cmd := Command{inputs{
+			ProjectInputs: cli.ProjectInputs{App: "test-app"},
+			Source:        "testdata/scratch.js",
+			Args:          []string{"eggcorn", "1", `{"active":true}`},
+			User:          "userID",
+		}}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: rc}))
+		assert.Equal(t, `Logs
+  [counted users]
+Error Logs
+[
+  "slow query"
+]
+Result
+{
+  "$numberInt": "3"
+}
+`, out.String())
+
+		t.Log("and should properly pass through the expected inputs")
+		assert.Equal(t, "groupID", capturedGroupID)
+		assert.Equal(t, "appID", capturedAppID)
+		assert.Equal(t, "userID", capturedUserID)
+		assert.Equal(t, `exports = async function(name) {
+  const users = context.services.get("mongodb-atlas").db("app").collection("users");
+  return users.count({ name });
+};
+`, capturedSource)
+		assert.Equal(t, `exports("eggcorn", 1, {"active":true})`, capturedEvalSource)
+	})
+
+	t.Run("should return an error when the function source cannot be read", func(t *testing.T) {
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID", Name: "test-app"}}, nil
+		}
+
+		cmd := Command{inputs{Source: "testdata/missing.js"}}
+
+		err := cmd.Handler(nil, nil, cli.Clients{Realm: rc})
+		assert.Equal(t, "failed to read function source: open testdata/missing.js: no such file or directory", err.Error())
+	})
+
+	t.Run("should return an error when running the function source fails", func(t *testing.T) {
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID", Name: "test-app"}}, nil
+		}
+		rc.AppDebugExecuteFunctionSourceFn = func(groupID, appID, userID, source, evalSource string) (realm.ExecutionResults, error) {
+			return realm.ExecutionResults{}, errors.New("something bad happened")
+		}
+
+		cmd := Command{inputs{Source: "testdata/scratch.js"}}
+
+		assert.Equal(t, errors.New("something bad happened"), cmd.Handler(nil, nil, cli.Clients{Realm: rc}))
+	})
+}
+
+func TestFunctionHandlerStrict(t *testing.T) {
+	t.Run("should pass strict args as canonical extended json and print the result as relaxed extended json", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", Name: "test-app"}}, nil
+		}
+		rc.FunctionsFn = func(groupID, appID string) ([]realm.Function, error) {
+			return []realm.Function{{Name: "test"}}, nil
+		}
+
+		var capturedArgs []interface{}
+		rc.AppDebugExecuteFunctionFn = func(groupID, appID, userID, name string, args []interface{}) (realm.ExecutionResults, error) {
+			capturedArgs = args
+			return realm.ExecutionResults{Result: map[string]interface{}{
+				"count":     map[string]interface{}{"$numberInt": "3"},
+				"createdAt": map[string]interface{}{"$date": map[string]interface{}{"$numberLong": "1609459200000"}},
+			}}, nil
+		}
+
+		cmd := Command{inputs{
+			ProjectInputs: cli.ProjectInputs{App: "test-app"},
+			Name:          "test",
+			Args:          []string{`"42"`, `{"$oid":"5f1b2c3d4e5f6a7b8c9d0e1f"}`},
+			Strict:        true,
+			EJSON:         "relaxed",
+		}}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: rc}))
+		assert.Equal(t, `Result
+{
+  "count": 3,
+  "createdAt": {
+    "$date": "2021-01-01T00:00:00Z"
+  }
+}
+`, out.String())
+
+		data, err := json.Marshal(capturedArgs)
+		assert.Nil(t, err)
+		assert.Equal(t, `["42",{"$oid":"5f1b2c3d4e5f6a7b8c9d0e1f"}]`, string(data))
+	})
+
+	t.Run("should parse strict args of function source with the function runtime's EJSON", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		rc := mock.RealmClient{}
+		rc.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", Name: "test-app"}}, nil
+		}
+
+		var capturedEvalSource string
+		rc.AppDebugExecuteFunctionSourceFn = func(groupID, appID, userID, source, evalSource string) (realm.ExecutionResults, error) {
+			capturedEvalSource = evalSource
+			return realm.ExecutionResults{}, nil
+		}
+
+		cmd := Command{inputs{
+			ProjectInputs: cli.ProjectInputs{App: "test-app"},
+			Source:        "testdata/scratch.js",
+			Args:          []string{"42"},
+			Strict:        true,
+		}}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: rc}))
+		assert.Equal(t, `exports.apply(null, EJSON.parse("[{\"$numberInt\":\"42\"}]"))`, capturedEvalSource)
+	})
+}
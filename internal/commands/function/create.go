@@ -0,0 +1,168 @@
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/pflag"
+)
+
+const (
+	flagNameCreate      = "name"
+	flagNameCreateUsage = "the name of the function, which may be a path such as 'utils/format' for apps of config version 2 or above"
+
+	flagLocalPathCreateUsage = "the local path to your Realm app"
+
+	flagPrivate      = "private"
+	flagPrivateUsage = "include to prevent client applications from calling the function"
+
+	flagRunAsSystem      = "run-as-system"
+	flagRunAsSystemUsage = "include to run the function as the system user, bypassing rules"
+
+	flagCanEvaluate      = "can-evaluate"
+	flagCanEvaluateUsage = "a JSON expression which must evaluate to true for the function to be called"
+
+	flagTemplate      = "template"
+	flagTemplateUsage = `the starter template of the function source, available options: ["default", "endpoint", "trigger", "auth"]`
+
+	templateDefault  = "default"
+	templateEndpoint = "endpoint"
+	templateTrigger  = "trigger"
+	templateAuth     = "auth"
+)
+
+var (
+	errTemplate    = errors.New("--" + flagTemplate + " must be one of '" + strings.Join(templateOptions, "', '") + "'")
+	errCanEvaluate = errors.New("--" + flagCanEvaluate + " must be a JSON object")
+
+	templateOptions = []string{templateDefault, templateEndpoint, templateTrigger, templateAuth}
+
+	functionTemplates = map[string]string{
+		templateDefault: `exports = function(arg) {
+  // Find the name of the MongoDB service you want to use (see "Linked Data Sources" tab)
+  // const collection = context.services.get("mongodb-atlas").db("dbName").collection("collName");
+
+  return arg;
+};
+`,
+		templateEndpoint: `// This function is the handler of an HTTPS endpoint, which is called with the
+// request and must set the response
+exports = async function({ query, headers, body }, response) {
+  const payload = body ? JSON.parse(body.text()) : {};
+
+  response.setStatusCode(200);
+  response.setHeader("Content-Type", "application/json");
+  response.setBody(JSON.stringify({ query, payload }));
+};
+`,
+		templateTrigger: `// This function is the handler of a database trigger, which is called with the
+// change event of each change the trigger observes
+exports = async function(changeEvent) {
+  const { operationType, documentKey, fullDocument } = changeEvent;
+
+  console.log(operationType + " of document " + JSON.stringify(documentKey));
+  return fullDocument;
+};
+`,
+		templateAuth: `// This function is the custom function authentication provider, which is called
+// with the login payload and must return a unique id for the user
+exports = async function(payload) {
+  const { username } = payload;
+  if (!username) {
+    throw new Error("username is required");
+  }
+
+  return username;
+};
+`,
+	}
+)
+
+type createInputs struct {
+	LocalPath   string
+	Name        string
+	Private     bool
+	RunAsSystem bool
+	CanEvaluate string
+	Template    string
+}
+
+func (i *createInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if _, ok := functionTemplates[i.Template]; !ok {
+		return errTemplate
+	}
+	if i.CanEvaluate != "" {
+		var canEvaluate map[string]interface{}
+		if err := json.Unmarshal([]byte(i.CanEvaluate), &canEvaluate); err != nil {
+			return errCanEvaluate
+		}
+	}
+	if i.LocalPath == "" {
+		i.LocalPath = profile.WorkingDirectory
+	}
+	if i.Name == "" {
+		if err := ui.AskOne(&i.Name, &survey.Input{Message: "Function Name"}); err != nil {
+			return err
+		}
+	}
+	return local.ValidateFunctionName(i.Name, true)
+}
+
+// CommandCreate is the `function create` command
+type CommandCreate struct {
+	inputs createInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandCreate) Flags(fs *pflag.FlagSet) {
+	fs.StringVar(&cmd.inputs.Name, flagNameCreate, "", flagNameCreateUsage)
+	fs.StringVar(&cmd.inputs.LocalPath, flagLocalPath, "", flagLocalPathCreateUsage)
+	fs.BoolVar(&cmd.inputs.Private, flagPrivate, false, flagPrivateUsage)
+	fs.BoolVar(&cmd.inputs.RunAsSystem, flagRunAsSystem, false, flagRunAsSystemUsage)
+	fs.StringVar(&cmd.inputs.CanEvaluate, flagCanEvaluate, "", flagCanEvaluateUsage)
+	fs.StringVar(&cmd.inputs.Template, flagTemplate, templateDefault, flagTemplateUsage)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandCreate) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandCreate) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := local.LoadApp(cmd.inputs.LocalPath)
+	if err != nil {
+		return err
+	}
+	if app.AppData == nil {
+		return fmt.Errorf("failed to find a Realm app at %s", cmd.inputs.LocalPath)
+	}
+
+	config := map[string]interface{}{
+		"name":          cmd.inputs.Name,
+		"private":       cmd.inputs.Private,
+		"run_as_system": cmd.inputs.RunAsSystem,
+	}
+	if cmd.inputs.CanEvaluate != "" {
+		var canEvaluate map[string]interface{}
+		if err := json.Unmarshal([]byte(cmd.inputs.CanEvaluate), &canEvaluate); err != nil {
+			return errCanEvaluate
+		}
+		config["can_evaluate"] = canEvaluate
+	}
+
+	path, err := local.WriteFunction(app, config, functionTemplates[cmd.inputs.Template])
+	if err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully created function %s at %s", cmd.inputs.Name, path))
+	return nil
+}
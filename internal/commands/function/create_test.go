@@ -0,0 +1,179 @@
+package function
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/local"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+
+	"github.com/Netflix/go-expect"
+)
+
+func TestFunctionCreateInputs(t *testing.T) {
+	t.Run("should default the local path to the working directory", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := createInputs{Name: "test", Template: templateDefault}
+		assert.Nil(t, i.Resolve(profile, nil))
+		assert.Equal(t, profile.WorkingDirectory, i.LocalPath)
+	})
+
+	for _, tc := range []struct {
+		description string
+		inputs      createInputs
+		expectedErr error
+	}{
+		{
+			description: "should return an error with an unknown template",
+			inputs:      createInputs{Name: "test", Template: "webhook"},
+			expectedErr: errors.New("--template must be one of 'default', 'endpoint', 'trigger', 'auth'"),
+		},
+		{
+			description: "should return an error when can evaluate is not a json object",
+			inputs:      createInputs{Name: "test", Template: templateDefault, CanEvaluate: "true"},
+			expectedErr: errors.New("--can-evaluate must be a JSON object"),
+		},
+		{
+			description: "should return an error when the name is an absolute path",
+			inputs:      createInputs{Name: "/etc/test", Template: templateDefault},
+			expectedErr: errors.New("invalid function name '/etc/test': must be a relative path"),
+		},
+		{
+			description: "should return an error when the name leaves the functions directory",
+			inputs:      createInputs{Name: "../test", Template: templateDefault},
+			expectedErr: errors.New("invalid function name '../test': cannot contain '.' or '..' path elements"),
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, tc.inputs.Resolve(mock.NewProfile(t), nil))
+		})
+	}
+
+	t.Run("should prompt for function name", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		procedure := func(c *expect.Console) {
+			c.ExpectString("Function Name")
+			c.SendLine("test")
+			c.ExpectEOF()
+		}
+
+		_, console, _, ui, err := mock.NewVT10XConsole()
+		assert.Nil(t, err)
+		defer console.Close()
+
+		doneCh := make(chan (struct{}))
+		go func() {
+			defer close(doneCh)
+			procedure(console)
+		}()
+
+		i := createInputs{Template: templateDefault}
+		assert.Nil(t, i.Resolve(profile, ui))
+
+		console.Tty().Close() // flush the writers
+		<-doneCh              // wait for procedure to complete
+
+		assert.Equal(t, "test", i.Name)
+	})
+}
+
+func TestFunctionCreateHandler(t *testing.T) {
+	t.Run("should append the function to the functions config of a v2 app", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("function")
+		assert.Nil(t, err)
+		defer teardown()
+
+		assert.Nil(t, local.WriteFile(filepath.Join(tmpDir, local.FileRealmConfig.String()), 0666, strings.NewReader(`{"config_version":20210101,"name":"eggcorn"}`)))
+		assert.Nil(t, local.WriteFile(filepath.Join(tmpDir, local.NameFunctions, local.FileConfig.String()), 0666, strings.NewReader(`[{"name":"existing","private":false}]`)))
+
+		out, ui := mock.NewUI()
+
+		cmd := CommandCreate{createInputs{
+			LocalPath:   tmpDir,
+			Name:        "webhooks/orders",
+			Private:     true,
+			CanEvaluate: `{"%%request.remoteIPAddress":{"$in":["10.0.0.1"]}}`,
+			Template:    templateEndpoint,
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{}))
+
+		sourcePath := filepath.Join(tmpDir, local.NameFunctions, "webhooks", "orders.js")
+		assert.Equal(t, "Successfully created function webhooks/orders at "+sourcePath+"\n", out.String())
+
+		config, err := ioutil.ReadFile(filepath.Join(tmpDir, local.NameFunctions, local.FileConfig.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, `[
+    {
+        "name": "existing",
+        "private": false
+    },
+    {
+        "can_evaluate": {
+            "%%request.remoteIPAddress": {
+                "$in": [
+                    "10.0.0.1"
+                ]
+            }
+        },
+        "name": "webhooks/orders",
+        "private": true,
+        "run_as_system": false
+    }
+]
+`, string(config))
+
+		source, err := ioutil.ReadFile(sourcePath)
+		assert.Nil(t, err)
+		assert.Equal(t, functionTemplates[templateEndpoint], string(source))
+	})
+
+	t.Run("should write the function to its own directory of a v1 app", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("function")
+		assert.Nil(t, err)
+		defer teardown()
+
+		assert.Nil(t, local.WriteFile(filepath.Join(tmpDir, local.FileConfig.String()), 0666, strings.NewReader(`{"config_version":20200603,"name":"eggcorn"}`)))
+
+		_, ui := mock.NewUI()
+
+		cmd := CommandCreate{createInputs{
+			LocalPath:   tmpDir,
+			Name:        "onOrder",
+			RunAsSystem: true,
+			Template:    templateTrigger,
+		}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{}))
+
+		config, err := ioutil.ReadFile(filepath.Join(tmpDir, local.NameFunctions, "onOrder", local.FileConfig.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, `{
+    "name": "onOrder",
+    "private": false,
+    "run_as_system": true
+}
+`, string(config))
+
+		source, err := ioutil.ReadFile(filepath.Join(tmpDir, local.NameFunctions, "onOrder", local.FileSource.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, functionTemplates[templateTrigger], string(source))
+	})
+
+	t.Run("should return an error when the local app cannot be found", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("function")
+		assert.Nil(t, err)
+		defer teardown()
+
+		_, ui := mock.NewUI()
+
+		cmd := CommandCreate{createInputs{LocalPath: tmpDir, Name: "test", Template: templateDefault}}
+		assert.Equal(t, errors.New("failed to find a Realm app at "+tmpDir), cmd.Handler(nil, ui, cli.Clients{}))
+	})
+}
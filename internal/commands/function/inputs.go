@@ -3,6 +3,7 @@ package function
 import (
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cloud/realm"
@@ -16,20 +17,90 @@ const (
 	flagFunctionNameUsage = "specify the function to run"
 
 	flagFunctionArgs      = "args"
-	flagFunctionArgsUsage = "specify the args to pass to your function"
+	flagFunctionArgsUsage = "specify the args to pass to your function, or '-' to read a JSON array of args from stdin"
+
+	flagArgsFile      = "args-file"
+	flagArgsFileUsage = "the path to a file holding a JSON array of the args to pass to your function"
+
+	flagStrict      = "strict"
+	flagStrictUsage = "include to parse every arg as Extended JSON rather than guess its type"
+
+	flagEJSON      = "ejson"
+	flagEJSONUsage = "print the function result as Extended JSON, either 'canonical' or 'relaxed'"
 
 	flagAsUser      = "user"
 	flagAsUserUsage = "specify the user to run the function as; defaults to system"
+
+	flagSource      = "source"
+	flagSourceUsage = "the path to a Javascript file of function source to run on your deployed app without deploying it"
+
+	flagLocalPath      = "local"
+	flagLocalPathUsage = "the local path to your Realm app, include to run the function locally instead of on your deployed app"
+
+	flagEnvironment      = "environment"
+	flagEnvironmentUsage = "specify the app environment to run the function locally with, defaults to the environment of your local app"
+
+	flagServices      = "services"
+	flagServicesUsage = "the path to a Javascript module mocking the services returned by context.services.get when running locally"
+)
+
+var (
+	errLocalOnly = errors.New("can only use --" + flagEnvironment + " and --" + flagServices + " with --" + flagLocalPath)
+	errLocalUser = errors.New("cannot use --" + flagAsUser + " with --" + flagLocalPath)
+
+	errArgsConflict = errors.New("cannot use --" + flagFunctionArgs + " with --" + flagArgsFile)
+	errArgsStdin    = errors.New("cannot use --" + flagFunctionArgs + " " + argsStdin + " along with other args")
+	errEJSONFormat  = errors.New("--" + flagEJSON + " must be either '" + ejsonCanonical + "' or '" + ejsonRelaxed + "'")
+
+	errSourceLocal    = errors.New("cannot use --" + flagSource + " with --" + flagLocalPath)
+	errSourceFunction = errors.New("cannot use --" + flagFunctionName + " with --" + flagSource)
 )
 
 type inputs struct {
 	cli.ProjectInputs
-	Name string
-	Args []string
-	User string
+	Name        string
+	Args        []string
+	ArgsFile    string
+	Strict      bool
+	EJSON       string
+	User        string
+	Source      string
+	LocalPath   string
+	Environment string
+	Services    string
+
+	in io.Reader
 }
 
 func (i *inputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if i.ArgsFile != "" && len(i.Args) > 0 {
+		return errArgsConflict
+	}
+	if len(i.Args) > 1 {
+		for _, arg := range i.Args {
+			if arg == argsStdin {
+				return errArgsStdin
+			}
+		}
+	}
+	if i.EJSON != "" && i.EJSON != ejsonCanonical && i.EJSON != ejsonRelaxed {
+		return errEJSONFormat
+	}
+	if i.LocalPath == "" && (i.Environment != "" || i.Services != "") {
+		return errLocalOnly
+	}
+	if i.LocalPath != "" && i.User != "" {
+		return errLocalUser
+	}
+	if i.Source != "" {
+		if i.LocalPath != "" {
+			return errSourceLocal
+		}
+		if i.Name != "" {
+			return errSourceFunction
+		}
+		return nil
+	}
 	if i.Name == "" {
 		if err := ui.AskOne(&i.Name, &survey.Input{Message: "Function Name"}); err != nil {
 			return err
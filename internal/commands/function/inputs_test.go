@@ -19,6 +19,58 @@ func TestFunctionInputsResolve(t *testing.T) {
 		assert.Nil(t, i.Resolve(profile, nil))
 	})
 
+	t.Run("should return an error when using local only flags without a local path", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		for _, i := range []inputs{
+			{Name: "test", Environment: "production"},
+			{Name: "test", Services: "services.js"},
+		} {
+			assert.Equal(t, errors.New("can only use --environment and --services with --local"), i.Resolve(profile, nil))
+		}
+	})
+
+	t.Run("should return an error when running a local function as a user", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := inputs{Name: "test", LocalPath: "testdata/local", User: "userID"}
+		assert.Equal(t, errors.New("cannot use --user with --local"), i.Resolve(profile, nil))
+	})
+
+	t.Run("should not prompt for a function name when running function source", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := inputs{Source: "scratch.js"}
+		assert.Nil(t, i.Resolve(profile, nil))
+	})
+
+	t.Run("should return an error when running function source along with a function or local app", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := inputs{Source: "scratch.js", Name: "test"}
+		assert.Equal(t, errors.New("cannot use --function with --source"), i.Resolve(profile, nil))
+
+		i = inputs{Source: "scratch.js", LocalPath: "testdata/local"}
+		assert.Equal(t, errors.New("cannot use --source with --local"), i.Resolve(profile, nil))
+	})
+
+	t.Run("should return an error when the args are provided more than one way", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := inputs{Name: "test", Args: []string{"1"}, ArgsFile: "args.json"}
+		assert.Equal(t, errors.New("cannot use --args with --args-file"), i.Resolve(profile, nil))
+
+		i = inputs{Name: "test", Args: []string{"-", "1"}}
+		assert.Equal(t, errors.New("cannot use --args - along with other args"), i.Resolve(profile, nil))
+	})
+
+	t.Run("should return an error with an unknown extended json format", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := inputs{Name: "test", EJSON: "pretty"}
+		assert.Equal(t, errors.New("--ejson must be either 'canonical' or 'relaxed'"), i.Resolve(profile, nil))
+	})
+
 	t.Run("should prompt for function name", func(t *testing.T) {
 		profile := mock.NewProfile(t)
 
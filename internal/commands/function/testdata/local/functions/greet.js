@@ -0,0 +1,10 @@
+exports = async function(name) {
+  const sum = await context.functions.execute("utils/sum", 1, 2);
+  console.log("greeting", name);
+  return {
+    greeting: context.values.get("greeting") + ", " + name,
+    environment: context.environment.tag,
+    apiURL: context.environment.values.apiURL,
+    sum: sum
+  };
+};
@@ -0,0 +1,4 @@
+exports = async function(name) {
+  const users = context.services.get("mongodb-atlas").db("app").collection("users");
+  return users.findOne({ name });
+};
@@ -0,0 +1,9 @@
+module.exports = {
+  "mongodb-atlas": {
+    db: () => ({
+      collection: () => ({
+        findOne: async (query) => ({ name: query.name, age: 42 }),
+      }),
+    }),
+  },
+};
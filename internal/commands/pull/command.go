@@ -5,7 +5,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cloud/realm"
@@ -13,7 +12,6 @@ import (
 	"github.com/10gen/realm-cli/internal/terminal"
 	"github.com/10gen/realm-cli/internal/utils/flags"
 
-	"github.com/briandowns/spinner"
 	"github.com/spf13/pflag"
 )
 
@@ -28,7 +26,9 @@ func (cmd *Command) Flags(fs *pflag.FlagSet) {
 	fs.StringVar(&cmd.inputs.RemoteApp, flagRemote, "", flagRemoteUsage)
 	fs.BoolVarP(&cmd.inputs.IncludeDependencies, flagIncludeDependencies, flagIncludeDependenciesShort, false, flagIncludeDependenciesUsage)
 	fs.BoolVarP(&cmd.inputs.IncludeHosting, flagIncludeHosting, flagIncludeHostingShort, false, flagIncludeHostingUsage)
+	fs.StringVar(&cmd.inputs.Archive, flagArchive, "", flagArchiveUsage)
 	fs.BoolVarP(&cmd.inputs.DryRun, flagDryRun, flagDryRunShort, false, flagDryRunUsage)
+	fs.BoolVar(&cmd.inputs.All, flagAll, false, flagAllUsage)
 
 	fs.StringVar(&cmd.inputs.Project, flagProject, "", flagProjectUsage)
 	flags.MarkHidden(fs, flagProject)
@@ -44,6 +44,44 @@ func (cmd *Command) Inputs() cli.InputResolver {
 
 // Handler is the command handler
 func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	if cmd.inputs.All {
+		return cmd.pullAll(profile, ui, clients)
+	}
+	return cmd.pull(profile, ui, clients)
+}
+
+// pullAll pulls every app of the workspace the working directory is part of
+func (cmd *Command) pullAll(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	workspace, err := cli.LoadWorkspace(profile.WorkingDirectory)
+	if err != nil {
+		return err
+	}
+
+	// the apps cannot prompt for confirmation, so the changes must be confirmed up front
+	if !cmd.inputs.DryRun && !ui.AutoConfirm() {
+		return cli.ErrWorkspaceConfirm
+	}
+
+	return cli.RunWorkspace(ui, workspace, "Workspace export summary", func(ui terminal.UI, app local.WorkspaceApp) error {
+		appCmd := &Command{cmd.inputs}
+		appCmd.inputs.All = false
+		appCmd.inputs.LocalPath = workspace.AppDir(app)
+		appCmd.inputs.RemoteApp = app.App
+		if app.Project != "" {
+			appCmd.inputs.Project = app.Project
+		}
+
+		if err := appCmd.inputs.Resolve(profile, ui); err != nil {
+			return err
+		}
+		if appCmd.inputs.RemoteApp == "" {
+			return cli.ErrWorkspaceAppNotLinked{Path: app.Path}
+		}
+		return appCmd.pull(profile, ui, clients)
+	})
+}
+
+func (cmd *Command) pull(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
 	appRemote, err := cmd.inputs.resolveRemoteApp(ui, clients.Realm)
 	if err != nil {
 		return err
@@ -54,16 +92,29 @@ func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Cl
 		return err
 	}
 
+	if cmd.inputs.Archive != "" {
+		return cmd.exportArchive(profile, ui, zipPkg)
+	}
+
 	pathRelative, err := filepath.Rel(profile.WorkingDirectory, pathTarget)
 	if err != nil {
 		return err
 	}
 
-	proceed, err := checkAppDestination(ui, pathTarget)
+	hasBaseline, err := local.HasBaseline(pathTarget)
 	if err != nil {
 		return err
-	} else if !proceed {
-		return nil
+	}
+
+	// an app with a baseline snapshot is merged rather than overwritten,
+	// so there are no local changes at risk of being discarded
+	if !hasBaseline {
+		proceed, err := checkAppDestination(ui, pathTarget)
+		if err != nil {
+			return err
+		} else if !proceed {
+			return nil
+		}
 	}
 
 	if cmd.inputs.DryRun {
@@ -74,14 +125,24 @@ func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Cl
 		return nil
 	}
 
-	if err := local.WriteZip(pathTarget, zipPkg); err != nil {
-		return err
+	if hasBaseline {
+		result, err := local.MergeZip(pathTarget, zipPkg)
+		if err != nil {
+			return err
+		}
+		printMergeResult(ui, result)
+	} else {
+		if err := local.WriteZip(pathTarget, zipPkg); err != nil {
+			return err
+		}
+		if err := local.WriteBaseline(pathTarget, zipPkg); err != nil {
+			return err
+		}
+		ui.Print(terminal.NewTextLog("Saved app to disk"))
 	}
-	ui.Print(terminal.NewTextLog("Saved app to disk"))
 
 	if cmd.inputs.IncludeDependencies {
-		s := spinner.New(terminal.SpinnerCircles, 250*time.Millisecond)
-		s.Suffix = " Fetching dependencies archive..."
+		s := terminal.NewSpinner(ui, "Fetching dependencies archive...")
 
 		exportDependencies := func() error {
 			s.Start()
@@ -106,8 +167,7 @@ func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Cl
 	}
 
 	if cmd.inputs.IncludeHosting {
-		s := spinner.New(terminal.SpinnerCircles, 250*time.Millisecond)
-		s.Suffix = " Fetching hosting assets..."
+		s := terminal.NewSpinner(ui, "Fetching hosting assets...")
 
 		exportHostingAssets := func() error {
 			s.Start()
@@ -131,6 +191,39 @@ func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Cl
 	return nil
 }
 
+func (cmd *Command) exportArchive(profile *cli.Profile, ui terminal.UI, zipPkg *zip.Reader) error {
+	pathArchive := cmd.inputs.Archive
+	if !filepath.IsAbs(pathArchive) {
+		pathArchive = filepath.Join(profile.WorkingDirectory, pathArchive)
+	}
+
+	if _, err := os.Stat(pathArchive); err == nil {
+		proceed, err := ui.Confirm("File '%s' already exists, do you still wish to proceed?", cmd.inputs.Archive)
+		if err != nil {
+			return err
+		} else if !proceed {
+			return nil
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if cmd.inputs.DryRun {
+		ui.Print(
+			terminal.NewTextLog("No changes were written to your file system"),
+			terminal.NewDebugLog("Contents would have been written to: %s", cmd.inputs.Archive),
+		)
+		return nil
+	}
+
+	if err := local.WriteArchive(pathArchive, zipPkg); err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully pulled app down: %s", cmd.inputs.Archive))
+	return nil
+}
+
 func (cmd *Command) doExport(profile *cli.Profile, realmClient realm.Client, groupID, appID string) (string, *zip.Reader, error) {
 	name, zipPkg, err := realmClient.Export(
 		groupID,
@@ -180,3 +273,31 @@ func checkAppDestination(ui terminal.UI, path string) (bool, error) {
 
 	return ui.Confirm("Directory '%s' already exists, do you still wish to proceed?", path)
 }
+
+func printMergeResult(ui terminal.UI, result local.MergeResult) {
+	ui.Print(terminal.NewTextLog(
+		"Merged app to disk: %d file(s) updated, %d file(s) deleted",
+		len(result.Updated),
+		len(result.Deleted),
+	))
+
+	if len(result.Skipped) > 0 {
+		ui.Print(terminal.NewListLog("Kept the following files which were only changed locally", toInterfaces(result.Skipped)...))
+	}
+
+	if len(result.Conflicts) > 0 {
+		ui.Print(terminal.NewWarningLog(
+			"%d file(s) were changed both locally and remotely, resolve the conflicts before pushing your app",
+			len(result.Conflicts),
+		))
+		ui.Print(terminal.NewListLog("Conflicting files", toInterfaces(result.Conflicts)...))
+	}
+}
+
+func toInterfaces(values []string) []interface{} {
+	out := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		out = append(out, value)
+	}
+	return out
+}
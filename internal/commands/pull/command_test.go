@@ -1,8 +1,10 @@
 package pull
 
 import (
+	"archive/tar"
 	"archive/zip"
 	"bytes"
+	"compress/gzip"
 	"errors"
 	"fmt"
 	"io"
@@ -104,6 +106,98 @@ Successfully pulled app down: app
 			assert.Nil(t, readErr)
 			assert.Equal(t, "{\"egg\":\"corn\"}\n", string(testData))
 		})
+		t.Run("should merge the received zip package into a destination with a baseline", func(t *testing.T) {
+			profile, teardown := mock.NewProfileFromTmpDir(t, "pull_handler_test")
+			defer teardown()
+
+			cmd := &Command{inputs{LocalPath: "app"}}
+
+			_, ui := mock.NewUI()
+			assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: realmClient}))
+
+			destination := filepath.Join(profile.WorkingDirectory, "app")
+
+			baselineData, readErr := ioutil.ReadFile(filepath.Join(destination, local.NameBaseline, "test.json"))
+			assert.Nil(t, readErr)
+			assert.Equal(t, "{\"egg\":\"corn\"}\n", string(baselineData))
+
+			assert.Nil(t, ioutil.WriteFile(filepath.Join(destination, "test.json"), []byte(`{"egg":"nog"}`), 0666))
+
+			out, ui := mock.NewUI()
+			assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: realmClient}))
+
+			assert.Equal(t, `Merged app to disk: 0 file(s) updated, 0 file(s) deleted
+Kept the following files which were only changed locally
+  test.json
+Successfully pulled app down: app
+`, out.String())
+
+			testData, readErr := ioutil.ReadFile(filepath.Join(destination, "test.json"))
+			assert.Nil(t, readErr)
+			assert.Equal(t, `{"egg":"nog"}`, string(testData))
+		})
+	})
+
+	t.Run("with an archive file set", func(t *testing.T) {
+		zipPkg, zipErr := zip.OpenReader("testdata/test.zip")
+		assert.Nil(t, zipErr)
+		defer zipPkg.Close()
+
+		var realmClient mock.RealmClient
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return nil, nil
+		}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			return "app_20210101", &zipPkg.Reader, nil
+		}
+
+		t.Run("should write the received zip package to the archive file", func(t *testing.T) {
+			profile, teardown := mock.NewProfileFromTmpDir(t, "pull_handler_test")
+			defer teardown()
+
+			out, ui := mock.NewUI()
+
+			cmd := &Command{inputs{Archive: "app.tar.gz"}}
+
+			assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: realmClient}))
+			assert.Equal(t, "Successfully pulled app down: app.tar.gz\n", out.String())
+
+			_, err := os.Stat(filepath.Join(profile.WorkingDirectory, "app"))
+			assert.True(t, os.IsNotExist(err), "expected app directory to not exist")
+
+			file, err := os.Open(filepath.Join(profile.WorkingDirectory, "app.tar.gz"))
+			assert.Nil(t, err)
+			defer file.Close()
+
+			gr, err := gzip.NewReader(file)
+			assert.Nil(t, err)
+
+			tr := tar.NewReader(gr)
+			header, err := tr.Next()
+			assert.Nil(t, err)
+			assert.Equal(t, "test.json", header.Name)
+
+			testData, err := ioutil.ReadAll(tr)
+			assert.Nil(t, err)
+			assert.Equal(t, "{\"egg\":\"corn\"}\n", string(testData))
+		})
+
+		t.Run("should not write the archive file in a dry run", func(t *testing.T) {
+			profile, teardown := mock.NewProfileFromTmpDir(t, "pull_handler_test")
+			defer teardown()
+
+			out, ui := mock.NewUI()
+
+			cmd := &Command{inputs{Archive: "app.zip", DryRun: true}}
+
+			assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: realmClient}))
+			assert.Equal(t, `No changes were written to your file system
+Contents would have been written to: app.zip
+`, out.String())
+
+			_, err := os.Stat(filepath.Join(profile.WorkingDirectory, "app.zip"))
+			assert.True(t, os.IsNotExist(err), "expected archive file to not exist")
+		})
 	})
 
 	t.Run("with a realm client that fails to export dependencies", func(t *testing.T) {
@@ -319,6 +413,122 @@ Successfully pulled app down: app
 	})
 }
 
+func TestPullHandlerHosting(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		zipContents map[string]string
+	}{
+		{
+			description: "v1",
+			zipContents: map[string]string{
+				"config.json": `{"config_version":20200603,"name":"eggcorn","location":"US-VA","deployment_model":"GLOBAL","hosting":{"enabled":true}}`,
+			},
+		},
+		{
+			description: "v2",
+			zipContents: map[string]string{
+				"realm_config.json":   `{"config_version":20210101,"name":"eggcorn","location":"US-VA","deployment_model":"GLOBAL"}`,
+				"hosting/config.json": `{"enabled":true}`,
+			},
+		},
+	} {
+		t.Run(fmt.Sprintf("should keep the hosting config of a pulled %s app when it is loaded and written again", tc.description), func(t *testing.T) {
+			profile, teardown := mock.NewProfileFromTmpDir(t, "pull_handler_test")
+			defer teardown()
+
+			var realmClient mock.RealmClient
+			realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+				return nil, nil
+			}
+			realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+				zipPkg, err := u.NewZipReader(tc.zipContents)
+				return "eggcorn", zipPkg, err
+			}
+
+			_, ui := mock.NewUI()
+
+			cmd := &Command{inputs{LocalPath: "app"}}
+			assert.Nil(t, cmd.Handler(profile, ui, cli.Clients{Realm: realmClient}))
+
+			app, err := local.LoadApp(filepath.Join(profile.WorkingDirectory, "app"))
+			assert.Nil(t, err)
+			assert.Equal(t, map[string]interface{}{"enabled": true}, appHosting(app.AppData))
+
+			app.RootDir = filepath.Join(profile.WorkingDirectory, "rewritten")
+			assert.Nil(t, app.Write())
+
+			rewritten, err := local.LoadApp(app.RootDir)
+			assert.Nil(t, err)
+			assert.Equal(t, map[string]interface{}{"enabled": true}, appHosting(rewritten.AppData))
+		})
+	}
+}
+
+func appHosting(appData local.AppData) map[string]interface{} {
+	switch ad := appData.(type) {
+	case *local.AppRealmConfigJSON:
+		return ad.Hosting
+	case *local.AppConfigJSON:
+		return ad.Hosting
+	}
+	return nil
+}
+
+func TestPullHandlerAll(t *testing.T) {
+	t.Run("should pull every app of the workspace and summarize the results", func(t *testing.T) {
+		profile, teardown := mock.NewProfileFromTmpDir(t, "pull_all_test")
+		defer teardown()
+
+		assert.Nil(t, ioutil.WriteFile(
+			filepath.Join(profile.WorkingDirectory, local.FileWorkspace.String()),
+			[]byte(`{"apps":[{"path":"auth","app":"auth-abcde"},{"path":"sync"}]}`),
+			0666,
+		))
+
+		zipPkg, zipErr := zip.OpenReader("testdata/test.zip")
+		assert.Nil(t, zipErr)
+		defer zipPkg.Close()
+
+		var realmClient mock.RealmClient
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "authID", GroupID: "groupID"}}, nil
+		}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			return "auth_20210101", &zipPkg.Reader, nil
+		}
+
+		out := new(bytes.Buffer)
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+		cmd := &Command{inputs{All: true}}
+		assert.Nil(t, cmd.inputs.Resolve(profile, ui))
+
+		err := cmd.Handler(profile, ui, cli.Clients{Realm: realmClient})
+		assert.Equal(t, cli.ErrWorkspaceFailed{Failed: 1, Total: 2}, err)
+
+		assert.True(t, strings.HasPrefix(out.String(), `Workspace app: auth
+Saved app to disk
+Successfully pulled app down: auth
+Workspace app: sync
+Workspace export summary
+`), "unexpected output: %s", out.String())
+		assert.True(t, strings.Contains(out.String(), cli.ErrWorkspaceAppNotLinked{Path: "sync"}.Error()), "unexpected output: %s", out.String())
+
+		_, err = os.Stat(filepath.Join(profile.WorkingDirectory, "auth", "test.json"))
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return an error if all is set along with an app to pull", func(t *testing.T) {
+		for _, i := range []inputs{
+			{All: true, LocalPath: "app"},
+			{All: true, Archive: "app.zip"},
+			{All: true, RemoteApp: "eggcorn-abcde"},
+		} {
+			assert.Equal(t, errAllConflict, i.Resolve(nil, nil))
+		}
+	})
+}
+
 func TestPullCommandDoExport(t *testing.T) {
 	t.Run("should return an error if the export fails", func(t *testing.T) {
 		groupID, appID := "groupID", "appID"
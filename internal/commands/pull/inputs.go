@@ -26,10 +26,16 @@ const (
 	flagIncludeHostingShort = "s"
 	flagIncludeHostingUsage = "include to export Realm app hosting changes as well"
 
+	flagArchive      = "archive"
+	flagArchiveUsage = "specify an archive file (.zip, .tar or .tar.gz) to export the Realm app into instead of a directory"
+
 	flagDryRun      = "dry-run"
 	flagDryRunShort = "x"
 	flagDryRunUsage = "include to run without writing any changes to the file system"
 
+	flagAll      = "all"
+	flagAllUsage = "include to export every Realm app listed in the realm-workspace.json workspace manifest"
+
 	flagProject      = "project"
 	flagProjectUsage = "the MongoDB cloud project id"
 
@@ -38,20 +44,47 @@ const (
 )
 
 var (
-	errConfigVersionMismatch = errors.New("must export an app with the same config version as found in the current project directory")
+	errConfigVersionMismatch  = errors.New("must export an app with the same config version as found in the current project directory")
+	errArchiveLocalConflict   = errors.New("cannot use both --local and --archive flags")
+	errArchiveIncludeConflict = errors.New("cannot use --include-dependencies or --include-hosting with --archive")
+	errAllConflict            = errors.New("cannot use --all with --local, --archive or --remote flags")
 )
 
 type inputs struct {
 	Project             string
 	RemoteApp           string
 	LocalPath           string
+	Archive             string
 	AppVersion          realm.AppConfigVersion
 	IncludeDependencies bool
 	IncludeHosting      bool
 	DryRun              bool
+	All                 bool
 }
 
 func (i *inputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if i.All {
+		if i.LocalPath != "" || i.Archive != "" || i.RemoteApp != "" {
+			return errAllConflict
+		}
+		return nil
+	}
+
+	if i.Archive != "" {
+		if i.LocalPath != "" {
+			return errArchiveLocalConflict
+		}
+		if i.IncludeDependencies || i.IncludeHosting {
+			return errArchiveIncludeConflict
+		}
+
+		archive, err := homedir.Expand(i.Archive)
+		if err != nil {
+			return err
+		}
+		i.Archive = archive
+	}
+
 	wd := i.LocalPath
 	if wd == "" {
 		wd = profile.WorkingDirectory
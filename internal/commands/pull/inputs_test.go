@@ -48,6 +48,20 @@ func TestPullInputsResolve(t *testing.T) {
 		})
 	})
 
+	t.Run("should return an error if the archive flag is set along with the local flag", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := inputs{Archive: "app.zip", LocalPath: "app"}
+		assert.Equal(t, errArchiveLocalConflict, i.Resolve(profile, nil))
+	})
+
+	t.Run("should return an error if the archive flag is set along with an include flag", func(t *testing.T) {
+		profile := mock.NewProfile(t)
+
+		i := inputs{Archive: "app.zip", IncludeHosting: true}
+		assert.Equal(t, errArchiveIncludeConflict, i.Resolve(profile, nil))
+	})
+
 	t.Run("resolving the to flag should work", func(t *testing.T) {
 		homeDir, teardown := u.SetupHomeDir("")
 		defer teardown()
@@ -1,9 +1,12 @@
 package push
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
+	"os/signal"
+	"sort"
 	"strings"
-	"time"
 
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cloud/realm"
@@ -12,7 +15,6 @@ import (
 	"github.com/10gen/realm-cli/internal/utils/flags"
 
 	"github.com/AlecAivazis/survey/v2"
-	"github.com/briandowns/spinner"
 	"github.com/spf13/pflag"
 )
 
@@ -39,6 +41,15 @@ func (cmd *Command) Flags(fs *pflag.FlagSet) {
 	fs.BoolVarP(&cmd.inputs.IncludeHosting, flagIncludeHosting, flagIncludeHostingShort, false, flagIncludeHostingUsage)
 	fs.BoolVarP(&cmd.inputs.ResetCDNCache, flagResetCDNCache, flagResetCDNCacheShort, false, flagResetCDNCacheUsage)
 	fs.BoolVarP(&cmd.inputs.DryRun, flagDryRun, flagDryRunShort, false, flagDryRunUsage)
+	fs.BoolVar(&cmd.inputs.RollbackOnFailure, flagRollbackOnFailure, false, flagRollbackOnFailureUsage)
+	fs.StringVar(&cmd.inputs.Archive, flagArchive, "", flagArchiveUsage)
+	fs.BoolVarP(&cmd.inputs.Watch, flagWatch, flagWatchShort, false, flagWatchUsage)
+	fs.StringVar(&cmd.inputs.Environment, flagEnvironment, "", flagEnvironmentUsage)
+	fs.Var(flags.NewEnumSet(&cmd.inputs.Include, validAppComponents()), flagInclude, flagIncludeUsage)
+	fs.Var(flags.NewEnumSet(&cmd.inputs.Exclude, validAppComponents()), flagExclude, flagExcludeUsage)
+	fs.BoolVar(&cmd.inputs.All, flagAll, false, flagAllUsage)
+	fs.StringVar(&cmd.inputs.PlanOut, flagPlanOut, "", flagPlanOutUsage)
+	fs.StringVar(&cmd.inputs.Plan, flagPlan, "", flagPlanUsage)
 
 	fs.StringVar(&cmd.inputs.Project, flagProject, "", flagProjectUsage)
 	flags.MarkHidden(fs, flagProject)
@@ -51,11 +62,67 @@ func (cmd *Command) Inputs() cli.InputResolver {
 
 // Handler is the command handler
 func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
-	app, err := local.LoadApp(cmd.inputs.LocalPath)
+	if cmd.inputs.All {
+		return cmd.pushAll(profile, ui, clients)
+	}
+
+	if !cmd.inputs.Watch {
+		return cmd.push(profile, ui, clients)
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	defer signal.Stop(stop)
+
+	return cmd.watch(profile, ui, clients, watcher{watchInterval, watchDebounce, stop})
+}
+
+// pushAll pushes every app of the workspace the working directory is part of
+func (cmd *Command) pushAll(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	workspace, err := cli.LoadWorkspace(profile.WorkingDirectory)
 	if err != nil {
 		return err
 	}
 
+	// the apps cannot prompt for confirmation, so the changes must be confirmed up front
+	if !cmd.inputs.DryRun && !ui.AutoConfirm() {
+		return cli.ErrWorkspaceConfirm
+	}
+
+	return cli.RunWorkspace(ui, workspace, "Workspace import summary", func(ui terminal.UI, app local.WorkspaceApp) error {
+		appCmd := &Command{cmd.inputs}
+		appCmd.inputs.All = false
+		appCmd.inputs.LocalPath = workspace.AppDir(app)
+		appCmd.inputs.RemoteApp = app.App
+		if app.Project != "" {
+			appCmd.inputs.Project = app.Project
+		}
+
+		if err := appCmd.inputs.Resolve(profile, ui); err != nil {
+			return err
+		}
+		return appCmd.push(profile, ui, clients)
+	})
+}
+
+func (cmd *Command) push(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, teardown, err := cmd.loadApp()
+	if err != nil {
+		return err
+	}
+	defer teardown()
+
+	var environmentValues map[string]interface{}
+	if cmd.inputs.Environment != "" {
+		environmentValues, err = local.ResolveEnvironment(app.AppData, cmd.inputs.Environment)
+		if err != nil {
+			return err
+		}
+		if err := local.SetEnvironment(app.AppData, cmd.inputs.Environment); err != nil {
+			return err
+		}
+	}
+
 	appRemote, err := cmd.inputs.resolveRemoteApp(ui, clients.Realm)
 	if err != nil {
 		return err
@@ -71,6 +138,10 @@ func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Cl
 
 	var isNewApp bool
 	if appRemote.AppID == "" {
+		if cmd.inputs.PlanOut != "" || cmd.inputs.plan != nil {
+			return errPlanNewApp
+		}
+
 		if cmd.inputs.DryRun {
 			ui.Print(
 				terminal.NewTextLog("This is a new app. To create a new app, you must omit the 'dry-run' flag to proceed"),
@@ -79,7 +150,11 @@ func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Cl
 			return nil
 		}
 
-		app, proceed, err := createNewApp(ui, clients.Realm, cmd.inputs.LocalPath, appRemote.GroupID, app.AppData)
+		if err := cmd.checkSecrets(ui, clients.Realm, appRemote, app.AppData); err != nil {
+			return err
+		}
+
+		app, proceed, err := createNewApp(ui, clients.Realm, app.RootDir, appRemote.GroupID, app.AppData)
 		if err != nil {
 			return err
 		}
@@ -89,10 +164,34 @@ func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Cl
 
 		appRemote.AppID = app.ID
 		isNewApp = true
+
+		// ensure any subsequent pushes while watching target the newly created app
+		cmd.inputs.Project = app.GroupID
+		cmd.inputs.RemoteApp = app.ClientAppID
+	}
+
+	appData := app.AppData
+	if components := cmd.inputs.components(app.ConfigVersion()); components != nil && !isNewApp {
+		ui.Print(terminal.NewTextLog("Scoping changes to: %s", strings.Join(components, ", ")))
+		appData, err = scopeAppData(clients.Realm, appRemote, app.AppData, components)
+		if err != nil {
+			return err
+		}
+		if cmd.inputs.Environment != "" {
+			if err := local.SetEnvironment(appData, cmd.inputs.Environment); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !isNewApp {
+		if err := cmd.checkSecrets(ui, clients.Realm, appRemote, appData); err != nil {
+			return err
+		}
 	}
 
 	ui.Print(terminal.NewTextLog("Determining changes"))
-	appDiffs, err := clients.Realm.Diff(appRemote.GroupID, appRemote.AppID, app.AppData)
+	appDiffs, err := clients.Realm.Diff(appRemote.GroupID, appRemote.AppID, appData)
 	if err != nil {
 		return err
 	}
@@ -129,12 +228,28 @@ func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Cl
 		}
 	}
 
+	if cmd.inputs.PlanOut != "" || cmd.inputs.plan != nil {
+		current, err := cmd.newPlan(clients.Realm, appRemote, app, appData, appDiffs, dependenciesDiffs, hostingDiffs)
+		if err != nil {
+			return err
+		}
+
+		if cmd.inputs.PlanOut != "" {
+			return cmd.savePlan(ui, current)
+		}
+
+		if err := cmd.inputs.plan.verify(current); err != nil {
+			return err
+		}
+		ui.Print(terminal.NewTextLog("Verified plan: %s", cmd.inputs.Plan))
+	}
+
 	if len(appDiffs) == 0 && dependenciesDiffs.Len() == 0 && hostingDiffs.Size() == 0 {
 		ui.Print(terminal.NewTextLog("Deployed app is identical to proposed version, nothing to do"))
 		return nil
 	}
 
-	if !ui.AutoConfirm() && !isNewApp {
+	if (!ui.AutoConfirm() || cmd.inputs.Watch) && !isNewApp {
 		diffs := make([]string, 0, len(appDiffs)+1+hostingDiffs.Cap())
 
 		diffs = append(diffs, appDiffs...)
@@ -145,12 +260,33 @@ func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Cl
 
 		diffs = append(diffs, hostingDiffs.Strings()...)
 
+		var structuredDiffs []realm.Diff
+		if cmd.inputs.IncludeDependencies {
+			structuredDiffs = append(structuredDiffs, dependenciesDiffs.Diffs()...)
+		}
+		structuredDiffs = append(structuredDiffs, hostingDiffs.Diffs()...)
+
 		// when updating an existing app, if the user has not set the '-y' flag
-		// print the app diffs back to the user
-		ui.Print(terminal.NewTextLog(
-			"The following reflects the proposed changes to your Realm app\n%s",
+		// or is watching for changes, print the app diffs back to the user,
+		// where the structured diffs of the app data are only computed for JSON output
+		ui.Print(terminal.NewLazyDocumentLog(
+			"The following reflects the proposed changes to your Realm app",
 			strings.Join(diffs, "\n"),
+			func() (interface{}, error) {
+				appDataDiffs, err := local.DiffDeployedAppData(clients.Realm, appRemote.GroupID, appRemote.AppID, appData, appDiffs)
+				if err != nil {
+					return nil, err
+				}
+				return append(appDataDiffs, structuredDiffs...), nil
+			},
 		))
+
+		if cmd.inputs.Environment != "" {
+			ui.Print(terminal.NewListLog(
+				fmt.Sprintf("Using environment '%s' with the following values", cmd.inputs.Environment),
+				environmentValueItems(environmentValues)...,
+			))
+		}
 	}
 
 	if cmd.inputs.DryRun {
@@ -161,17 +297,32 @@ func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Cl
 		return nil
 	}
 
-	proceed, err := ui.Confirm("Please confirm the changes shown above")
-	if err != nil {
-		return err
+	// a plan has already been approved and a watch is confirmed once up front,
+	// so either is pushed without confirmation
+	if !cmd.inputs.Watch && cmd.inputs.plan == nil {
+		proceed, err := ui.Confirm("Please confirm the changes shown above")
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			return nil
+		}
 	}
-	if !proceed {
-		return nil
+
+	var lastDeployment realm.AppDeployment
+	if cmd.inputs.RollbackOnFailure && !isNewApp {
+		lastDeployment, err = findLastSuccessfulDeployment(clients.Realm, appRemote)
+		if err != nil {
+			return err
+		}
+		if lastDeployment.ID == "" {
+			ui.Print(terminal.NewWarningLog("No successful deployment was found to roll back to should the push fail"))
+		}
 	}
 
 	if len(appDiffs) > 0 {
 		ui.Print(terminal.NewTextLog("Creating draft"))
-		draft, proceed, err := createNewDraft(ui, clients.Realm, appRemote)
+		draft, proceed, err := cli.CreateNewDraft(ui, clients.Realm, appRemote.GroupID, appRemote.AppID)
 		if err != nil {
 			return err
 		}
@@ -180,26 +331,25 @@ func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Cl
 		}
 
 		ui.Print(terminal.NewTextLog("Pushing changes"))
-		if err := clients.Realm.Import(appRemote.GroupID, appRemote.AppID, app.AppData); err != nil {
+		if err := clients.Realm.Import(appRemote.GroupID, appRemote.AppID, appData); err != nil {
 			return err
 		}
 
 		ui.Print(terminal.NewTextLog("Deploying draft"))
-		if err := deployDraftAndWait(ui, clients.Realm, appRemote, draft.ID); err != nil {
-			return err
+		if err := cli.DeployDraftAndWait(ui, clients.Realm, appRemote.GroupID, appRemote.AppID, draft.ID); err != nil {
+			return rollbackDeployment(ui, clients.Realm, appRemote, lastDeployment, err)
 		}
 	}
 
 	if cmd.inputs.IncludeDependencies {
 		if err := clients.Realm.ImportDependencies(appRemote.GroupID, appRemote.AppID, uploadPathDependencies); err != nil {
-			return err
+			return rollbackDeployment(ui, clients.Realm, appRemote, lastDeployment, err)
 		}
 		ui.Print(terminal.NewTextLog("Uploaded dependencies archive"))
 	}
 
 	if cmd.inputs.IncludeHosting {
-		s := spinner.New(terminal.SpinnerCircles, 250*time.Millisecond)
-		s.Suffix = " Importing hosting assets..."
+		s := terminal.NewSpinner(ui, "Importing hosting assets...")
 
 		importHosting := func() error {
 			s.Start()
@@ -217,13 +367,12 @@ func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Cl
 		}
 
 		if err := importHosting(); err != nil {
-			return err
+			return rollbackDeployment(ui, clients.Realm, appRemote, lastDeployment, err)
 		}
 		ui.Print(terminal.NewTextLog("Import hosting assets"))
 
 		if cmd.inputs.ResetCDNCache {
-			s := spinner.New(terminal.SpinnerCircles, 250*time.Millisecond)
-			s.Suffix = " Resetting CDN cache..."
+			s := terminal.NewSpinner(ui, "Resetting CDN cache...")
 
 			invalidateCache := func() error {
 				s.Start()
@@ -239,10 +388,177 @@ func (cmd *Command) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Cl
 		}
 	}
 
+	if err := refreshBaseline(clients.Realm, appRemote, app); err != nil {
+		ui.Print(terminal.NewWarningLog("Failed to record the pushed app as the baseline for future pulls: %s", err))
+	}
+
 	ui.Print(terminal.NewTextLog("Successfully pushed app up: %s", app.ID()))
 	return nil
 }
 
+// newPlan builds the plan of the computed changes, along with checksums
+// of the local and remote app to detect any changes made after it
+func (cmd *Command) newPlan(
+	realmClient realm.Client,
+	remote appRemote,
+	app local.App,
+	appData local.AppData,
+	appDiffs []string,
+	dependenciesDiffs realm.DependenciesDiff,
+	hostingDiffs local.HostingDiffs,
+) (pushPlan, error) {
+	localHash, err := local.BundleHash(app, cmd.inputs.IncludeDependencies, cmd.inputs.IncludeHosting)
+	if err != nil {
+		return pushPlan{}, err
+	}
+
+	deployedData, err := local.ExportAppData(realmClient, remote.GroupID, remote.AppID, appData.ConfigVersion())
+	if err != nil {
+		return pushPlan{}, err
+	}
+
+	remoteHash, err := local.AppDataHash(deployedData)
+	if err != nil {
+		return pushPlan{}, err
+	}
+
+	return pushPlan{
+		Version:             planVersion,
+		GroupID:             remote.GroupID,
+		AppID:               remote.AppID,
+		RemoteApp:           cmd.inputs.RemoteApp,
+		Environment:         cmd.inputs.Environment,
+		Include:             cmd.inputs.Include,
+		Exclude:             cmd.inputs.Exclude,
+		IncludeDependencies: cmd.inputs.IncludeDependencies,
+		IncludeHosting:      cmd.inputs.IncludeHosting,
+		LocalHash:           localHash,
+		RemoteHash:          remoteHash,
+		AppDiffs:            appDiffs,
+		DependenciesDiff:    dependenciesDiffs,
+		HostingDiffs:        hostingDiffs,
+	}, nil
+}
+
+// savePlan writes the plan to the plan-out file and shows the changes it contains
+func (cmd *Command) savePlan(ui terminal.UI, p pushPlan) error {
+	if err := writePlan(cmd.inputs.PlanOut, p); err != nil {
+		return err
+	}
+
+	if p.HasChanges() {
+		ui.Print(terminal.NewDocumentLog(
+			"The following reflects the planned changes to your Realm app",
+			strings.Join(p.Diffs(), "\n"),
+			p,
+		))
+	} else {
+		ui.Print(terminal.NewTextLog("Deployed app is identical to proposed version, the plan has nothing to do"))
+	}
+
+	args := make([]flags.Arg, 0, 3)
+	if cmd.inputs.LocalPath != "" {
+		args = append(args, flags.Arg{Name: flagLocalPath, Value: cmd.inputs.LocalPath})
+	}
+	if cmd.inputs.Archive != "" {
+		args = append(args, flags.Arg{Name: flagArchive, Value: cmd.inputs.Archive})
+	}
+	args = append(args, flags.Arg{Name: flagPlan, Value: cmd.inputs.PlanOut})
+
+	ui.Print(
+		terminal.NewTextLog("Saved plan to %s", cmd.inputs.PlanOut),
+		terminal.NewFollowupLog("To push exactly these changes run", cli.CommandDisplay(CommandUse, args)),
+	)
+	return nil
+}
+
+// checkSecrets ensures every secret referenced by the app data exists in the remote app,
+// so that a push does not only fail once its draft is deployed
+func (cmd *Command) checkSecrets(ui terminal.UI, realmClient realm.Client, remote appRemote, appData local.AppData) error {
+	references, err := local.SecretReferences(appData)
+	if err != nil {
+		return err
+	}
+	if len(references) == 0 {
+		return nil
+	}
+
+	// a new app holds no secrets, which can only be created once the app exists
+	if remote.AppID == "" {
+		ui.Print(terminal.NewWarningLog(
+			"The new app references secrets which must be created once it exists: %s",
+			strings.Join(references, ", "),
+		))
+		return nil
+	}
+
+	secrets, err := realmClient.Secrets(remote.GroupID, remote.AppID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]struct{}, len(secrets))
+	for _, secret := range secrets {
+		existing[secret.Name] = struct{}{}
+	}
+
+	var missing []string
+	for _, reference := range references {
+		if _, ok := existing[reference]; !ok {
+			missing = append(missing, reference)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	suggestions := make([]interface{}, len(missing))
+	for i, name := range missing {
+		args := make([]flags.Arg, 0, 2)
+		if cmd.inputs.RemoteApp != "" {
+			args = append(args, flags.Arg{Name: flagApp, Value: cmd.inputs.RemoteApp})
+		}
+		args = append(args, flags.Arg{Name: flagName, Value: name})
+		suggestions[i] = cli.CommandDisplay(commandSecretsCreate, args)
+	}
+	ui.Print(terminal.NewFollowupLog(terminal.MsgSuggestedCommands, suggestions...))
+
+	return errMissingSecrets{missing}
+}
+
+// loadApp loads the local app to push from either the local directory or the archive file,
+// returning a teardown func which removes any temporary files created in doing so
+func (cmd *Command) loadApp() (local.App, func(), error) {
+	if cmd.inputs.Archive != "" {
+		return local.LoadAppArchive(cmd.inputs.Archive)
+	}
+
+	app, err := local.LoadApp(cmd.inputs.LocalPath)
+	if err != nil {
+		return local.App{}, nil, err
+	}
+	return app, func() {}, nil
+}
+
+// environmentValueItems returns the resolved environment values as list items sorted by name
+func environmentValueItems(values map[string]interface{}) []interface{} {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	items := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		value, err := json.Marshal(values[name])
+		if err != nil {
+			value = []byte(fmt.Sprintf("%v", values[name]))
+		}
+		items = append(items, fmt.Sprintf("%s: %s", name, value))
+	}
+	return items
+}
+
 func (cmd *Command) display(omitDryRun bool) string {
 	return cli.CommandDisplay(CommandUse, cmd.inputs.args(omitDryRun))
 }
@@ -319,103 +635,73 @@ func createNewApp(ui terminal.UI, realmClient realm.Client, appDirectory, groupI
 	return app, true, nil
 }
 
-func createNewDraft(ui terminal.UI, realmClient realm.Client, remote appRemote) (realm.AppDraft, bool, error) {
-	draft, draftErr := realmClient.CreateDraft(remote.GroupID, remote.AppID)
-	if draftErr == nil {
-		return draft, true, nil
-	}
-
-	if err, ok := draftErr.(realm.ServerError); !ok || err.Code != realm.ErrCodeDraftAlreadyExists {
-		return realm.AppDraft{}, false, draftErr
-	}
-
-	existingDraft, existingDraftErr := realmClient.Draft(remote.GroupID, remote.AppID)
-	if existingDraftErr != nil {
-		return realm.AppDraft{}, false, existingDraftErr
+// findLastSuccessfulDeployment finds the most recent successful deployment of the app,
+// returning a zero-value deployment if the app has never been successfully deployed
+func findLastSuccessfulDeployment(realmClient realm.Client, remote appRemote) (realm.AppDeployment, error) {
+	deployments, err := realmClient.Deployments(remote.GroupID, remote.AppID)
+	if err != nil {
+		return realm.AppDeployment{}, err
 	}
 
-	if !ui.AutoConfirm() {
-		if err := diffDraft(ui, realmClient, remote, existingDraft.ID); err != nil {
-			return realm.AppDraft{}, false, err
+	var last realm.AppDeployment
+	for _, deployment := range deployments {
+		if deployment.Status != realm.DeploymentStatusSuccessful {
+			continue
 		}
-
-		proceed, proceedErr := ui.Confirm("Would you like to discard this draft?")
-		if proceedErr != nil {
-			return realm.AppDraft{}, false, proceedErr
+		if last.ID == "" || deployment.DeployedAt > last.DeployedAt {
+			last = deployment
 		}
-		if !proceed {
-			return realm.AppDraft{}, false, nil
-		}
-	}
-
-	if err := realmClient.DiscardDraft(remote.GroupID, remote.AppID, existingDraft.ID); err != nil {
-		return realm.AppDraft{}, false, err
 	}
-
-	draft, draftErr = realmClient.CreateDraft(remote.GroupID, remote.AppID)
-	return draft, true, draftErr
+	return last, nil
 }
 
-func diffDraft(ui terminal.UI, realmClient realm.Client, remote appRemote, draftID string) error {
-	diff, diffErr := realmClient.DiffDraft(remote.GroupID, remote.AppID, draftID)
-	if diffErr != nil {
-		return diffErr
+// rollbackDeployment redeploys the provided deployment after a push fails,
+// always returning the error that caused the push to fail
+func rollbackDeployment(ui terminal.UI, realmClient realm.Client, remote appRemote, deployment realm.AppDeployment, pushErr error) error {
+	if deployment.ID == "" {
+		return pushErr
 	}
 
-	var logs []terminal.Log
-	if !diff.HasChanges() {
-		logs = append(logs, terminal.NewTextLog("An empty draft already exists for your app"))
-	} else {
-		logs = append(logs, terminal.NewListLog("The following draft already exists for your app...", diff.DiffList()...))
-		if diff.HostingFilesDiff.HasChanges() {
-			logs = append(logs, terminal.NewListLog("With changes to your static hosting files...", diff.HostingFilesDiff.DiffList()...))
-		}
-		if diff.DependenciesDiff.HasChanges() {
-			logs = append(logs, terminal.NewListLog("With changes to your app dependencies...", diff.DependenciesDiff.DiffList()...))
-		}
-		if diff.GraphQLConfigDiff.HasChanges() {
-			logs = append(logs, terminal.NewListLog("With changes to your GraphQL configuration...", diff.GraphQLConfigDiff.DiffList()...))
-		}
-		if diff.SchemaOptionsDiff.HasChanges() {
-			logs = append(logs, terminal.NewListLog("With changes to your app schema...", diff.SchemaOptionsDiff.DiffList()...))
-		}
+	ui.Print(terminal.NewTextLog("Rolling back app to deployment: %s", deployment.ID))
+	if err := realmClient.RedeployDeployment(remote.GroupID, remote.AppID, deployment.ID); err != nil {
+		ui.Print(terminal.NewWarningLog("Failed to roll back app to deployment '%s': %s", deployment.ID, err))
+		return pushErr
 	}
-	ui.Print(logs...)
-	return nil
+
+	ui.Print(terminal.NewTextLog("Successfully rolled back app to deployment: %s", deployment.ID))
+	return pushErr
 }
 
-func deployDraftAndWait(ui terminal.UI, realmClient realm.Client, remote appRemote, draftID string) error {
-	deployment, err := realmClient.DeployDraft(remote.GroupID, remote.AppID, draftID)
+// scopeAppData builds the app data to push by taking the specified components from
+// the local app and all others from the currently deployed app, so that only the
+// specified components are changed by the push
+func scopeAppData(realmClient realm.Client, remote appRemote, appData local.AppData, components []string) (local.AppData, error) {
+	remoteData, err := local.ExportAppData(realmClient, remote.GroupID, remote.AppID, appData.ConfigVersion())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	s := spinner.New(terminal.SpinnerCircles, 250*time.Millisecond)
-	s.Suffix = " Deploying app changes..."
-
-	waitForDeployment := func() error {
-		s.Start()
-		defer s.Stop()
-
-		for deployment.Status == realm.DeploymentStatusCreated || deployment.Status == realm.DeploymentStatusPending {
-			time.Sleep(time.Second)
-
-			deployment, err = realmClient.Deployment(remote.GroupID, remote.AppID, deployment.ID)
-			if err != nil {
-				if e := realmClient.DiscardDraft(remote.GroupID, remote.AppID, draftID); e != nil {
-					ui.Print(terminal.NewWarningLog("Failed to discard the draft created for your deployment"))
-				}
-				return err
-			}
-		}
-
-		return nil
+	if err := local.MergeAppComponents(remoteData, appData, components); err != nil {
+		return nil, err
 	}
+	return remoteData, nil
+}
 
-	if err := waitForDeployment(); err != nil {
+// refreshBaseline records the deployed app as the baseline snapshot of the local app,
+// so that a later pull does not mistake the pushed changes for local edits
+func refreshBaseline(realmClient realm.Client, remote appRemote, app local.App) error {
+	hasBaseline, err := local.HasBaseline(app.RootDir)
+	if err != nil || !hasBaseline {
 		return err
 	}
 
-	ui.Print(terminal.NewTextLog("Deployment complete"))
-	return nil
+	_, zipPkg, err := realmClient.Export(
+		remote.GroupID,
+		remote.AppID,
+		realm.ExportRequest{ConfigVersion: app.ConfigVersion()},
+	)
+	if err != nil {
+		return err
+	}
+	return local.WriteBaseline(app.RootDir, zipPkg)
 }
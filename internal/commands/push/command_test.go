@@ -1,8 +1,11 @@
 package push
 
 import (
+	"archive/zip"
 	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -244,6 +247,448 @@ func TestPushHandler(t *testing.T) {
 		assert.Equal(t, "draftID", capturedDraftID)
 	})
 
+	t.Run("with rollback on failure set", func(t *testing.T) {
+		var realmClient mock.RealmClient
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID"}}, nil
+		}
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			return []string{"diff1"}, nil
+		}
+		realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return realm.AppDraft{ID: "draftID"}, nil
+		}
+		realmClient.ImportFn = func(groupID, appID string, appData interface{}) error {
+			return nil
+		}
+		realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+			return realm.AppDeployment{}, errors.New("something bad happened")
+		}
+
+		t.Run("should return an error if the command fails to get the deployments", func(t *testing.T) {
+			ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, new(bytes.Buffer))
+
+			realmClient.DeploymentsFn = func(groupID, appID string) ([]realm.AppDeployment, error) {
+				return nil, errors.New("failed to get deployments")
+			}
+
+			cmd := &Command{inputs{LocalPath: "testdata/project", RemoteApp: "appID", RollbackOnFailure: true}}
+
+			err := cmd.Handler(nil, ui, cli.Clients{Realm: realmClient})
+			assert.Equal(t, errors.New("failed to get deployments"), err)
+		})
+
+		t.Run("should restore the last successful deployment when the deployment fails", func(t *testing.T) {
+			out := new(bytes.Buffer)
+			ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+			realmClient.DeploymentsFn = func(groupID, appID string) ([]realm.AppDeployment, error) {
+				return []realm.AppDeployment{
+					{ID: "deployment1", Status: realm.DeploymentStatusSuccessful, DeployedAt: 1},
+					{ID: "deployment3", Status: realm.DeploymentStatusFailed, DeployedAt: 3},
+					{ID: "deployment2", Status: realm.DeploymentStatusSuccessful, DeployedAt: 2},
+				}, nil
+			}
+
+			var capturedGroupID, capturedAppID, capturedDeploymentID string
+			realmClient.RedeployDeploymentFn = func(groupID, appID, deploymentID string) error {
+				capturedGroupID = groupID
+				capturedAppID = appID
+				capturedDeploymentID = deploymentID
+				return nil
+			}
+
+			cmd := &Command{inputs{LocalPath: "testdata/project", RemoteApp: "appID", RollbackOnFailure: true}}
+
+			err := cmd.Handler(nil, ui, cli.Clients{Realm: realmClient})
+			assert.Equal(t, errors.New("something bad happened"), err)
+			assert.Equal(t, `Determining changes
+Creating draft
+Pushing changes
+Deploying draft
+Rolling back app to deployment: deployment2
+Successfully rolled back app to deployment: deployment2
+`, out.String())
+
+			t.Log("and should properly pass through the expected inputs")
+			assert.Equal(t, "groupID", capturedGroupID)
+			assert.Equal(t, "appID", capturedAppID)
+			assert.Equal(t, "deployment2", capturedDeploymentID)
+		})
+
+		t.Run("should warn when there is no successful deployment to restore", func(t *testing.T) {
+			out := new(bytes.Buffer)
+			ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+			realmClient.DeploymentsFn = func(groupID, appID string) ([]realm.AppDeployment, error) {
+				return []realm.AppDeployment{{ID: "deployment1", Status: realm.DeploymentStatusFailed}}, nil
+			}
+
+			var redeployed bool
+			realmClient.RedeployDeploymentFn = func(groupID, appID, deploymentID string) error {
+				redeployed = true
+				return nil
+			}
+
+			cmd := &Command{inputs{LocalPath: "testdata/project", RemoteApp: "appID", RollbackOnFailure: true}}
+
+			err := cmd.Handler(nil, ui, cli.Clients{Realm: realmClient})
+			assert.Equal(t, errors.New("something bad happened"), err)
+			assert.Equal(t, `Determining changes
+No successful deployment was found to roll back to should the push fail
+Creating draft
+Pushing changes
+Deploying draft
+`, out.String())
+			assert.False(t, redeployed, "expected no deployment to be restored")
+		})
+	})
+
+	t.Run("with app components to include set", func(t *testing.T) {
+		var realmClient mock.RealmClient
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID"}}, nil
+		}
+
+		t.Run("should return an error if the command fails to export the deployed app", func(t *testing.T) {
+			realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+				return "", nil, errors.New("failed to export")
+			}
+
+			cmd := &Command{inputs{LocalPath: "testdata/project", RemoteApp: "appID", Include: []string{local.ComponentSync}}}
+
+			err := cmd.Handler(nil, mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, new(bytes.Buffer)), cli.Clients{Realm: realmClient})
+			assert.Equal(t, errors.New("failed to export"), err)
+		})
+
+		t.Run("should push the deployed app with only the included components replaced", func(t *testing.T) {
+			out := new(bytes.Buffer)
+			ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+			var capturedExportRequest realm.ExportRequest
+			realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+				capturedExportRequest = req
+				return "eggcorn_20210101", mustZip(t, map[string]string{
+					"config.json": `{
+	"config_version": 20200603,
+	"app_id": "eggcorn-abcde",
+	"name": "eggcorn",
+	"location": "US-VA",
+	"deployment_model": "GLOBAL",
+	"security": {},
+	"custom_user_data_config": {"enabled": false},
+	"sync": {"development_mode_enabled": true}
+}`,
+				}), nil
+			}
+
+			var capturedDiffData, capturedImportData interface{}
+			realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+				capturedDiffData = appData
+				return []string{"diff1"}, nil
+			}
+			realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+				return realm.AppDraft{ID: "draftID"}, nil
+			}
+			realmClient.ImportFn = func(groupID, appID string, appData interface{}) error {
+				capturedImportData = appData
+				return nil
+			}
+			realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+				return realm.AppDeployment{Status: realm.DeploymentStatusSuccessful}, nil
+			}
+
+			cmd := &Command{inputs{LocalPath: "testdata/project", RemoteApp: "appID", Include: []string{local.ComponentSync}}}
+
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+			assert.Equal(t, `Scoping changes to: sync
+Determining changes
+Creating draft
+Pushing changes
+Deploying draft
+Deployment complete
+Successfully pushed app up: eggcorn-abcde
+`, out.String())
+
+			t.Log("and should export the deployed app with the local config version")
+			assert.Equal(t, realm.ExportRequest{ConfigVersion: realm.AppConfigVersion20200603}, capturedExportRequest)
+
+			t.Log("and should only replace the included components")
+			appData, ok := capturedImportData.(*local.AppConfigJSON)
+			assert.True(t, ok, "expected app data to be a config.json app")
+			assert.Equal(t, map[string]interface{}{"development_mode_enabled": false}, appData.Sync)
+			assert.Equal(t, map[string]interface{}{"enabled": false}, appData.CustomUserDataConfig)
+			assert.Equal(t, capturedImportData, capturedDiffData)
+		})
+
+		t.Run("should push a v1 app with all but the excluded components replaced", func(t *testing.T) {
+			out := new(bytes.Buffer)
+			ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+			realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+				return "eggcorn_20210101", mustZip(t, map[string]string{
+					"config.json": `{
+	"config_version": 20200603,
+	"app_id": "eggcorn-abcde",
+	"name": "eggcorn",
+	"location": "US-VA",
+	"deployment_model": "GLOBAL",
+	"security": {},
+	"custom_user_data_config": {"enabled": false},
+	"sync": {"development_mode_enabled": true}
+}`,
+				}), nil
+			}
+
+			var capturedImportData interface{}
+			realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+				return []string{"diff1"}, nil
+			}
+			realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+				return realm.AppDraft{ID: "draftID"}, nil
+			}
+			realmClient.ImportFn = func(groupID, appID string, appData interface{}) error {
+				capturedImportData = appData
+				return nil
+			}
+			realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+				return realm.AppDeployment{Status: realm.DeploymentStatusSuccessful}, nil
+			}
+
+			cmd := &Command{inputs{LocalPath: "testdata/project", RemoteApp: "appID", Exclude: []string{local.ComponentAuth}}}
+
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+			assert.True(t, strings.HasPrefix(out.String(), "Scoping changes to: environments, functions, graphql, hosting, services, settings, sync, triggers, values\n"), "unexpected output:\n%s", out.String())
+
+			appData, ok := capturedImportData.(*local.AppConfigJSON)
+			assert.True(t, ok, "expected app data to be a config.json app")
+			assert.Equal(t, map[string]interface{}{"development_mode_enabled": false}, appData.Sync)
+			assert.Equal(t, map[string]interface{}{"enabled": false}, appData.CustomUserDataConfig)
+		})
+	})
+
+	t.Run("should refresh the baseline of an app which has one after a successful push", func(t *testing.T) {
+		tmpDir, teardown, tmpDirErr := u.NewTempDir("push_baseline")
+		assert.Nil(t, tmpDirErr)
+		defer teardown()
+
+		configData, readErr := ioutil.ReadFile("testdata/project/config.json")
+		assert.Nil(t, readErr)
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, local.FileConfig.String()), configData, 0666))
+		assert.Nil(t, local.WriteBaseline(tmpDir, mustZip(t, map[string]string{"config.json": "stale"})))
+
+		var realmClient mock.RealmClient
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID"}}, nil
+		}
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			return []string{"diff1"}, nil
+		}
+		realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return realm.AppDraft{ID: "draftID"}, nil
+		}
+		realmClient.ImportFn = func(groupID, appID string, appData interface{}) error {
+			return nil
+		}
+		realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+			return realm.AppDeployment{Status: realm.DeploymentStatusSuccessful}, nil
+		}
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			return "eggcorn_20210101", mustZip(t, map[string]string{"config.json": "pushed"}), nil
+		}
+
+		cmd := &Command{inputs{LocalPath: tmpDir, RemoteApp: "appID"}}
+
+		assert.Nil(t, cmd.Handler(nil, mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, new(bytes.Buffer)), cli.Clients{Realm: realmClient}))
+
+		baselineData, readErr := ioutil.ReadFile(filepath.Join(tmpDir, local.NameBaseline, "config.json"))
+		assert.Nil(t, readErr)
+		assert.Equal(t, "pushed", string(baselineData))
+	})
+
+	t.Run("should push the app contained in an archive file", func(t *testing.T) {
+		tmpDir, teardown, tmpDirErr := u.NewTempDir("push_archive")
+		assert.Nil(t, tmpDirErr)
+		defer teardown()
+
+		configData, readErr := ioutil.ReadFile("testdata/project/config.json")
+		assert.Nil(t, readErr)
+
+		archivePath := filepath.Join(tmpDir, "app.tar.gz")
+		assert.Nil(t, local.WriteArchive(archivePath, mustZip(t, map[string]string{"config.json": string(configData)})))
+
+		var realmClient mock.RealmClient
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID"}}, nil
+		}
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			return []string{"diff1"}, nil
+		}
+		realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return realm.AppDraft{ID: "draftID"}, nil
+		}
+		var importedData interface{}
+		realmClient.ImportFn = func(groupID, appID string, appData interface{}) error {
+			importedData = appData
+			return nil
+		}
+		realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+			return realm.AppDeployment{Status: realm.DeploymentStatusSuccessful}, nil
+		}
+
+		cmd := &Command{inputs{Archive: archivePath, RemoteApp: "appID"}}
+
+		out := new(bytes.Buffer)
+		assert.Nil(t, cmd.Handler(nil, mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out), cli.Clients{Realm: realmClient}))
+		assert.Equal(t, testApp.AppData, importedData)
+		assert.True(t, strings.HasSuffix(out.String(), "Successfully pushed app up: eggcorn-abcde\n"), "expected push to succeed, but instead: %s", out.String())
+	})
+
+	t.Run("with an environment set", func(t *testing.T) {
+		setupEnvironmentApp := func(t *testing.T, values string) (string, func()) {
+			t.Helper()
+
+			tmpDir, teardown, err := u.NewTempDir("push_environment")
+			assert.Nil(t, err)
+
+			configData, err := ioutil.ReadFile("testdata/project/config.json")
+			assert.Nil(t, err)
+			assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, local.FileConfig.String()), configData, 0666))
+
+			assert.Nil(t, os.MkdirAll(filepath.Join(tmpDir, local.NameValues), os.ModePerm))
+			assert.Nil(t, ioutil.WriteFile(
+				filepath.Join(tmpDir, local.NameValues, "cluster.json"),
+				[]byte(`{"name":"cluster","value":"%(%%environment.values.clusterName)"}`),
+				0666,
+			))
+
+			assert.Nil(t, os.MkdirAll(filepath.Join(tmpDir, local.NameEnvironments), os.ModePerm))
+			assert.Nil(t, ioutil.WriteFile(
+				filepath.Join(tmpDir, local.NameEnvironments, "production.json"),
+				[]byte(`{"values":`+values+`}`),
+				0666,
+			))
+
+			return tmpDir, teardown
+		}
+
+		t.Run("should push the app with the environment and show its resolved values", func(t *testing.T) {
+			tmpDir, teardown := setupEnvironmentApp(t, `{"clusterName":"Cluster0"}`)
+			defer teardown()
+
+			var realmClient mock.RealmClient
+			realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+				return []realm.App{{ID: "appID", GroupID: "groupID"}}, nil
+			}
+			var diffedData interface{}
+			realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+				diffedData = appData
+				return []string{"diff1"}, nil
+			}
+			realmClient.ExportFn = exportTestProject
+
+			out, ui := mock.NewUI()
+
+			cmd := &Command{inputs{LocalPath: tmpDir, RemoteApp: "appID", Environment: "production", DryRun: true}}
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+			appData, ok := diffedData.(*local.AppConfigJSON)
+			assert.True(t, ok, "expected app data to be v1")
+			assert.Equal(t, "production", appData.Environment)
+
+			assert.True(t, strings.Contains(out.String(), `Using environment 'production' with the following values
+  clusterName: "Cluster0"
+`), "expected environment values to be shown, but instead: %s", out.String())
+		})
+
+		t.Run("should return an error when the environment is missing a referenced value", func(t *testing.T) {
+			tmpDir, teardown := setupEnvironmentApp(t, `{}`)
+			defer teardown()
+
+			cmd := &Command{inputs{LocalPath: tmpDir, RemoteApp: "appID", Environment: "production"}}
+
+			err := cmd.Handler(nil, nil, cli.Clients{})
+			assert.Equal(t, errors.New("environment 'production' is missing values referenced by the app: clusterName"), err)
+		})
+
+		t.Run("should return an error when the environment does not exist", func(t *testing.T) {
+			tmpDir, teardown := setupEnvironmentApp(t, `{}`)
+			defer teardown()
+
+			cmd := &Command{inputs{LocalPath: tmpDir, RemoteApp: "appID", Environment: "staging"}}
+
+			err := cmd.Handler(nil, nil, cli.Clients{})
+			assert.Equal(t, errors.New("failed to find environment 'staging' in the app's environments directory"), err)
+		})
+	})
+
+	t.Run("with an app which references secrets", func(t *testing.T) {
+		tmpDir, teardown, tmpDirErr := u.NewTempDir("push_secrets")
+		assert.Nil(t, tmpDirErr)
+		defer teardown()
+
+		configData, readErr := ioutil.ReadFile("testdata/project/config.json")
+		assert.Nil(t, readErr)
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, local.FileConfig.String()), configData, 0666))
+		assert.Nil(t, local.WriteValue(tmpDir, map[string]interface{}{"name": "linked", "value": "mySecret", "from_secret": true}))
+
+		setupClient := func(secrets []realm.Secret) mock.RealmClient {
+			var realmClient mock.RealmClient
+			realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+				return []realm.App{{ID: "appID", GroupID: "groupID"}}, nil
+			}
+			realmClient.SecretsFn = func(groupID, appID string) ([]realm.Secret, error) {
+				return secrets, nil
+			}
+			realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+				return nil, nil
+			}
+			return realmClient
+		}
+
+		t.Run("should return an error and suggest commands when the secrets do not exist", func(t *testing.T) {
+			out, ui := mock.NewUI()
+
+			cmd := &Command{inputs{LocalPath: tmpDir, RemoteApp: "appID"}}
+
+			err := cmd.Handler(nil, ui, cli.Clients{Realm: setupClient([]realm.Secret{{ID: "secret1", Name: "otherSecret"}})})
+			assert.Equal(t, errMissingSecrets{[]string{"mySecret"}}, err)
+			assert.Equal(t, "Try running instead: realm-cli secrets create --app appID --name mySecret\n", out.String())
+		})
+
+		t.Run("should warn of the secrets rather than return an error when creating a new app", func(t *testing.T) {
+			out := new(bytes.Buffer)
+			ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
+
+			realmClient := setupClient(nil)
+			realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+				return []realm.App{{GroupID: "groupID"}}, nil
+			}
+			realmClient.SecretsFn = func(groupID, appID string) ([]realm.Secret, error) {
+				t.Fatal("expected no secrets to be looked up for a new app")
+				return nil, nil
+			}
+			realmClient.CreateAppFn = func(groupID, name string, meta realm.AppMeta) (realm.App, error) {
+				return realm.App{}, errors.New("something bad happened")
+			}
+
+			cmd := &Command{inputs{LocalPath: tmpDir, RemoteApp: "appID"}}
+
+			err := cmd.Handler(nil, ui, cli.Clients{Realm: realmClient})
+			assert.Equal(t, errors.New("something bad happened"), err)
+			assert.True(t, strings.Contains(out.String(), "The new app references secrets which must be created once it exists: mySecret\n"), "unexpected output: %s", out.String())
+		})
+
+		t.Run("should continue with the push when the secrets exist", func(t *testing.T) {
+			out, ui := mock.NewUI()
+
+			cmd := &Command{inputs{LocalPath: tmpDir, RemoteApp: "appID"}}
+
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: setupClient([]realm.Secret{{ID: "secret1", Name: "mySecret"}})}))
+			assert.True(t, strings.HasSuffix(out.String(), "Deployed app is identical to proposed version, nothing to do\n"), "unexpected output: %s", out.String())
+		})
+	})
+
 	t.Run("with a realm client that successfully imports and deploys drafts", func(t *testing.T) {
 		var realmClient mock.RealmClient
 		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
@@ -691,6 +1136,7 @@ Deployed app is identical to proposed version, nothing to do
 		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
 			return []string{"diff1", "diff2"}, nil
 		}
+		realmClient.ExportFn = exportTestProject
 
 		out, ui := mock.NewUI()
 
@@ -723,6 +1169,7 @@ Try running instead: realm-cli import --local testdata/project --remote appID
 		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
 			return []string{"diff1", "diff2"}, nil
 		}
+		realmClient.ExportFn = exportTestProject
 
 		out, ui := mock.NewUI()
 
@@ -774,6 +1221,7 @@ Try running instead: realm-cli import --local testdata/dependencies --remote app
 		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
 			return []string{"diff1", "diff2"}, nil
 		}
+		realmClient.ExportFn = exportTestProject
 
 		_, console, _, ui, consoleErr := mock.NewVT10XConsole()
 		assert.Nil(t, consoleErr)
@@ -799,37 +1247,338 @@ Try running instead: realm-cli import --local testdata/dependencies --remote app
 	})
 }
 
-func TestPushCommandCreateNewApp(t *testing.T) {
-	groupID := "groupID"
-	appID := primitive.NewObjectID().Hex()
+func TestPushHandlerHosting(t *testing.T) {
+	hosting := map[string]interface{}{"enabled": true}
 
-	fullPkg := &local.AppConfigJSON{local.AppDataV1{local.AppStructureV1{
-		Name:            "name",
-		Location:        realm.Location("location"),
-		DeploymentModel: realm.DeploymentModel("deployment_model"),
-	}}}
+	for _, tc := range []struct {
+		description string
+		config      local.File
+		appData     local.AppData
+	}{
+		{
+			description: "v1",
+			config:      local.FileConfig,
+			appData: &local.AppConfigJSON{AppDataV1: local.AppDataV1{AppStructureV1: local.AppStructureV1{
+				ConfigVersion: realm.AppConfigVersion20200603,
+				Name:          "eggcorn",
+				Hosting:       hosting,
+			}}},
+		},
+		{
+			description: "v2",
+			config:      local.FileRealmConfig,
+			appData: &local.AppRealmConfigJSON{AppDataV2: local.AppDataV2{AppStructureV2: local.AppStructureV2{
+				ConfigVersion: realm.AppConfigVersion20210101,
+				Name:          "eggcorn",
+				Hosting:       hosting,
+			}}},
+		},
+	} {
+		t.Run(fmt.Sprintf("should push the hosting config of a written %s app", tc.description), func(t *testing.T) {
+			tmpDir, teardown, err := u.NewTempDir("push_hosting")
+			assert.Nil(t, err)
+			defer teardown()
 
-	t.Run("with a client that successfully creates apps", func(t *testing.T) {
-		realmClient := mock.RealmClient{}
-		realmClient.CreateAppFn = func(groupID, name string, meta realm.AppMeta) (realm.App, error) {
-			return realm.App{
-				GroupID: groupID,
-				ID:      appID,
-				Name:    name,
-				AppMeta: meta,
-			}, nil
-		}
+			assert.Nil(t, local.App{RootDir: tmpDir, Config: tc.config, AppData: tc.appData}.Write())
 
-		t.Run("and a ui that is not set to auto confirm", func(t *testing.T) {
-			for _, tc := range []struct {
-				description     string
-				autoConfirm     bool
-				procedure       func(c *expect.Console)
-				expectedApp     realm.App
-				expectedProceed bool
-				test            func(t *testing.T, configPath string)
-			}{
-				{
+			var realmClient mock.RealmClient
+			realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+				return []realm.App{{ID: "appID", GroupID: "groupID"}}, nil
+			}
+			realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+				return []string{"diff1"}, nil
+			}
+			realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+				return realm.AppDraft{ID: "draftID"}, nil
+			}
+			realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+				return realm.AppDeployment{ID: "deploymentID", Status: realm.DeploymentStatusSuccessful}, nil
+			}
+
+			var capturedAppData interface{}
+			realmClient.ImportFn = func(groupID, appID string, appData interface{}) error {
+				capturedAppData = appData
+				return nil
+			}
+
+			ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, new(bytes.Buffer))
+
+			cmd := &Command{inputs{LocalPath: tmpDir, RemoteApp: "appID"}}
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+			payload, err := json.Marshal(capturedAppData)
+			assert.Nil(t, err)
+
+			var imported struct {
+				Hosting map[string]interface{} `json:"hosting"`
+			}
+			assert.Nil(t, json.Unmarshal(payload, &imported))
+			assert.Equal(t, hosting, imported.Hosting)
+		})
+	}
+}
+
+func TestPushHandlerAll(t *testing.T) {
+	profile, teardown := mock.NewProfileFromTmpDir(t, "push_all_test")
+	defer teardown()
+
+	assert.Nil(t, ioutil.WriteFile(
+		filepath.Join(profile.WorkingDirectory, local.FileWorkspace.String()),
+		[]byte(`{"apps":[{"path":"auth"},{"path":"sync","app":"sync-abcde","project":"syncGroupID"}]}`),
+		0666,
+	))
+	for _, name := range []string{"auth", "sync"} {
+		app := local.NewApp(
+			filepath.Join(profile.WorkingDirectory, name),
+			name+"-abcde",
+			name,
+			realm.LocationVirginia,
+			realm.DeploymentModelGlobal,
+			realm.DefaultAppConfigVersion,
+		)
+		assert.Nil(t, app.Write())
+	}
+
+	var realmClient mock.RealmClient
+	realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+		if filter.App == "sync-abcde" {
+			return nil, errors.New("something bad happened")
+		}
+		return []realm.App{{ID: "authID", GroupID: "groupID"}}, nil
+	}
+	realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+		return []string{}, nil
+	}
+
+	t.Run("should push every app of the workspace and summarize the results", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		cmd := &Command{inputs{All: true, DryRun: true}}
+		assert.Nil(t, cmd.inputs.Resolve(profile, ui))
+
+		err := cmd.Handler(profile, ui, cli.Clients{Realm: realmClient})
+		assert.Equal(t, cli.ErrWorkspaceFailed{Failed: 1, Total: 2}, err)
+
+		assert.Equal(t, `Workspace app: auth
+Determining changes
+Deployed app is identical to proposed version, nothing to do
+Workspace app: sync
+Workspace import summary
+  App   Result                
+  ----  ----------------------
+  auth  ok                    
+  sync  something bad happened
+`, out.String())
+	})
+
+	t.Run("should return an error when the changes are not auto-confirmed", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		cmd := &Command{inputs{All: true}}
+
+		err := cmd.Handler(profile, ui, cli.Clients{Realm: realmClient})
+		assert.Equal(t, cli.ErrWorkspaceConfirm, err)
+	})
+
+	t.Run("should return an error when there is no workspace manifest", func(t *testing.T) {
+		_, ui := mock.NewUI()
+
+		cmd := &Command{inputs{All: true}}
+
+		err := cmd.Handler(&cli.Profile{WorkingDirectory: "testdata/project"}, ui, cli.Clients{})
+		assert.Equal(t, cli.ErrWorkspaceNotFound, err)
+	})
+}
+
+func TestPushHandlerPlan(t *testing.T) {
+	setupClient := func(imported *bool) mock.RealmClient {
+		var realmClient mock.RealmClient
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID"}}, nil
+		}
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			return []string{"diff1"}, nil
+		}
+		realmClient.ExportFn = exportTestProject
+		realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
+			return realm.AppDraft{ID: "draftID"}, nil
+		}
+		realmClient.ImportFn = func(groupID, appID string, appData interface{}) error {
+			*imported = true
+			return nil
+		}
+		realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
+			return realm.AppDeployment{Status: realm.DeploymentStatusSuccessful}, nil
+		}
+		return realmClient
+	}
+
+	savePlan := func(t *testing.T, realmClient realm.Client, planPath string) {
+		t.Helper()
+
+		_, ui := mock.NewUI()
+
+		cmd := &Command{inputs{LocalPath: "testdata/project", RemoteApp: "appID", PlanOut: planPath}}
+		assert.Nil(t, cmd.inputs.Resolve(nil, ui))
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+	}
+
+	t.Run("should save the computed changes to the plan file without pushing them", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("push_plan")
+		assert.Nil(t, err)
+		defer teardown()
+
+		planPath := filepath.Join(tmpDir, "plan.json")
+
+		var imported bool
+
+		out, ui := mock.NewUI()
+
+		cmd := &Command{inputs{LocalPath: "testdata/project", RemoteApp: "appID", PlanOut: planPath}}
+		assert.Nil(t, cmd.inputs.Resolve(nil, ui))
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: setupClient(&imported)}))
+
+		assert.Equal(t, `Determining changes
+The following reflects the planned changes to your Realm app
+diff1
+Saved plan to `+planPath+`
+To push exactly these changes run: realm-cli import --local testdata/project --plan `+planPath+`
+`, out.String())
+		assert.True(t, !imported, "expected no changes to be pushed")
+
+		p, err := readPlan(planPath)
+		assert.Nil(t, err)
+		assert.Equal(t, "groupID", p.GroupID)
+		assert.Equal(t, "appID", p.AppID)
+		assert.Equal(t, []string{"diff1"}, p.AppDiffs)
+		assert.True(t, p.LocalHash != "", "expected the plan to have a local hash")
+		assert.True(t, p.RemoteHash != "", "expected the plan to have a remote hash")
+	})
+
+	t.Run("should push the changes of a plan when nothing has changed since it was made", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("push_plan")
+		assert.Nil(t, err)
+		defer teardown()
+
+		planPath := filepath.Join(tmpDir, "plan.json")
+
+		var imported bool
+		realmClient := setupClient(&imported)
+
+		savePlan(t, realmClient, planPath)
+
+		out, ui := mock.NewUI()
+
+		cmd := &Command{inputs{LocalPath: "testdata/project", Plan: planPath}}
+		assert.Nil(t, cmd.inputs.Resolve(nil, ui))
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+
+		assert.True(t, strings.HasPrefix(out.String(), "Determining changes\nVerified plan: "+planPath+"\n"), "unexpected output: %s", out.String())
+		assert.True(t, imported, "expected the changes to be pushed")
+	})
+
+	t.Run("should refuse to push a plan when the remote app has changed since it was made", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("push_plan")
+		assert.Nil(t, err)
+		defer teardown()
+
+		planPath := filepath.Join(tmpDir, "plan.json")
+
+		var imported bool
+		realmClient := setupClient(&imported)
+
+		savePlan(t, realmClient, planPath)
+
+		realmClient.ExportFn = func(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+			zipPkg, err := u.NewZipReader(map[string]string{
+				local.FileConfig.String(): `{"config_version":20200603,"app_id":"eggcorn-abcde","name":"eggcorn-renamed"}`,
+			})
+			return "eggcorn_20200603", zipPkg, err
+		}
+
+		_, ui := mock.NewUI()
+
+		cmd := &Command{inputs{LocalPath: "testdata/project", Plan: planPath}}
+		assert.Nil(t, cmd.inputs.Resolve(nil, ui))
+
+		err = cmd.Handler(nil, ui, cli.Clients{Realm: realmClient})
+		assert.Equal(t, errPlanStale{"the remote app has changed"}, err)
+		assert.True(t, !imported, "expected no changes to be pushed")
+	})
+
+	t.Run("should refuse to push a plan when the local app has changed since it was made", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("push_plan")
+		assert.Nil(t, err)
+		defer teardown()
+
+		planPath := filepath.Join(tmpDir, "plan.json")
+
+		var imported bool
+		realmClient := setupClient(&imported)
+
+		savePlan(t, realmClient, planPath)
+
+		p, err := readPlan(planPath)
+		assert.Nil(t, err)
+		p.LocalHash = "outdated"
+		assert.Nil(t, writePlan(planPath, p))
+
+		_, ui := mock.NewUI()
+
+		cmd := &Command{inputs{LocalPath: "testdata/project", Plan: planPath}}
+		assert.Nil(t, cmd.inputs.Resolve(nil, ui))
+
+		err = cmd.Handler(nil, ui, cli.Clients{Realm: realmClient})
+		assert.Equal(t, errPlanStale{"the local app has changed"}, err)
+		assert.True(t, !imported, "expected no changes to be pushed")
+	})
+
+	t.Run("should return an error when planning the push of a new app", func(t *testing.T) {
+		var realmClient mock.RealmClient
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return nil, nil
+		}
+
+		_, ui := mock.NewUI()
+
+		cmd := &Command{inputs{LocalPath: "testdata/project", Project: "groupID", PlanOut: "plan.json"}}
+
+		err := cmd.Handler(nil, ui, cli.Clients{Realm: realmClient})
+		assert.Equal(t, errPlanNewApp, err)
+	})
+}
+
+func TestPushCommandCreateNewApp(t *testing.T) {
+	groupID := "groupID"
+	appID := primitive.NewObjectID().Hex()
+
+	fullPkg := &local.AppConfigJSON{local.AppDataV1{local.AppStructureV1{
+		Name:            "name",
+		Location:        realm.Location("location"),
+		DeploymentModel: realm.DeploymentModel("deployment_model"),
+	}}}
+
+	t.Run("with a client that successfully creates apps", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.CreateAppFn = func(groupID, name string, meta realm.AppMeta) (realm.App, error) {
+			return realm.App{
+				GroupID: groupID,
+				ID:      appID,
+				Name:    name,
+				AppMeta: meta,
+			}, nil
+		}
+
+		t.Run("and a ui that is not set to auto confirm", func(t *testing.T) {
+			for _, tc := range []struct {
+				description     string
+				autoConfirm     bool
+				procedure       func(c *expect.Console)
+				expectedApp     realm.App
+				expectedProceed bool
+				test            func(t *testing.T, configPath string)
+			}{
+				{
 					description: "should return empty data if user does not wish to continue",
 					procedure: func(c *expect.Console) {
 						c.ExpectString("Do you wish to create a new app?")
@@ -1046,367 +1795,30 @@ func TestPushCommandCreateNewApp(t *testing.T) {
 	})
 }
 
-func TestPushCommandCreateNewDraft(t *testing.T) {
-	t.Run("should create and return the draft when initially successful", func(t *testing.T) {
-		groupID, appID := "groupID", "appID"
-		testDraft := realm.AppDraft{ID: "id"}
-
-		realmClient := mock.RealmClient{}
-
-		var capturedGroupID, capturedAppID string
-		realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
-			capturedGroupID = groupID
-			capturedAppID = appID
-			return testDraft, nil
-		}
-
-		draft, proceed, err := createNewDraft(nil, realmClient, appRemote{groupID, appID})
-		assert.Nil(t, err)
-		assert.Equal(t, testDraft, draft)
-		assert.True(t, proceed, "expected draft to be created successfully")
-
-		t.Log("and should properly pass through the expected inputs")
-		assert.Equal(t, groupID, capturedGroupID)
-		assert.Equal(t, appID, capturedAppID)
-	})
-
-	t.Run("should return the error if client fails to create the draft for reasons other than it already exists", func(t *testing.T) {
-		realmClient := mock.RealmClient{}
-		realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
-			return realm.AppDraft{}, errors.New("something bad happened while creating a draft")
-		}
-
-		_, _, err := createNewDraft(nil, realmClient, appRemote{})
-		assert.Equal(t, errors.New("something bad happened while creating a draft"), err)
-	})
-
-	t.Run("with a client that fails to create a draft because it already exists", func(t *testing.T) {
-		errDraftAlreadyExists := realm.ServerError{Code: realm.ErrCodeDraftAlreadyExists, Message: "a draft already exists"}
-
-		realmClient := mock.RealmClient{}
-		realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
-			return realm.AppDraft{}, errDraftAlreadyExists
-		}
-
-		t.Run("and fails to retrieve the existing draft should return the error", func(t *testing.T) {
-			realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
-				return realm.AppDraft{}, errors.New("something bad happened while getting a draft")
-			}
-
-			_, _, err := createNewDraft(nil, realmClient, appRemote{})
-			assert.Equal(t, errors.New("something bad happened while getting a draft"), err)
-		})
-
-		t.Run("and successfully retrieves and diffs the existing draft", func(t *testing.T) {
-			draftID := "draftID"
-
-			realmClient.DraftFn = func(groupID, appID string) (realm.AppDraft, error) {
-				return realm.AppDraft{ID: draftID}, nil
-			}
-
-			realmClient.DiffDraftFn = func(groupID, appID, draftID string) (realm.AppDraftDiff, error) {
-				return realm.AppDraftDiff{}, nil
-			}
-
-			t.Run("with a ui set to auto-confirm", func(t *testing.T) {
-				out := new(bytes.Buffer)
-				ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out)
-
-				t.Run("and a client that fails to discard the draft should return the error", func(t *testing.T) {
-					var capturedDraftID string
-					realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
-						capturedDraftID = draftID
-						return errors.New("something bad happened while discarding the draft")
-					}
-
-					_, _, err := createNewDraft(ui, realmClient, appRemote{})
-					assert.Equal(t, errors.New("something bad happened while discarding the draft"), err)
-
-					t.Log("and should properly pass through the expected inputs")
-					assert.Equal(t, draftID, capturedDraftID)
-				})
-
-				t.Run("and a client that successfully discards the existing draft", func(t *testing.T) {
-					realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
-						return nil
-					}
-
-					t.Run("but still fails to create a new draft should return the error", func(t *testing.T) {
-						realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
-							return realm.AppDraft{}, errDraftAlreadyExists
-						}
-
-						_, _, err := createNewDraft(ui, realmClient, appRemote{})
-						assert.Equal(t, errDraftAlreadyExists, err)
-					})
-
-					t.Run("and successfully creates a new draft should be successful", func(t *testing.T) {
-						testDraft := realm.AppDraft{ID: "id"}
-
-						realmClient := mock.RealmClient{}
-
-						realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
-							return testDraft, nil
-						}
-
-						draft, proceed, err := createNewDraft(nil, realmClient, appRemote{})
-						assert.Nil(t, err)
-						assert.Equal(t, testDraft, draft)
-						assert.True(t, proceed, "expected draft to be created successfully")
-					})
-				})
-			})
-
-			t.Run("should prompt the user to accept the diffed changes", func(t *testing.T) {
-				t.Run("and mark the draft as kept in command outputs if the user selects no", func(t *testing.T) {
-					_, console, _, ui, consoleErr := mock.NewVT10XConsole()
-					assert.Nil(t, consoleErr)
-					defer console.Close()
-
-					doneCh := make(chan (struct{}))
-					go func() {
-						defer close(doneCh)
-
-						console.ExpectString("Would you like to discard this draft?")
-						console.SendLine("")
-						console.ExpectEOF()
-					}()
-
-					draft, proceed, err := createNewDraft(ui, realmClient, appRemote{})
-
-					console.Tty().Close() // flush the writers
-					<-doneCh              // wait for procedure to complete
-
-					assert.Nil(t, err)
-					assert.Equal(t, realm.AppDraft{}, draft)
-					assert.False(t, proceed, "expected draft to be rejected")
-				})
-
-				t.Run("and return a newly created draft if the user selects yes", func(t *testing.T) {
-					testDraft := realm.AppDraft{ID: "id"}
+func TestPushCommandRollbackDeployment(t *testing.T) {
+	pushErr := errors.New("something bad happened")
 
-					realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
-						return nil
-					}
-
-					realmClient.CreateDraftFn = func(groupID, appID string) (realm.AppDraft, error) {
-						return testDraft, nil
-					}
-
-					_, console, _, ui, consoleErr := mock.NewVT10XConsole()
-					assert.Nil(t, consoleErr)
-					defer console.Close()
-
-					doneCh := make(chan (struct{}))
-					go func() {
-						defer close(doneCh)
-
-						console.ExpectString("Would you like to discard this draft?")
-						console.SendLine("y")
-						console.ExpectEOF()
-					}()
-
-					draft, proceed, err := createNewDraft(ui, realmClient, appRemote{})
-
-					console.Tty().Close() // flush the writers
-					<-doneCh              // wait for procedure to complete
+	t.Run("should return the push error without a deployment to restore", func(t *testing.T) {
+		out, ui := mock.NewUI()
 
-					assert.Nil(t, err)
-					assert.Equal(t, testDraft, draft)
-					assert.True(t, proceed, "expected draft to be created successfully")
-				})
-			})
-		})
+		err := rollbackDeployment(ui, mock.RealmClient{}, appRemote{"groupID", "appID"}, realm.AppDeployment{}, pushErr)
+		assert.Equal(t, pushErr, err)
+		assert.Equal(t, "", out.String())
 	})
-}
 
-func TestPushCommandDiffDraft(t *testing.T) {
-	t.Run("with a client that fails to diff the draft should return the error", func(t *testing.T) {
-		groupID, appID, draftID := "groupID", "appID", "draftID"
+	t.Run("should print a warning and return the push error when the restore fails", func(t *testing.T) {
+		out, ui := mock.NewUI()
 
 		var realmClient mock.RealmClient
-
-		var capturedGroupID, capturedAppID, capturedDraftID string
-		realmClient.DiffDraftFn = func(groupID, appID, draftID string) (realm.AppDraftDiff, error) {
-			capturedGroupID = groupID
-			capturedAppID = appID
-			capturedDraftID = draftID
-			return realm.AppDraftDiff{}, errors.New("something bad happened")
+		realmClient.RedeployDeploymentFn = func(groupID, appID, deploymentID string) error {
+			return errors.New("failed to redeploy")
 		}
 
-		err := diffDraft(nil, realmClient, appRemote{groupID, appID}, draftID)
-		assert.Equal(t, errors.New("something bad happened"), err)
-
-		t.Log("and should properly pass through the expected inputs")
-		assert.Equal(t, groupID, capturedGroupID)
-		assert.Equal(t, appID, capturedAppID)
-		assert.Equal(t, draftID, capturedDraftID)
-	})
-
-	t.Run("should print the expected contents", func(t *testing.T) {
-		for _, tc := range []struct {
-			description      string
-			actualDiff       realm.AppDraftDiff
-			expectedContents string
-		}{
-			{
-				description:      "with a client that returns an empty diff",
-				expectedContents: "An empty draft already exists for your app\n",
-			},
-			{
-				description: "with a client that returns a minimal diff",
-				actualDiff:  realm.AppDraftDiff{Diffs: []string{"diff1", "diff2", "diff3"}},
-				expectedContents: strings.Join(
-					[]string{
-						"The following draft already exists for your app...",
-						"  diff1",
-						"  diff2",
-						"  diff3\n",
-					},
-					"\n",
-				),
-			},
-			{
-				description: "with a client that returns a full diff",
-				actualDiff: realm.AppDraftDiff{
-					Diffs: []string{"diff1", "diff2", "diff3"},
-					HostingFilesDiff: realm.HostingFilesDiff{
-						Added:   []string{"hosting_added1", "hosting_added2"},
-						Deleted: []string{"hosting_deleted1"},
-					},
-					DependenciesDiff: realm.DependenciesDiff{
-						Added: []realm.DependencyData{{"dep_added1", "v1"}},
-						Modified: []realm.DependencyDiffData{
-							{realm.DependencyData{"dep_modified1", "v1"}, "v2"},
-							{realm.DependencyData{"dep_modified2", "v2"}, "v1"},
-						},
-					},
-					GraphQLConfigDiff: realm.GraphQLConfigDiff{[]realm.FieldDiff{{"gql_field1", "previous", "updated"}}},
-					SchemaOptionsDiff: realm.SchemaOptionsDiff{
-						GraphQLValidationDiffs: []realm.FieldDiff{{"gql_validation_field1", "old", "new"}},
-						RestValidationDiffs:    []realm.FieldDiff{{"rest_validation_field1", "old", "new"}},
-					},
-				},
-				expectedContents: strings.Join(
-					[]string{
-						"The following draft already exists for your app...",
-						"  diff1",
-						"  diff2",
-						"  diff3",
-						"With changes to your static hosting files...",
-						"  added: hosting_added1",
-						"  added: hosting_added2",
-						"  deleted: hosting_deleted1",
-						"With changes to your app dependencies...",
-						"  + dep_added1@v1",
-						"  dep_modified1@v2 -> dep_modified1@v1",
-						"  dep_modified2@v1 -> dep_modified2@v2",
-						"With changes to your GraphQL configuration...",
-						"  gql_field1: previous -> updated",
-						"With changes to your app schema...",
-						"  gql_validation_field1: old -> new",
-						"  rest_validation_field1: old -> new",
-						"",
-					},
-					"\n",
-				),
-			},
-		} {
-			t.Run(tc.description, func(t *testing.T) {
-				var realmClient mock.RealmClient
-				realmClient.DiffDraftFn = func(groupID, appID, draftID string) (realm.AppDraftDiff, error) {
-					return tc.actualDiff, nil
-				}
-
-				out, ui := mock.NewUI()
-
-				assert.Nil(t, diffDraft(ui, realmClient, appRemote{}, ""))
-				assert.Equal(t, tc.expectedContents, out.String())
-			})
-		}
-	})
-}
-
-func TestPushCommandDeployDraftAndWait(t *testing.T) {
-	groupID, appID, draftID := "groupID", "appID", "draftID"
-	t.Run("should return an error with a client that fails to deploy the draft", func(t *testing.T) {
-		realmClient := mock.RealmClient{}
-
-		var capturedGroupID, capturedAppID, capturedDraftID string
-		realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
-			capturedGroupID = groupID
-			capturedAppID = appID
-			capturedDraftID = draftID
-			return realm.AppDeployment{}, errors.New("something bad happened")
-		}
-
-		err := deployDraftAndWait(nil, realmClient, appRemote{groupID, appID}, draftID)
-		assert.Equal(t, errors.New("something bad happened"), err)
-
-		t.Log("and should properly pass through the expected inputs")
-		assert.Equal(t, groupID, capturedGroupID)
-		assert.Equal(t, appID, capturedAppID)
-		assert.Equal(t, draftID, capturedDraftID)
-	})
-
-	t.Run("with a client that successfully deploys a draft", func(t *testing.T) {
-		realmClient := mock.RealmClient{}
-		realmClient.DeployDraftFn = func(groupID, appID, draftID string) (realm.AppDeployment, error) {
-			return realm.AppDeployment{ID: "id", Status: realm.DeploymentStatusCreated}, nil
-		}
-
-		t.Run("but fails to get the deployment", func(t *testing.T) {
-			realmClient.DeploymentFn = func(groupID, appID, deploymentID string) (realm.AppDeployment, error) {
-				return realm.AppDeployment{}, errors.New("something bad happened")
-			}
-
-			for _, tc := range []struct {
-				description      string
-				discardDraftErr  error
-				expectedContents string
-			}{
-				{
-					description: "yet can successfully discard the draft should return the error",
-				},
-				{
-					description:      "and fails to discard the draft should return the deployment error and print a warning message",
-					discardDraftErr:  errors.New("failed to discard draft"),
-					expectedContents: "Failed to discard the draft created for your deployment\n",
-				},
-			} {
-				t.Run(tc.description, func(t *testing.T) {
-					realmClient.DiscardDraftFn = func(groupID, appID, draftID string) error {
-						return tc.discardDraftErr
-					}
-
-					out, ui := mock.NewUI()
-
-					err := deployDraftAndWait(ui, realmClient, appRemote{groupID, appID}, draftID)
-					assert.Equal(t, errors.New("something bad happened"), err)
-					assert.Equal(t, tc.expectedContents, out.String())
-				})
-			}
-		})
-
-		t.Run("and successfully retrieves the deployment should eventually succeed", func(t *testing.T) {
-			var polls int
-
-			realmClient.DeploymentFn = func(groupID, appID, deploymentID string) (realm.AppDeployment, error) {
-				status := realm.DeploymentStatusPending
-				if polls > 1 {
-					status = realm.DeploymentStatusSuccessful
-				}
-				polls++
-				return realm.AppDeployment{ID: deploymentID, Status: status}, nil
-			}
-
-			out, ui := mock.NewUI()
-
-			err := deployDraftAndWait(ui, realmClient, appRemote{groupID, appID}, draftID)
-			assert.Nil(t, err)
-
-			assert.Equal(t, "Deployment complete\n", out.String())
-		})
+		err := rollbackDeployment(ui, realmClient, appRemote{"groupID", "appID"}, realm.AppDeployment{ID: "deploymentID"}, pushErr)
+		assert.Equal(t, pushErr, err)
+		assert.Equal(t, `Rolling back app to deployment: deploymentID
+Failed to roll back app to deployment 'deploymentID': failed to redeploy
+`, out.String())
 	})
 }
 
@@ -1442,8 +1854,17 @@ func TestPushCommandDisplay(t *testing.T) {
 				IncludeHosting:      true,
 				ResetCDNCache:       true,
 				DryRun:              true,
+				RollbackOnFailure:   true,
+				Watch:               true,
+				Environment:         "production",
+				Include:             []string{"functions", "triggers"},
 			},
-			display: "realm-cli import --project project --local directory --remote remote --include-dependencies --include-hosting --reset-cdn-cache --dry-run",
+			display: "realm-cli import --project project --local directory --remote remote --include-dependencies --include-hosting --reset-cdn-cache --rollback-on-failure --watch --environment production --include functions,triggers --dry-run",
+		},
+		{
+			description: "should print the excluded app components",
+			inputs:      inputs{Exclude: []string{"graphql"}},
+			display:     "realm-cli import --exclude graphql",
 		},
 	} {
 		t.Run(tc.description, func(t *testing.T) {
@@ -1468,3 +1889,19 @@ Deployment complete
 Successfully pushed app up: eggcorn-abcde
 `, out.String())
 }
+
+func mustZip(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+	zipPkg, err := u.NewZipReader(files)
+	assert.Nil(t, err)
+	return zipPkg
+}
+
+func exportTestProject(groupID, appID string, req realm.ExportRequest) (string, *zip.Reader, error) {
+	config, err := ioutil.ReadFile("testdata/project/config.json")
+	if err != nil {
+		return "", nil, err
+	}
+	zipPkg, err := u.NewZipReader(map[string]string{local.FileConfig.String(): string(config)})
+	return "eggcorn_20200603", zipPkg, err
+}
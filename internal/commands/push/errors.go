@@ -1,5 +1,10 @@
 package push
 
+import (
+	"fmt"
+	"strings"
+)
+
 type errProjectNotFound struct {
 }
 
@@ -8,3 +13,23 @@ func (err errProjectNotFound) Error() string {
 }
 
 func (err errProjectNotFound) DisableUsage() struct{} { return struct{}{} }
+
+type errMissingSecrets struct {
+	names []string
+}
+
+func (err errMissingSecrets) Error() string {
+	return fmt.Sprintf("app references secrets which do not exist: %s", strings.Join(err.names, ", "))
+}
+
+func (err errMissingSecrets) DisableUsage() struct{} { return struct{}{} }
+
+type errPlanStale struct {
+	reason string
+}
+
+func (err errPlanStale) Error() string {
+	return "cannot apply plan, " + err.reason + " since the plan was made"
+}
+
+func (err errPlanStale) DisableUsage() struct{} { return struct{}{} }
@@ -14,4 +14,12 @@ func TestPushErrors(t *testing.T) {
 		_, ok := err.(cli.DisableUsage)
 		assert.True(t, ok, "expected project not found error to disable usage")
 	})
+
+	t.Run("err missing secrets should list the missing secret names", func(t *testing.T) {
+		assert.Equal(t, "app references secrets which do not exist: a, b", errMissingSecrets{[]string{"a", "b"}}.Error())
+	})
+
+	t.Run("err plan stale should print why the plan cannot be applied", func(t *testing.T) {
+		assert.Equal(t, "cannot apply plan, the local app has changed since the plan was made", errPlanStale{"the local app has changed"}.Error())
+	})
 }
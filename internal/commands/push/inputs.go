@@ -1,11 +1,16 @@
 package push
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/10gen/realm-cli/internal/cli"
 	"github.com/10gen/realm-cli/internal/cloud/realm"
 	"github.com/10gen/realm-cli/internal/local"
 	"github.com/10gen/realm-cli/internal/terminal"
 	"github.com/10gen/realm-cli/internal/utils/flags"
+
+	"github.com/mitchellh/go-homedir"
 )
 
 const (
@@ -31,10 +36,56 @@ const (
 	flagDryRunShort = "x"
 	flagDryRunUsage = "include to run without pushing any changes to the Realm server"
 
+	flagRollbackOnFailure      = "rollback-on-failure"
+	flagRollbackOnFailureUsage = "include to restore the last successful deployment should the push fail to deploy or upload its changes"
+
+	flagWatch      = "watch"
+	flagWatchShort = "w"
+	flagWatchUsage = "include to keep watching your local directory and push changes as they are saved"
+
+	flagArchive      = "archive"
+	flagArchiveUsage = "specify an archive file (.zip, .tar or .tar.gz) containing a Realm app to import"
+
+	flagEnvironment      = "environment"
+	flagEnvironmentUsage = "specify the app environment to push with, e.g. development, testing, qa or production"
+
+	flagInclude      = "include"
+	flagIncludeUsage = "specify the app components to push, leaving all others as they are deployed"
+
+	flagExclude      = "exclude"
+	flagExcludeUsage = "specify the app components to leave as they are deployed, pushing all others"
+
+	flagPlanOut      = "plan-out"
+	flagPlanOutUsage = "specify a file to save the computed changes to as a plan, without pushing them"
+
+	flagPlan      = "plan"
+	flagPlanUsage = "specify a plan file saved with --plan-out to push exactly, provided nothing has changed since it was made"
+
+	flagAll      = "all"
+	flagAllUsage = "include to push every Realm app listed in the realm-workspace.json workspace manifest"
+
 	flagProject      = "project"
 	flagProjectUsage = "the MongoDB cloud project id"
 )
 
+// set of `secrets create` command strings suggested when referenced secrets are missing
+const (
+	commandSecretsCreate = "secrets create"
+
+	flagApp  = "app"
+	flagName = "name"
+)
+
+var (
+	errIncludeExcludeConflict = errors.New("cannot use both --include and --exclude flags")
+	errArchiveLocalConflict   = errors.New("cannot use both --local and --archive flags")
+	errArchiveWatchConflict   = errors.New("cannot use both --watch and --archive flags")
+	errAllConflict            = errors.New("cannot use --all with --local, --archive, --remote or --watch flags")
+	errPlanOutConflict        = errors.New("cannot use --plan-out with --plan, --watch or --all flags")
+	errPlanConflict           = errors.New("cannot use --plan with flags which change the computed changes, these are set by the plan")
+	errPlanNewApp             = errors.New("cannot plan the push of a new app, the app must be created first")
+)
+
 type appRemote struct {
 	GroupID string
 	AppID   string
@@ -42,15 +93,59 @@ type appRemote struct {
 
 type inputs struct {
 	LocalPath           string
+	Archive             string
 	Project             string
 	RemoteApp           string
 	IncludeDependencies bool
 	IncludeHosting      bool
 	ResetCDNCache       bool
 	DryRun              bool
+	RollbackOnFailure   bool
+	Watch               bool
+	Environment         string
+	Include             []string
+	Exclude             []string
+	All                 bool
+	PlanOut             string
+	Plan                string
+
+	plan *pushPlan
 }
 
 func (i *inputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if len(i.Include) > 0 && len(i.Exclude) > 0 {
+		return errIncludeExcludeConflict
+	}
+
+	if i.PlanOut != "" {
+		if i.Plan != "" || i.Watch || i.All {
+			return errPlanOutConflict
+		}
+
+		planOut, err := homedir.Expand(i.PlanOut)
+		if err != nil {
+			return err
+		}
+		i.PlanOut = planOut
+	}
+
+	if i.Plan != "" {
+		if err := i.resolvePlan(); err != nil {
+			return err
+		}
+	}
+
+	if i.All {
+		if i.LocalPath != "" || i.Archive != "" || i.RemoteApp != "" || i.Watch {
+			return errAllConflict
+		}
+		return nil
+	}
+
+	if i.Archive != "" {
+		return i.resolveArchive()
+	}
+
 	wd := i.LocalPath
 	if wd == "" {
 		wd = profile.WorkingDirectory
@@ -75,6 +170,55 @@ func (i *inputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
 	return nil
 }
 
+// resolvePlan loads the plan to push and sets every input which shapes the computed changes from it
+func (i *inputs) resolvePlan() error {
+	if i.DryRun || i.Watch || i.All ||
+		i.Project != "" || i.RemoteApp != "" || i.Environment != "" ||
+		i.IncludeDependencies || i.IncludeHosting ||
+		len(i.Include) > 0 || len(i.Exclude) > 0 {
+		return errPlanConflict
+	}
+
+	path, err := homedir.Expand(i.Plan)
+	if err != nil {
+		return err
+	}
+
+	p, err := readPlan(path)
+	if err != nil {
+		return err
+	}
+
+	i.Project = p.GroupID
+	i.RemoteApp = p.RemoteApp
+	i.Environment = p.Environment
+	i.Include = p.Include
+	i.Exclude = p.Exclude
+	i.IncludeDependencies = p.IncludeDependencies
+	i.IncludeHosting = p.IncludeHosting
+	i.plan = &p
+	return nil
+}
+
+func (i *inputs) resolveArchive() error {
+	if i.LocalPath != "" {
+		return errArchiveLocalConflict
+	}
+	if i.Watch {
+		return errArchiveWatchConflict
+	}
+
+	app, err := local.LoadAppArchiveConfig(i.Archive)
+	if err != nil {
+		return err
+	}
+
+	if i.RemoteApp == "" {
+		i.RemoteApp = app.Option()
+	}
+	return nil
+}
+
 func (i inputs) resolveRemoteApp(ui terminal.UI, client realm.Client) (appRemote, error) {
 	r := appRemote{GroupID: i.Project}
 
@@ -94,16 +238,52 @@ func (i inputs) resolveRemoteApp(ui terminal.UI, client realm.Client) (appRemote
 	return r, nil
 }
 
+// components returns the app components to push, or nil if the entire app should be pushed
+func (i inputs) components(configVersion realm.AppConfigVersion) []string {
+	if len(i.Include) > 0 {
+		return i.Include
+	}
+	if len(i.Exclude) == 0 {
+		return nil
+	}
+
+	excluded := make(map[string]struct{}, len(i.Exclude))
+	for _, component := range i.Exclude {
+		excluded[component] = struct{}{}
+	}
+
+	supported := local.SupportedAppComponents(configVersion)
+
+	components := make([]string, 0, len(supported))
+	for _, component := range supported {
+		if _, ok := excluded[component]; !ok {
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+func validAppComponents() []interface{} {
+	components := make([]interface{}, 0, len(local.AppComponents))
+	for _, component := range local.AppComponents {
+		components = append(components, component)
+	}
+	return components
+}
+
 func (i inputs) args(omitDryRun bool) []flags.Arg {
-	args := make([]flags.Arg, 0, 7)
+	args := make([]flags.Arg, 0, 16)
 	if i.Project != "" {
-		args = append(args, flags.Arg{flagProject, i.Project})
+		args = append(args, flags.Arg{Name: flagProject, Value: i.Project})
 	}
 	if i.LocalPath != "" {
-		args = append(args, flags.Arg{flagLocalPath, i.LocalPath})
+		args = append(args, flags.Arg{Name: flagLocalPath, Value: i.LocalPath})
+	}
+	if i.Archive != "" {
+		args = append(args, flags.Arg{Name: flagArchive, Value: i.Archive})
 	}
 	if i.RemoteApp != "" {
-		args = append(args, flags.Arg{flagRemote, i.RemoteApp})
+		args = append(args, flags.Arg{Name: flagRemote, Value: i.RemoteApp})
 	}
 	if i.IncludeDependencies {
 		args = append(args, flags.Arg{Name: flagIncludeDependencies})
@@ -114,6 +294,30 @@ func (i inputs) args(omitDryRun bool) []flags.Arg {
 	if i.ResetCDNCache {
 		args = append(args, flags.Arg{Name: flagResetCDNCache})
 	}
+	if i.RollbackOnFailure {
+		args = append(args, flags.Arg{Name: flagRollbackOnFailure})
+	}
+	if i.Watch {
+		args = append(args, flags.Arg{Name: flagWatch})
+	}
+	if i.Environment != "" {
+		args = append(args, flags.Arg{Name: flagEnvironment, Value: i.Environment})
+	}
+	if len(i.Include) > 0 {
+		args = append(args, flags.Arg{Name: flagInclude, Value: strings.Join(i.Include, ",")})
+	}
+	if len(i.Exclude) > 0 {
+		args = append(args, flags.Arg{Name: flagExclude, Value: strings.Join(i.Exclude, ",")})
+	}
+	if i.All {
+		args = append(args, flags.Arg{Name: flagAll})
+	}
+	if i.PlanOut != "" {
+		args = append(args, flags.Arg{Name: flagPlanOut, Value: i.PlanOut})
+	}
+	if i.Plan != "" {
+		args = append(args, flags.Arg{Name: flagPlan, Value: i.Plan})
+	}
 	if i.DryRun && !omitDryRun {
 		args = append(args, flags.Arg{Name: flagDryRun})
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/10gen/realm-cli/internal/cloud/realm"
 	"github.com/10gen/realm-cli/internal/local"
+	u "github.com/10gen/realm-cli/internal/utils/test"
 	"github.com/10gen/realm-cli/internal/utils/test/assert"
 	"github.com/10gen/realm-cli/internal/utils/test/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -21,6 +22,99 @@ func TestPushInputsResolve(t *testing.T) {
 		assert.Equal(t, errProjectNotFound{}, i.Resolve(profile, nil))
 	})
 
+	t.Run("Should return an error if both include and exclude are set", func(t *testing.T) {
+		i := inputs{Include: []string{local.ComponentFunctions}, Exclude: []string{local.ComponentGraphQL}}
+		assert.Equal(t, errIncludeExcludeConflict, i.Resolve(nil, nil))
+	})
+
+	t.Run("Should return an error if both local and archive are set", func(t *testing.T) {
+		i := inputs{LocalPath: "testdata/project", Archive: "app.zip"}
+		assert.Equal(t, errArchiveLocalConflict, i.Resolve(nil, nil))
+	})
+
+	t.Run("Should return an error if both watch and archive are set", func(t *testing.T) {
+		i := inputs{Watch: true, Archive: "app.zip"}
+		assert.Equal(t, errArchiveWatchConflict, i.Resolve(nil, nil))
+	})
+
+	t.Run("Should return an error if all is set along with an app to push", func(t *testing.T) {
+		for _, i := range []inputs{
+			{All: true, LocalPath: "testdata/project"},
+			{All: true, Archive: "app.zip"},
+			{All: true, RemoteApp: "eggcorn-abcde"},
+			{All: true, Watch: true},
+		} {
+			assert.Equal(t, errAllConflict, i.Resolve(nil, nil))
+		}
+	})
+
+	t.Run("Should return an error if plan out is set along with a plan, watch or all", func(t *testing.T) {
+		for _, i := range []inputs{
+			{PlanOut: "plan.json", Plan: "plan.json"},
+			{PlanOut: "plan.json", Watch: true},
+			{PlanOut: "plan.json", All: true},
+		} {
+			assert.Equal(t, errPlanOutConflict, i.Resolve(nil, nil))
+		}
+	})
+
+	t.Run("Should return an error if plan is set along with flags which change the computed changes", func(t *testing.T) {
+		for _, i := range []inputs{
+			{Plan: "plan.json", DryRun: true},
+			{Plan: "plan.json", RemoteApp: "eggcorn-abcde"},
+			{Plan: "plan.json", Environment: "production"},
+			{Plan: "plan.json", IncludeHosting: true},
+			{Plan: "plan.json", Include: []string{local.ComponentFunctions}},
+		} {
+			assert.Equal(t, errPlanConflict, i.Resolve(nil, nil))
+		}
+	})
+
+	t.Run("Should set the inputs which shape the computed changes from the plan", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("push_plan")
+		assert.Nil(t, err)
+		defer teardown()
+
+		planPath := filepath.Join(tmpDir, "plan.json")
+		assert.Nil(t, writePlan(planPath, pushPlan{
+			Version:        planVersion,
+			GroupID:        "groupID",
+			AppID:          "appID",
+			RemoteApp:      "eggcorn-abcde",
+			Environment:    "production",
+			Include:        []string{local.ComponentFunctions},
+			IncludeHosting: true,
+		}))
+
+		i := inputs{LocalPath: "testdata/project", Plan: planPath}
+		assert.Nil(t, i.Resolve(nil, nil))
+
+		assert.Equal(t, "groupID", i.Project)
+		assert.Equal(t, "eggcorn-abcde", i.RemoteApp)
+		assert.Equal(t, "production", i.Environment)
+		assert.Equal(t, []string{local.ComponentFunctions}, i.Include)
+		assert.True(t, i.IncludeHosting, "expected include hosting to be set from the plan")
+	})
+
+	t.Run("Should set the remote app from the app contained in the archive file", func(t *testing.T) {
+		profile, teardown := mock.NewProfileFromTmpDir(t, "app_init_input_test")
+		defer teardown()
+
+		zipPkg, err := u.NewZipReader(map[string]string{
+			local.FileConfig.String(): `{"app_id": "eggcorn-abcde", "name":"eggcorn"}`,
+		})
+		assert.Nil(t, err)
+
+		archivePath := filepath.Join(profile.WorkingDirectory, "app.zip")
+		assert.Nil(t, local.WriteArchive(archivePath, zipPkg))
+
+		i := inputs{Archive: archivePath}
+		assert.Nil(t, i.Resolve(profile, nil))
+
+		assert.Equal(t, "", i.LocalPath)
+		assert.Equal(t, "eggcorn-abcde", i.RemoteApp)
+	})
+
 	t.Run("Should set the app data if no flags are set but is run from inside a project directory", func(t *testing.T) {
 		profile, teardown := mock.NewProfileFromTmpDir(t, "app_init_input_test")
 		defer teardown()
@@ -71,3 +165,43 @@ func TestPushInputsResolveTo(t *testing.T) {
 		assert.Equal(t, realm.AppFilter{GroupID: app.GroupID, App: app.ClientAppID}, appFilter)
 	})
 }
+
+func TestPushInputsComponents(t *testing.T) {
+	t.Run("Should return nil if neither include nor exclude are set", func(t *testing.T) {
+		var i inputs
+		assert.Equal(t, []string(nil), i.components(realm.AppConfigVersion20210101))
+	})
+
+	t.Run("Should return the included components", func(t *testing.T) {
+		i := inputs{Include: []string{local.ComponentFunctions, local.ComponentTriggers}}
+		assert.Equal(t, []string{local.ComponentFunctions, local.ComponentTriggers}, i.components(realm.AppConfigVersion20210101))
+	})
+
+	t.Run("Should return all but the excluded components", func(t *testing.T) {
+		i := inputs{Exclude: []string{local.ComponentGraphQL, local.ComponentHosting, local.ComponentSettings}}
+		assert.Equal(t, []string{
+			local.ComponentAuth,
+			local.ComponentDataSources,
+			local.ComponentEnvironments,
+			local.ComponentFunctions,
+			local.ComponentHTTPEndpoints,
+			local.ComponentServices,
+			local.ComponentSync,
+			local.ComponentTriggers,
+			local.ComponentValues,
+		}, i.components(realm.AppConfigVersion20210101))
+	})
+
+	t.Run("Should return all but the excluded components supported by a v1 app", func(t *testing.T) {
+		i := inputs{Exclude: []string{local.ComponentGraphQL, local.ComponentHosting, local.ComponentSettings}}
+		assert.Equal(t, []string{
+			local.ComponentAuth,
+			local.ComponentEnvironments,
+			local.ComponentFunctions,
+			local.ComponentServices,
+			local.ComponentSync,
+			local.ComponentTriggers,
+			local.ComponentValues,
+		}, i.components(realm.AppConfigVersion20200603))
+	})
+}
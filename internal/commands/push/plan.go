@@ -0,0 +1,93 @@
+package push
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+)
+
+const (
+	planVersion = 1
+)
+
+// pushPlan is the full change set computed for a push, saved to be applied later exactly as it was approved
+type pushPlan struct {
+	Version             int                    `json:"version"`
+	GroupID             string                 `json:"group_id"`
+	AppID               string                 `json:"app_id"`
+	RemoteApp           string                 `json:"remote_app"`
+	Environment         string                 `json:"environment,omitempty"`
+	Include             []string               `json:"include,omitempty"`
+	Exclude             []string               `json:"exclude,omitempty"`
+	IncludeDependencies bool                   `json:"include_dependencies,omitempty"`
+	IncludeHosting      bool                   `json:"include_hosting,omitempty"`
+	LocalHash           string                 `json:"local_hash"`
+	RemoteHash          string                 `json:"remote_hash"`
+	AppDiffs            []string               `json:"app_diffs"`
+	DependenciesDiff    realm.DependenciesDiff `json:"dependencies_diff"`
+	HostingDiffs        local.HostingDiffs     `json:"hosting_diffs"`
+}
+
+// HasChanges returns true if applying the plan changes the app
+func (p pushPlan) HasChanges() bool {
+	return len(p.AppDiffs) > 0 || p.DependenciesDiff.Len() > 0 || p.HostingDiffs.Size() > 0
+}
+
+// Diffs returns every change of the plan as a single list of diffs
+func (p pushPlan) Diffs() []string {
+	diffs := make([]string, 0, len(p.AppDiffs)+p.DependenciesDiff.Len()+p.HostingDiffs.Cap())
+	diffs = append(diffs, p.AppDiffs...)
+	diffs = append(diffs, p.DependenciesDiff.Strings()...)
+	diffs = append(diffs, p.HostingDiffs.Strings()...)
+	return diffs
+}
+
+// verify checks the plan computed now matches the saved plan,
+// meaning neither the local app nor the remote app have changed since it was made
+func (p pushPlan) verify(current pushPlan) error {
+	if p.GroupID != current.GroupID || p.AppID != current.AppID {
+		return errPlanStale{"the remote app resolves to a different app"}
+	}
+	if p.LocalHash != current.LocalHash {
+		return errPlanStale{"the local app has changed"}
+	}
+	if p.RemoteHash != current.RemoteHash {
+		return errPlanStale{"the remote app has changed"}
+	}
+	if !reflect.DeepEqual(p.Diffs(), current.Diffs()) {
+		return errPlanStale{"the computed changes no longer match"}
+	}
+	return nil
+}
+
+func readPlan(path string) (pushPlan, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return pushPlan{}, fmt.Errorf("failed to read plan: %s", err)
+	}
+
+	var p pushPlan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return pushPlan{}, fmt.Errorf("failed to parse plan at %s: %s", path, err)
+	}
+	if p.Version != planVersion {
+		return pushPlan{}, fmt.Errorf("unsupported plan version %d, expected version %d", p.Version, planVersion)
+	}
+	if p.GroupID == "" || p.AppID == "" {
+		return pushPlan{}, fmt.Errorf("plan at %s is missing the app it applies to", path)
+	}
+	return p, nil
+}
+
+func writePlan(path string, p pushPlan) error {
+	data, err := local.MarshalJSON(p)
+	if err != nil {
+		return err
+	}
+	return local.WriteFile(path, 0666, bytes.NewReader(data))
+}
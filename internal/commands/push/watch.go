@@ -0,0 +1,112 @@
+package push
+
+import (
+	"os"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+)
+
+const (
+	watchInterval = time.Second
+	watchDebounce = 500 * time.Millisecond
+)
+
+// watcher configures how the local app directory is polled for changes
+type watcher struct {
+	// interval is how often the app directory is checked for changes
+	interval time.Duration
+
+	// debounce is how long the app directory must go unchanged
+	// before a burst of changes is considered complete
+	debounce time.Duration
+
+	// stop ends the watch once it receives a signal
+	stop <-chan os.Signal
+}
+
+func (cmd *Command) watch(profile *cli.Profile, ui terminal.UI, clients cli.Clients, w watcher) error {
+	// the changes pushed while watching are not confirmed one by one, so confirm them once up front
+	if !ui.AutoConfirm() {
+		proceed, err := ui.Confirm("Changes saved while watching are pushed without confirmation, do you wish to proceed?")
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			return nil
+		}
+	}
+
+	if err := cmd.push(profile, ui, clients); err != nil {
+		return err
+	}
+
+	fingerprint, err := local.Fingerprint(cmd.inputs.LocalPath)
+	if err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Watching for changes to your local app at %s (press Ctrl+C to stop)", cmd.inputs.LocalPath))
+
+	for {
+		stopped, err := w.waitForChanges(cmd.inputs.LocalPath, fingerprint)
+		if err != nil {
+			return err
+		}
+		if stopped {
+			ui.Print(terminal.NewTextLog("Stopped watching for changes"))
+			return nil
+		}
+
+		ui.Print(terminal.NewTextLog("Detected changes to your local app"))
+		if err := cmd.push(profile, ui, clients); err != nil {
+			ui.Print(terminal.NewErrorLog(err))
+		}
+
+		// pushing may write to the app directory (e.g. when creating a new app),
+		// so the fingerprint is refreshed to avoid pushing again for those changes
+		if fingerprint, err = local.Fingerprint(cmd.inputs.LocalPath); err != nil {
+			return err
+		}
+	}
+}
+
+// waitForChanges polls the app directory until its fingerprint changes and then settles,
+// returning early should the watcher be stopped in the meantime
+func (w watcher) waitForChanges(rootDir, fingerprint string) (bool, error) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	current := fingerprint
+	for current == fingerprint {
+		select {
+		case <-w.stop:
+			return true, nil
+		case <-ticker.C:
+		}
+
+		var err error
+		if current, err = local.Fingerprint(rootDir); err != nil {
+			return false, err
+		}
+	}
+
+	for {
+		select {
+		case <-w.stop:
+			return true, nil
+		case <-time.After(w.debounce):
+		}
+
+		settled, err := local.Fingerprint(rootDir)
+		if err != nil {
+			return false, err
+		}
+		if settled == current {
+			return false, nil
+		}
+		current = settled
+	}
+}
@@ -0,0 +1,147 @@
+package push
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestPushCommandWatch(t *testing.T) {
+	setupApp := func(t *testing.T) (string, func()) {
+		tmpDir, teardown, err := u.NewTempDir("push_watch")
+		assert.Nil(t, err)
+
+		app := local.NewApp(tmpDir, "eggcorn-abcde", "eggcorn", realm.LocationVirginia, realm.DeploymentModelGlobal, realm.DefaultAppConfigVersion)
+		assert.Nil(t, app.Write())
+
+		return tmpDir, teardown
+	}
+
+	t.Run("should return an error if the initial push fails", func(t *testing.T) {
+		tmpDir, teardown := setupApp(t)
+		defer teardown()
+
+		var realmClient mock.RealmClient
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return nil, errors.New("something bad happened")
+		}
+
+		cmd := &Command{inputs{LocalPath: tmpDir, RemoteApp: "eggcorn-abcde", Watch: true}}
+
+		ui := mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, new(bytes.Buffer))
+
+		err := cmd.watch(nil, ui, cli.Clients{Realm: realmClient}, watcher{time.Millisecond, time.Millisecond, nil})
+		assert.Equal(t, errors.New("something bad happened"), err)
+	})
+
+	t.Run("should confirm once before pushing and not watch when declined", func(t *testing.T) {
+		tmpDir, teardown := setupApp(t)
+		defer teardown()
+
+		_, console, _, ui, consoleErr := mock.NewVT10XConsole()
+		assert.Nil(t, consoleErr)
+		defer console.Close()
+
+		doneCh := make(chan (struct{}))
+		go func() {
+			defer close(doneCh)
+			console.ExpectString("Changes saved while watching are pushed without confirmation, do you wish to proceed?")
+			console.SendLine("n")
+			console.ExpectEOF()
+		}()
+
+		var realmClient mock.RealmClient
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			t.Fatal("expected no push once the watch is declined")
+			return nil, nil
+		}
+
+		cmd := &Command{inputs{LocalPath: tmpDir, RemoteApp: "eggcorn-abcde", Watch: true}}
+
+		assert.Nil(t, cmd.watch(nil, ui, cli.Clients{Realm: realmClient}, watcher{time.Millisecond, time.Millisecond, nil}))
+
+		console.Tty().Close() // flush the writers
+		<-doneCh              // wait for procedure to complete
+	})
+
+	t.Run("should push again once the local app changes and stop when signaled", func(t *testing.T) {
+		tmpDir, teardown := setupApp(t)
+		defer teardown()
+
+		out := new(bytes.Buffer)
+		watchingCh := make(chan struct{}, 1)
+		ui := watchingUI{mock.NewUIWithOptions(mock.UIOptions{AutoConfirm: true}, out), watchingCh}
+
+		diffCh := make(chan struct{}, 2)
+
+		var realmClient mock.RealmClient
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{{ID: "appID", GroupID: "groupID"}}, nil
+		}
+		realmClient.DiffFn = func(groupID, appID string, appData interface{}) ([]string, error) {
+			diffCh <- struct{}{}
+			return nil, nil
+		}
+		realmClient.ExportFn = exportTestProject
+
+		stop := make(chan os.Signal, 1)
+
+		cmd := &Command{inputs{LocalPath: tmpDir, RemoteApp: "eggcorn-abcde", Watch: true}}
+
+		errCh := make(chan error)
+		go func() {
+			errCh <- cmd.watch(nil, ui, cli.Clients{Realm: realmClient}, watcher{10 * time.Millisecond, 10 * time.Millisecond, stop})
+		}()
+
+		<-diffCh     // wait for the initial push
+		<-watchingCh // wait for the watch to begin
+
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, local.NameFunctions, "test.js"), []byte("exports = () => 1"), 0666))
+
+		select {
+		case <-diffCh:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the local app changes to be pushed")
+		}
+
+		stop <- os.Interrupt
+		assert.Nil(t, <-errCh)
+
+		assert.Equal(t, `Determining changes
+Deployed app is identical to proposed version, nothing to do
+Watching for changes to your local app at `+tmpDir+` (press Ctrl+C to stop)
+Detected changes to your local app
+Determining changes
+Deployed app is identical to proposed version, nothing to do
+Stopped watching for changes
+`, out.String())
+	})
+}
+
+// watchingUI signals once the watch has begun
+type watchingUI struct {
+	terminal.UI
+	watchingCh chan<- struct{}
+}
+
+func (ui watchingUI) Print(logs ...terminal.Log) {
+	ui.UI.Print(logs...)
+	for _, l := range logs {
+		if msg, _ := l.Data.Message(); strings.HasPrefix(msg, "Watching for changes") {
+			ui.watchingCh <- struct{}{}
+		}
+	}
+}
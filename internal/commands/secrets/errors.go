@@ -0,0 +1,15 @@
+package secrets
+
+import (
+	"fmt"
+)
+
+type errImportFailed struct {
+	failed int
+}
+
+func (err errImportFailed) Error() string {
+	return fmt.Sprintf("failed to import %d secret(s)", err.failed)
+}
+
+func (err errImportFailed) DisableUsage() struct{} { return struct{}{} }
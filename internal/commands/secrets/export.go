@@ -0,0 +1,101 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/pflag"
+)
+
+var (
+	errSecretValuesUnavailable = errors.New("secret values cannot be read back from Realm, must export with --names-only")
+)
+
+// CommandExport is the `secrets export` command
+type CommandExport struct {
+	inputs exportInputs
+}
+
+type exportInputs struct {
+	cli.ProjectInputs
+	File      string
+	NamesOnly bool
+}
+
+// Flags is the command flags
+func (cmd *CommandExport) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+
+	fs.StringVar(&cmd.inputs.File, flagFile, "", flagFileUsageExport)
+	fs.BoolVar(&cmd.inputs.NamesOnly, flagNamesOnly, false, flagNamesOnlyUsage)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandExport) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandExport) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	secrets, err := clients.Realm.Secrets(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(secrets) == 0 {
+		ui.Print(terminal.NewTextLog("No available secrets to export"))
+		return nil
+	}
+
+	names := make([]string, len(secrets))
+	for i, secret := range secrets {
+		names[i] = secret.Name
+	}
+
+	template, err := formatSecretsTemplate(cmd.inputs.File, names)
+	if err != nil {
+		return err
+	}
+
+	if cmd.inputs.File == "" {
+		ui.Print(terminal.NewTextLog("%s", strings.TrimSuffix(string(template), "\n")))
+		return nil
+	}
+
+	if err := local.WriteFile(cmd.inputs.File, 0600, bytes.NewReader(template)); err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully exported %d secret name(s) to %s", len(names), cmd.inputs.File))
+	return nil
+}
+
+func (i *exportInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if !i.NamesOnly {
+		return errSecretValuesUnavailable
+	}
+
+	if err := i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false); err != nil {
+		return err
+	}
+
+	if i.File != "" {
+		file, err := homedir.Expand(i.File)
+		if err != nil {
+			return err
+		}
+		i.File = file
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package secrets
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestSecretsExportHandler(t *testing.T) {
+	realmClient := mock.RealmClient{}
+	realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+		return []realm.App{{ID: "appID", GroupID: "projectID"}}, nil
+	}
+	realmClient.SecretsFn = func(groupID, appID string) ([]realm.Secret, error) {
+		return []realm.Secret{{ID: "secret1", Name: "DB_USER"}, {ID: "secret2", Name: "API_KEY"}}, nil
+	}
+
+	t.Run("should print the secrets template when no file is set", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		cmd := &CommandExport{exportInputs{NamesOnly: true}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+		assert.Equal(t, "API_KEY=\nDB_USER=\n", out.String())
+	})
+
+	t.Run("should write the secrets template to the file", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("secrets_export")
+		assert.Nil(t, err)
+		defer teardown()
+
+		file := filepath.Join(tmpDir, "secrets.json")
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandExport{exportInputs{File: file, NamesOnly: true}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+		assert.Equal(t, "Successfully exported 2 secret name(s) to "+file+"\n", out.String())
+
+		data, err := ioutil.ReadFile(file)
+		assert.Nil(t, err)
+		assert.Equal(t, "{\n    \"API_KEY\": \"\",\n    \"DB_USER\": \"\"\n}\n", string(data))
+	})
+}
+
+func TestSecretsExportInputs(t *testing.T) {
+	t.Run("should return an error when names only is not set", func(t *testing.T) {
+		inputs := exportInputs{}
+		assert.Equal(t, errSecretValuesUnavailable, inputs.Resolve(nil, nil))
+	})
+}
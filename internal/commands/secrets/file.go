@@ -0,0 +1,110 @@
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/local"
+)
+
+const (
+	extJSON = ".json"
+)
+
+// parseSecretsFile reads the secrets from either a .json file of names to values
+// or a dotenv file of NAME=value lines
+func parseSecretsFile(path string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secrets file: %s", err)
+	}
+
+	if strings.ToLower(filepath.Ext(path)) == extJSON {
+		return parseSecretsJSON(path, data)
+	}
+	return parseSecretsDotenv(path, data)
+}
+
+func parseSecretsJSON(path string, data []byte) (map[string]string, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets file at %s: %s", path, err)
+	}
+
+	secrets := make(map[string]string, len(raw))
+	for name, value := range raw {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse secrets file at %s: secret '%s' must have a string value", path, name)
+		}
+		secrets[name] = s
+	}
+	return secrets, nil
+}
+
+func parseSecretsDotenv(path string, data []byte) (map[string]string, error) {
+	secrets := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		idx := strings.Index(line, "=")
+		if idx < 1 {
+			return nil, fmt.Errorf("failed to parse secrets file at %s: invalid line %d", path, n)
+		}
+
+		name := strings.TrimSpace(line[:idx])
+		value := strings.TrimSpace(line[idx+1:])
+
+		switch {
+		case len(value) > 1 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`):
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse secrets file at %s: invalid value on line %d", path, n)
+			}
+			value = unquoted
+		case len(value) > 1 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'"):
+			value = value[1 : len(value)-1]
+		}
+
+		secrets[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets file at %s: %s", path, err)
+	}
+	return secrets, nil
+}
+
+// formatSecretsTemplate creates a secrets file of the names with empty values,
+// formatted as .json or dotenv based on the file extension
+func formatSecretsTemplate(path string, names []string) ([]byte, error) {
+	sorted := make([]string, len(names))
+	copy(sorted, names)
+	sort.Strings(sorted)
+
+	if strings.ToLower(filepath.Ext(path)) == extJSON {
+		template := make(map[string]string, len(sorted))
+		for _, name := range sorted {
+			template[name] = ""
+		}
+		return local.MarshalJSON(template)
+	}
+
+	var buf bytes.Buffer
+	for _, name := range sorted {
+		buf.WriteString(name + "=\n")
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,83 @@
+package secrets
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestParseSecretsFile(t *testing.T) {
+	tmpDir, teardown, err := u.NewTempDir("secrets_file")
+	assert.Nil(t, err)
+	defer teardown()
+
+	writeFile := func(t *testing.T, name, contents string) string {
+		t.Helper()
+		path := filepath.Join(tmpDir, name)
+		assert.Nil(t, ioutil.WriteFile(path, []byte(contents), 0600))
+		return path
+	}
+
+	t.Run("should parse a dotenv file", func(t *testing.T) {
+		path := writeFile(t, ".env", `# database credentials
+DB_USER=eggcorn
+export DB_PASSWORD="p@ss=word\n"
+
+API_KEY='abc 123'
+EMPTY=
+`)
+
+		secrets, err := parseSecretsFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{
+			"DB_USER":     "eggcorn",
+			"DB_PASSWORD": "p@ss=word\n",
+			"API_KEY":     "abc 123",
+			"EMPTY":       "",
+		}, secrets)
+	})
+
+	t.Run("should parse a json file", func(t *testing.T) {
+		path := writeFile(t, "secrets.json", `{"DB_USER":"eggcorn","API_KEY":"abc"}`)
+
+		secrets, err := parseSecretsFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"DB_USER": "eggcorn", "API_KEY": "abc"}, secrets)
+	})
+
+	t.Run("should return an error for an invalid dotenv line", func(t *testing.T) {
+		path := writeFile(t, "invalid.env", "DB_USER=eggcorn\nDB_PASSWORD\n")
+
+		_, err := parseSecretsFile(path)
+		assert.Equal(t, errors.New("failed to parse secrets file at "+path+": invalid line 2"), err)
+	})
+
+	t.Run("should return an error for a json secret without a string value", func(t *testing.T) {
+		path := writeFile(t, "invalid.json", `{"PORT":8080}`)
+
+		_, err := parseSecretsFile(path)
+		assert.Equal(t, errors.New("failed to parse secrets file at "+path+": secret 'PORT' must have a string value"), err)
+	})
+}
+
+func TestFormatSecretsTemplate(t *testing.T) {
+	t.Run("should format a dotenv template", func(t *testing.T) {
+		template, err := formatSecretsTemplate(".env", []string{"B", "A"})
+		assert.Nil(t, err)
+		assert.Equal(t, "A=\nB=\n", string(template))
+	})
+
+	t.Run("should format a json template", func(t *testing.T) {
+		template, err := formatSecretsTemplate("secrets.json", []string{"B", "A"})
+		assert.Nil(t, err)
+		assert.Equal(t, `{
+    "A": "",
+    "B": ""
+}
+`, string(template))
+	})
+}
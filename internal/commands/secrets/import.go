@@ -0,0 +1,170 @@
+package secrets
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/spf13/pflag"
+)
+
+// set of secret import actions
+const (
+	importActionCreate = "create"
+	importActionUpdate = "update"
+	importActionSkip   = "skip"
+)
+
+const (
+	headerAction = "Action"
+)
+
+// CommandImport is the `secrets import` command
+type CommandImport struct {
+	inputs importInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandImport) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+
+	fs.StringVar(&cmd.inputs.File, flagFile, "", flagFileUsageImport)
+	fs.BoolVar(&cmd.inputs.Overwrite, flagOverwrite, false, flagOverwriteUsage)
+	fs.BoolVarP(&cmd.inputs.DryRun, flagDryRun, flagDryRunShort, false, flagDryRunUsage)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandImport) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandImport) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	fileSecrets, err := parseSecretsFile(cmd.inputs.File)
+	if err != nil {
+		return err
+	}
+
+	if len(fileSecrets) == 0 {
+		ui.Print(terminal.NewTextLog("No secrets to import"))
+		return nil
+	}
+
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	secrets, err := clients.Realm.Secrets(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	imports := planSecretImports(fileSecrets, secrets, cmd.inputs.Overwrite)
+
+	if cmd.inputs.DryRun {
+		ui.Print(
+			terminal.NewTableLog(
+				fmt.Sprintf("Found %d secret(s) to import", len(imports)),
+				tableHeaders(headerAction, headerDetails),
+				secretImportRows(imports)...,
+			),
+			terminal.NewTextLog("To import these secrets, you must omit the 'dry-run' flag to proceed"),
+		)
+		return nil
+	}
+
+	var imported, failed int
+	for i, imp := range imports {
+		switch imp.action {
+		case importActionCreate:
+			secret, err := clients.Realm.CreateSecret(app.GroupID, app.ID, imp.secret.Name, imp.value)
+			if err == nil {
+				imports[i].secret = secret
+			}
+			imports[i].err = err
+		case importActionUpdate:
+			imports[i].err = clients.Realm.UpdateSecret(app.GroupID, app.ID, imp.secret.ID, imp.secret.Name, imp.value)
+		default:
+			continue
+		}
+
+		if imports[i].err != nil {
+			failed++
+		} else {
+			imported++
+		}
+	}
+
+	sort.SliceStable(imports, func(i, j int) bool {
+		return imports[i].err != nil && imports[j].err == nil
+	})
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Imported %d secret(s)", imported),
+		tableHeaders(headerAction, headerDetails),
+		secretImportRows(imports)...,
+	))
+
+	if failed > 0 {
+		return errImportFailed{failed}
+	}
+	return nil
+}
+
+type secretImport struct {
+	secret realm.Secret
+	value  string
+	action string
+	err    error
+}
+
+// planSecretImports diffs the secrets from the file against those of the app,
+// determining whether each secret should be created, updated or skipped
+func planSecretImports(fileSecrets map[string]string, appSecrets []realm.Secret, overwrite bool) []secretImport {
+	secretsByName := make(map[string]realm.Secret, len(appSecrets))
+	for _, secret := range appSecrets {
+		secretsByName[secret.Name] = secret
+	}
+
+	names := make([]string, 0, len(fileSecrets))
+	for name := range fileSecrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	imports := make([]secretImport, 0, len(names))
+	for _, name := range names {
+		imp := secretImport{secret: realm.Secret{Name: name}, value: fileSecrets[name], action: importActionCreate}
+
+		if secret, ok := secretsByName[name]; ok {
+			imp.secret = secret
+			imp.action = importActionSkip
+			if overwrite {
+				imp.action = importActionUpdate
+			}
+		}
+
+		imports = append(imports, imp)
+	}
+	return imports
+}
+
+func secretImportRows(imports []secretImport) []map[string]interface{} {
+	rows := make([]map[string]interface{}, 0, len(imports))
+	for _, imp := range imports {
+		row := tableRow(secretOutput{imp.secret, imp.err}, func(output secretOutput, row map[string]interface{}) {
+			row[headerAction] = imp.action
+			if output.err != nil {
+				row[headerDetails] = output.err.Error()
+			} else if imp.action == importActionSkip {
+				row[headerDetails] = "already exists, include --overwrite to update"
+			}
+		})
+		rows = append(rows, row)
+	}
+	return rows
+}
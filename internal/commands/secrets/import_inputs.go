@@ -0,0 +1,35 @@
+package secrets
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/mitchellh/go-homedir"
+)
+
+type importInputs struct {
+	cli.ProjectInputs
+	File      string
+	Overwrite bool
+	DryRun    bool
+}
+
+func (i *importInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if err := i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false); err != nil {
+		return err
+	}
+
+	if i.File == "" {
+		if err := ui.AskOne(&i.File, &survey.Input{Message: "Secrets File"}); err != nil {
+			return err
+		}
+	}
+
+	file, err := homedir.Expand(i.File)
+	if err != nil {
+		return err
+	}
+	i.File = file
+	return nil
+}
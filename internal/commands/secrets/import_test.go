@@ -0,0 +1,142 @@
+package secrets
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestSecretsImportHandler(t *testing.T) {
+	app := realm.App{
+		ID:          "appID",
+		GroupID:     "projectID",
+		ClientAppID: "eggcorn-abcde",
+		Name:        "eggcorn",
+	}
+
+	tmpDir, teardown, err := u.NewTempDir("secrets_import")
+	assert.Nil(t, err)
+	defer teardown()
+
+	file := filepath.Join(tmpDir, ".env")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("existing=new\nmissing=value\n"), 0600))
+
+	setupClient := func(created, updated map[string]string) mock.RealmClient {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		realmClient.SecretsFn = func(groupID, appID string) ([]realm.Secret, error) {
+			return []realm.Secret{{ID: "secret1", Name: "existing"}, {ID: "secret2", Name: "untouched"}}, nil
+		}
+		realmClient.CreateSecretFn = func(groupID, appID, name, value string) (realm.Secret, error) {
+			created[name] = value
+			return realm.Secret{ID: "secret3", Name: name}, nil
+		}
+		realmClient.UpdateSecretFn = func(groupID, appID, secretID, name, value string) error {
+			updated[secretID] = name + "=" + value
+			return nil
+		}
+		return realmClient
+	}
+
+	t.Run("should create the missing secrets and skip the existing ones", func(t *testing.T) {
+		created, updated := map[string]string{}, map[string]string{}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandImport{importInputs{File: file}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: setupClient(created, updated)}))
+
+		assert.Equal(t, strings.Join([]string{
+			"Imported 1 secret(s)",
+			"  ID       Name      Action  Details                                      ",
+			"  -------  --------  ------  ---------------------------------------------",
+			"  secret1  existing  skip    already exists, include --overwrite to update",
+			"  secret3  missing   create                                               ",
+			"",
+		}, "\n"), out.String())
+		assert.Equal(t, map[string]string{"missing": "value"}, created)
+		assert.Equal(t, map[string]string{}, updated)
+	})
+
+	t.Run("should update the existing secrets with overwrite set", func(t *testing.T) {
+		created, updated := map[string]string{}, map[string]string{}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandImport{importInputs{File: file, Overwrite: true}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: setupClient(created, updated)}))
+
+		assert.Equal(t, strings.Join([]string{
+			"Imported 2 secret(s)",
+			"  ID       Name      Action  Details",
+			"  -------  --------  ------  -------",
+			"  secret1  existing  update         ",
+			"  secret3  missing   create         ",
+			"",
+		}, "\n"), out.String())
+		assert.Equal(t, map[string]string{"missing": "value"}, created)
+		assert.Equal(t, map[string]string{"secret1": "existing=new"}, updated)
+	})
+
+	t.Run("should only report the changes in a dry run", func(t *testing.T) {
+		created, updated := map[string]string{}, map[string]string{}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandImport{importInputs{File: file, Overwrite: true, DryRun: true}}
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: setupClient(created, updated)}))
+
+		assert.Equal(t, strings.Join([]string{
+			"Found 2 secret(s) to import",
+			"  ID       Name      Action  Details",
+			"  -------  --------  ------  -------",
+			"  secret1  existing  update         ",
+			"           missing   create         ",
+			"To import these secrets, you must omit the 'dry-run' flag to proceed",
+			"",
+		}, "\n"), out.String())
+		assert.Equal(t, 0, len(created))
+		assert.Equal(t, 0, len(updated))
+	})
+
+	t.Run("should report the secrets which fail to import first and return an error", func(t *testing.T) {
+		realmClient := setupClient(map[string]string{}, map[string]string{})
+		realmClient.CreateSecretFn = func(groupID, appID, name, value string) (realm.Secret, error) {
+			return realm.Secret{}, errors.New("something bad happened")
+		}
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandImport{importInputs{File: file, Overwrite: true}}
+
+		err := cmd.Handler(nil, ui, cli.Clients{Realm: realmClient})
+		assert.Equal(t, errImportFailed{1}, err)
+		assert.Equal(t, "failed to import 1 secret(s)", err.Error())
+
+		assert.Equal(t, strings.Join([]string{
+			"Imported 1 secret(s)",
+			"  ID       Name      Action  Details               ",
+			"  -------  --------  ------  ----------------------",
+			"           missing   create  something bad happened",
+			"  secret1  existing  update                        ",
+			"",
+		}, "\n"), out.String())
+	})
+
+	t.Run("should return an error when the file cannot be read", func(t *testing.T) {
+		cmd := &CommandImport{importInputs{File: filepath.Join(tmpDir, "missing.env")}}
+
+		err := cmd.Handler(nil, nil, cli.Clients{})
+		assert.True(t, strings.HasPrefix(err.Error(), "failed to read secrets file: "), "unexpected error: %s", err)
+	})
+}
@@ -16,4 +16,18 @@ const (
 	flagSecretShort       = "s"
 	flagSecretUsageUpdate = "the name or id of the secret to update"
 	flagSecretUsageDelete = "the name or id of the secret to delete"
+
+	flagFile            = "file"
+	flagFileUsageImport = "the .env or .json file of secrets to import"
+	flagFileUsageExport = "the .env or .json file to export the secrets template to; prints the template if omitted"
+
+	flagOverwrite      = "overwrite"
+	flagOverwriteUsage = "include to update the secrets which already exist in your Realm app"
+
+	flagDryRun      = "dry-run"
+	flagDryRunShort = "x"
+	flagDryRunUsage = "include to show the changes without importing any secrets"
+
+	flagNamesOnly      = "names-only"
+	flagNamesOnlyUsage = "include to export the secret names with empty values as a template"
 )
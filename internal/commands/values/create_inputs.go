@@ -0,0 +1,60 @@
+package values
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+const (
+	createInputFieldValueName  = "name"
+	createInputFieldValueValue = "value"
+)
+
+type createInputs struct {
+	cli.ProjectInputs
+	Name    string
+	Value   string
+	Secret  string
+	Private bool
+}
+
+func (i *createInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if i.Value != "" && i.Secret != "" {
+		return errValueSecretConflict
+	}
+
+	if err := i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false); err != nil {
+		return err
+	}
+
+	var questions []*survey.Question
+
+	if i.Name == "" {
+		questions = append(questions, &survey.Question{
+			Name:   createInputFieldValueName,
+			Prompt: &survey.Input{Message: "Value Name"},
+		})
+	}
+
+	if i.Value == "" && i.Secret == "" {
+		questions = append(questions, &survey.Question{
+			Name:   createInputFieldValueValue,
+			Prompt: &survey.Input{Message: "Value"},
+		})
+	}
+
+	if len(questions) > 0 {
+		return ui.Ask(i, questions...)
+	}
+	return nil
+}
+
+func (i createInputs) value() realm.Value {
+	if i.Secret != "" {
+		return realm.Value{Name: i.Name, Private: i.Private, FromSecret: true, Value: i.Secret}
+	}
+	return realm.Value{Name: i.Name, Private: i.Private, Value: parseValue(i.Value)}
+}
@@ -0,0 +1,147 @@
+package values
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+
+	"github.com/Netflix/go-expect"
+)
+
+func TestValuesCreateHandler(t *testing.T) {
+	app := realm.App{
+		ID:          "appID",
+		GroupID:     "projectID",
+		ClientAppID: "eggcorn-abcde",
+		Name:        "eggcorn",
+	}
+
+	for _, tc := range []struct {
+		description   string
+		inputs        createInputs
+		expectedValue realm.Value
+	}{
+		{
+			description:   "should create a value parsed as json",
+			inputs:        createInputs{Name: "name", Value: `{"eggcorn":[1,2]}`},
+			expectedValue: realm.Value{Name: "name", Value: map[string]interface{}{"eggcorn": []interface{}{float64(1), float64(2)}}},
+		},
+		{
+			description:   "should create a value as a string when it is not json",
+			inputs:        createInputs{Name: "name", Value: "eggcorn", Private: true},
+			expectedValue: realm.Value{Name: "name", Private: true, Value: "eggcorn"},
+		},
+		{
+			description:   "should create a value backed by a secret",
+			inputs:        createInputs{Name: "name", Secret: "mySecret"},
+			expectedValue: realm.Value{Name: "name", FromSecret: true, Value: "mySecret"},
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			out, ui := mock.NewUI()
+
+			realmClient := mock.RealmClient{}
+			realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+				return []realm.App{app}, nil
+			}
+
+			var capturedGroupID, capturedAppID string
+			var capturedValue realm.Value
+			realmClient.CreateValueFn = func(groupID, appID string, value realm.Value) (realm.Value, error) {
+				capturedGroupID = groupID
+				capturedAppID = appID
+				capturedValue = value
+				value.ID = "valueID"
+				return value, nil
+			}
+
+			cmd := &CommandCreate{tc.inputs}
+
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+			assert.Equal(t, "Successfully created value, id: valueID\n", out.String())
+
+			assert.Equal(t, "projectID", capturedGroupID)
+			assert.Equal(t, "appID", capturedAppID)
+			assert.Equal(t, tc.expectedValue, capturedValue)
+		})
+	}
+
+	t.Run("should return an error when creating a value fails", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		realmClient.CreateValueFn = func(groupID, appID string, value realm.Value) (realm.Value, error) {
+			return realm.Value{}, errors.New("something bad happened")
+		}
+
+		cmd := &CommandCreate{}
+
+		err := cmd.Handler(nil, nil, cli.Clients{Realm: realmClient})
+		assert.Equal(t, errors.New("something bad happened"), err)
+	})
+}
+
+func TestValuesCreateInputs(t *testing.T) {
+	t.Run("should return an error when both a value and a secret are set", func(t *testing.T) {
+		inputs := createInputs{Value: "value", Secret: "secret"}
+		assert.Equal(t, errValueSecretConflict, inputs.Resolve(nil, nil))
+	})
+
+	for _, tc := range []struct {
+		description string
+		inputs      createInputs
+		procedure   func(c *expect.Console)
+		test        func(t *testing.T, i createInputs)
+	}{
+		{
+			description: "should prompt for the name and value when not provided",
+			procedure: func(c *expect.Console) {
+				c.ExpectString("Value Name")
+				c.SendLine("name")
+				c.ExpectString("Value")
+				c.SendLine("value")
+				c.ExpectEOF()
+			},
+			test: func(t *testing.T, i createInputs) {
+				assert.Equal(t, "name", i.Name)
+				assert.Equal(t, "value", i.Value)
+			},
+		},
+		{
+			description: "should not prompt for the value when backed by a secret",
+			inputs:      createInputs{Name: "name", Secret: "secret"},
+			procedure:   func(c *expect.Console) {},
+			test: func(t *testing.T, i createInputs) {
+				assert.Equal(t, "", i.Value)
+				assert.Equal(t, "secret", i.Secret)
+			},
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			_, console, _, ui, consoleErr := mock.NewVT10XConsole()
+			assert.Nil(t, consoleErr)
+			defer console.Close()
+
+			profile := mock.NewProfile(t)
+
+			doneCh := make(chan (struct{}))
+			go func() {
+				defer close(doneCh)
+				tc.procedure(console)
+			}()
+
+			tc.inputs.App = "appID"
+			assert.Nil(t, tc.inputs.Resolve(profile, ui))
+
+			console.Tty().Close() // flush the writers
+			<-doneCh              // wait for procedure to complete
+
+			tc.test(t, tc.inputs)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package values
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/spf13/pflag"
+)
+
+// CommandDelete is the `values delete` command
+type CommandDelete struct {
+	inputs deleteInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandDelete) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+	fs.StringSliceVarP(&cmd.inputs.values, flagName, flagNameShort, []string{}, flagNameUsageDelete)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandDelete) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandDelete) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	values, err := clients.Realm.Values(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	selected, err := cmd.inputs.resolveValues(ui, values)
+	if err != nil {
+		return err
+	}
+
+	if len(selected) == 0 {
+		ui.Print(terminal.NewTextLog("No values to delete"))
+		return nil
+	}
+
+	outputs := make(valueOutputs, len(selected))
+	for i, value := range selected {
+		err := clients.Realm.DeleteValue(app.GroupID, app.ID, value.ID)
+		outputs[i] = valueOutput{value, err}
+	}
+
+	sort.SliceStable(outputs, func(i, j int) bool {
+		return outputs[i].err != nil && outputs[j].err == nil
+	})
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Deleted %d value(s)", len(outputs)),
+		tableHeaders(headerDeleted, headerDetails),
+		tableRows(outputs, tableRowDelete)...,
+	))
+	return nil
+}
+
+func tableRowDelete(output valueOutput, row map[string]interface{}) {
+	deleted := false
+	if output.err != nil {
+		row[headerDetails] = output.err.Error()
+	} else {
+		deleted = true
+	}
+	row[headerDeleted] = deleted
+}
@@ -0,0 +1,66 @@
+package values
+
+import (
+	"errors"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+type deleteInputs struct {
+	cli.ProjectInputs
+	values []string
+}
+
+func (i *deleteInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
+
+func (i *deleteInputs) resolveValues(ui terminal.UI, appValues []realm.Value) ([]realm.Value, error) {
+	if len(appValues) == 0 {
+		return nil, nil
+	}
+
+	if len(i.values) > 0 {
+		values := make([]realm.Value, 0, len(i.values))
+		for _, identifier := range i.values {
+			if value, ok := findValue(appValues, identifier); ok {
+				values = append(values, value)
+			}
+		}
+
+		if len(values) == 0 {
+			return nil, errors.New("unable to find values")
+		}
+		return values, nil
+	}
+
+	options := make([]string, 0, len(appValues))
+	valuesByOption := map[string]realm.Value{}
+	for _, value := range appValues {
+		option := displayValueOption(value)
+
+		options = append(options, option)
+		valuesByOption[option] = value
+	}
+
+	var selections []string
+	if err := ui.AskOne(
+		&selections,
+		&survey.MultiSelect{
+			Message: "Which value(s) would you like to delete?",
+			Options: options,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	values := make([]realm.Value, 0, len(selections))
+	for _, selection := range selections {
+		values = append(values, valuesByOption[selection])
+	}
+	return values, nil
+}
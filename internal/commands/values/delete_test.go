@@ -0,0 +1,110 @@
+package values
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestValuesDeleteHandler(t *testing.T) {
+	app := realm.App{
+		ID:          "appID",
+		GroupID:     "projectID",
+		ClientAppID: "eggcorn-abcde",
+		Name:        "eggcorn",
+	}
+	testValues := []realm.Value{
+		{ID: "value1", Name: "one"},
+		{ID: "value2", Name: "two"},
+		{ID: "value3", Name: "three"},
+	}
+
+	t.Run("should show empty state message if no values are found", func(t *testing.T) {
+		out, ui := mock.NewUI()
+
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		realmClient.ValuesFn = func(groupID, appID string) ([]realm.Value, error) {
+			return nil, nil
+		}
+
+		cmd := &CommandDelete{}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+		assert.Equal(t, "No values to delete\n", out.String())
+	})
+
+	for _, tc := range []struct {
+		description    string
+		values         []string
+		deleteErr      error
+		expectedOutput string
+	}{
+		{
+			description: "should delete the values found by name or id",
+			values:      []string{"one", "value3"},
+			expectedOutput: strings.Join([]string{
+				"Deleted 2 value(s)",
+				"  ID      Name   Deleted  Details",
+				"  ------  -----  -------  -------",
+				"  value1  one    true            ",
+				"  value3  three  true            ",
+				"",
+			}, "\n"),
+		},
+		{
+			description: "should still output the errors for deletes on individual values",
+			values:      []string{"two"},
+			deleteErr:   errors.New("something happened"),
+			expectedOutput: strings.Join([]string{
+				"Deleted 1 value(s)",
+				"  ID      Name  Deleted  Details           ",
+				"  ------  ----  -------  ------------------",
+				"  value2  two   false    something happened",
+				"",
+			}, "\n"),
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			out, ui := mock.NewUI()
+
+			realmClient := mock.RealmClient{}
+			realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+				return []realm.App{app}, nil
+			}
+			realmClient.ValuesFn = func(groupID, appID string) ([]realm.Value, error) {
+				return testValues, nil
+			}
+			realmClient.DeleteValueFn = func(groupID, appID, valueID string) error {
+				return tc.deleteErr
+			}
+
+			cmd := &CommandDelete{deleteInputs{values: tc.values}}
+
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+			assert.Equal(t, tc.expectedOutput, out.String())
+		})
+	}
+
+	t.Run("should return an error when none of the values can be found", func(t *testing.T) {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		realmClient.ValuesFn = func(groupID, appID string) ([]realm.Value, error) {
+			return testValues, nil
+		}
+
+		cmd := &CommandDelete{deleteInputs{values: []string{"missing"}}}
+
+		err := cmd.Handler(nil, nil, cli.Clients{Realm: realmClient})
+		assert.Equal(t, errors.New("unable to find values"), err)
+	})
+}
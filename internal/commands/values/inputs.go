@@ -0,0 +1,80 @@
+package values
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/local"
+)
+
+// Flag names and usages across the values commands
+const (
+	flagName            = "name"
+	flagNameShort       = "n"
+	flagNameUsageCreate = "the name of the value"
+	flagNameUsageUpdate = "the name or id of the value to update"
+	flagNameUsageDelete = "the name or id of the value to delete"
+	flagNameUsagePull   = "the name of the value to pull"
+	flagNameUsagePush   = "the name of the value to push"
+
+	flagValue            = "value"
+	flagValueShort       = "v"
+	flagValueUsageCreate = "the value, parsed as JSON if possible"
+	flagValueUsageUpdate = "the new value, parsed as JSON if possible"
+
+	flagSecret            = "secret"
+	flagSecretShort       = "s"
+	flagSecretUsageCreate = "the name of the secret to back the value with"
+	flagSecretUsageUpdate = "the name of the secret to back the value with instead"
+
+	flagPrivate      = "private"
+	flagPrivateUsage = "include to hide the value from client applications"
+
+	flagLocalPath      = "local"
+	flagLocalPathUsage = "the local path to your Realm app"
+)
+
+var (
+	errValueSecretConflict = errors.New("cannot use both --value and --secret flags")
+)
+
+// parseValue parses the raw value as JSON, falling back to the raw string
+func parseValue(raw string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return raw
+	}
+	return value
+}
+
+// resolveLocalApp finds the root directory of the local app at or above the path
+func resolveLocalApp(path string) (string, error) {
+	app, err := local.LoadAppConfig(path)
+	if err != nil {
+		return "", err
+	}
+	if app.RootDir == "" {
+		return "", errProjectNotFound{}
+	}
+	return app.RootDir, nil
+}
+
+type localInputs struct {
+	cli.ProjectInputs
+	LocalPath string
+	Name      string
+}
+
+func (i *localInputs) resolve(profile *cli.Profile) error {
+	if i.LocalPath == "" {
+		i.LocalPath = profile.WorkingDirectory
+	}
+
+	rootDir, err := resolveLocalApp(i.LocalPath)
+	if err != nil {
+		return err
+	}
+	i.LocalPath = rootDir
+	return nil
+}
@@ -0,0 +1,73 @@
+package values
+
+import (
+	"fmt"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/spf13/pflag"
+)
+
+// CommandList is the `values list` command
+type CommandList struct {
+	inputs listInputs
+}
+
+type listInputs struct {
+	cli.ProjectInputs
+}
+
+// Flags are the command flags
+func (cmd *CommandList) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+}
+
+// Inputs are the command inputs
+func (cmd *CommandList) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandList) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	values, err := clients.Realm.Values(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(values) == 0 {
+		ui.Print(terminal.NewTextLog("No available values to show"))
+		return nil
+	}
+
+	// the values listing omits their contents, so each value must be fetched on its own
+	outputs := make(valueOutputs, len(values))
+	for i, value := range values {
+		found, err := clients.Realm.Value(app.GroupID, app.ID, value.ID)
+		if err != nil {
+			return err
+		}
+		outputs[i] = valueOutput{value: found}
+	}
+
+	ui.Print(terminal.NewTableLog(
+		fmt.Sprintf("Found %d values", len(outputs)),
+		tableHeaders(headerPrivate, headerValue),
+		tableRows(outputs, tableRowList)...,
+	))
+	return nil
+}
+
+func tableRowList(output valueOutput, row map[string]interface{}) {
+	row[headerPrivate] = output.value.Private
+	row[headerValue] = displayValue(output.value)
+}
+
+func (i *listInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
@@ -0,0 +1,145 @@
+package values
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestValuesListHandler(t *testing.T) {
+	projectID := "projectID"
+	appID := "appID"
+	app := realm.App{
+		ID:          appID,
+		GroupID:     projectID,
+		ClientAppID: "eggcorn-abcde",
+		Name:        "eggcorn",
+	}
+	testValues := []realm.Value{
+		{ID: "value1", Name: "string", Value: "eggcorn"},
+		{ID: "value2", Name: "document", Private: true, Value: map[string]interface{}{"eggcorn": true}},
+		{ID: "value3", Name: "linked", FromSecret: true, Value: "mySecret"},
+	}
+
+	for _, tc := range []struct {
+		description    string
+		values         []realm.Value
+		expectedOutput string
+	}{
+		{
+			description:    "should list no values with no app values found",
+			expectedOutput: "No available values to show\n",
+		},
+		{
+			description: "should list the values found for the app with secret backed values linked to their secrets",
+			values:      testValues,
+			expectedOutput: strings.Join(
+				[]string{
+					"Found 3 values",
+					"  ID      Name      Private  Value           ",
+					"  ------  --------  -------  ----------------",
+					"  value1  string    false    \"eggcorn\"       ",
+					"  value2  document  true     {\"eggcorn\":true}",
+					"  value3  linked    false    Secret: mySecret",
+					"",
+				},
+				"\n",
+			),
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			out, ui := mock.NewUI()
+
+			realmClient := mock.RealmClient{}
+			realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+				return []realm.App{app}, nil
+			}
+			realmClient.ValuesFn = func(groupID, appID string) ([]realm.Value, error) {
+				values := make([]realm.Value, len(tc.values))
+				for i, value := range tc.values {
+					values[i] = realm.Value{ID: value.ID, Name: value.Name, Private: value.Private, FromSecret: value.FromSecret}
+				}
+				return values, nil
+			}
+			realmClient.ValueFn = func(groupID, appID, valueID string) (realm.Value, error) {
+				for _, value := range tc.values {
+					if value.ID == valueID {
+						return value, nil
+					}
+				}
+				return realm.Value{}, errors.New("value not found")
+			}
+
+			cmd := &CommandList{listInputs{cli.ProjectInputs{
+				Project: projectID,
+				App:     appID,
+			}}}
+
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+			assert.Equal(t, tc.expectedOutput, out.String())
+		})
+	}
+
+	t.Run("should return an error", func(t *testing.T) {
+		for _, tc := range []struct {
+			description string
+			setupClient func() realm.Client
+			expectedErr error
+		}{
+			{
+				description: "when resolving the app fails",
+				setupClient: func() realm.Client {
+					realmClient := mock.RealmClient{}
+					realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+						return nil, errors.New("something bad happened")
+					}
+					return realmClient
+				},
+				expectedErr: errors.New("something bad happened"),
+			},
+			{
+				description: "when finding the values fails",
+				setupClient: func() realm.Client {
+					realmClient := mock.RealmClient{}
+					realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+						return []realm.App{app}, nil
+					}
+					realmClient.ValuesFn = func(groupID, appID string) ([]realm.Value, error) {
+						return nil, errors.New("something bad happened")
+					}
+					return realmClient
+				},
+				expectedErr: errors.New("something bad happened"),
+			},
+			{
+				description: "when getting a value fails",
+				setupClient: func() realm.Client {
+					realmClient := mock.RealmClient{}
+					realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+						return []realm.App{app}, nil
+					}
+					realmClient.ValuesFn = func(groupID, appID string) ([]realm.Value, error) {
+						return testValues, nil
+					}
+					realmClient.ValueFn = func(groupID, appID, valueID string) (realm.Value, error) {
+						return realm.Value{}, errors.New("something bad happened")
+					}
+					return realmClient
+				},
+				expectedErr: errors.New("something bad happened"),
+			},
+		} {
+			t.Run(tc.description, func(t *testing.T) {
+				cmd := &CommandList{}
+
+				err := cmd.Handler(nil, nil, cli.Clients{Realm: tc.setupClient()})
+				assert.Equal(t, tc.expectedErr, err)
+			})
+		}
+	})
+}
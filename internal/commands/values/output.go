@@ -0,0 +1,74 @@
+package values
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+)
+
+const (
+	headerID      = "ID"
+	headerName    = "Name"
+	headerPrivate = "Private"
+	headerValue   = "Value"
+	headerDeleted = "Deleted"
+	headerDetails = "Details"
+)
+
+type valueOutputs []valueOutput
+
+type valueOutput struct {
+	value realm.Value
+	err   error
+}
+
+type tableRowModifier func(valueOutput, map[string]interface{})
+
+func tableHeaders(additionalHeaders ...string) []string {
+	return append([]string{headerID, headerName}, additionalHeaders...)
+}
+
+func tableRows(outputs valueOutputs, modifier tableRowModifier) []map[string]interface{} {
+	rows := make([]map[string]interface{}, 0, len(outputs))
+	for _, output := range outputs {
+		rows = append(rows, tableRow(output, modifier))
+	}
+	return rows
+}
+
+func tableRow(output valueOutput, modifier tableRowModifier) map[string]interface{} {
+	row := map[string]interface{}{
+		headerID:   output.value.ID,
+		headerName: output.value.Name,
+	}
+	modifier(output, row)
+	return row
+}
+
+// displayValue shows the contents of the value, where a value backed by
+// a secret links to the name of that secret rather than its plaintext
+func displayValue(value realm.Value) string {
+	if value.FromSecret {
+		return fmt.Sprintf("Secret: %v", value.Value)
+	}
+	data, err := json.Marshal(value.Value)
+	if err != nil {
+		return fmt.Sprintf("%v", value.Value)
+	}
+	return string(data)
+}
+
+func displayValueOption(value realm.Value) string {
+	return value.ID + terminal.DelimiterInline + value.Name
+}
+
+func findValue(values []realm.Value, identifier string) (realm.Value, bool) {
+	for _, value := range values {
+		if value.ID == identifier || value.Name == identifier {
+			return value, true
+		}
+	}
+	return realm.Value{}, false
+}
@@ -0,0 +1,116 @@
+package values
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/pflag"
+)
+
+// CommandPull is the `values pull` command
+type CommandPull struct {
+	inputs pullInputs
+}
+
+type pullInputs struct {
+	localInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandPull) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+
+	fs.StringVarP(&cmd.inputs.Name, flagName, flagNameShort, "", flagNameUsagePull)
+	fs.StringVar(&cmd.inputs.LocalPath, flagLocalPath, "", flagLocalPathUsage)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandPull) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandPull) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	values, err := clients.Realm.Values(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	value, err := cmd.inputs.resolveValue(ui, values)
+	if err != nil {
+		return err
+	}
+
+	value, err = clients.Realm.Value(app.GroupID, app.ID, value.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := local.WriteValue(cmd.inputs.LocalPath, localValue(value)); err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog(
+		"Successfully pulled value '%s' into %s",
+		value.Name,
+		filepath.Join(cmd.inputs.LocalPath, local.NameValues),
+	))
+	return nil
+}
+
+func (i *pullInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if err := i.localInputs.resolve(profile); err != nil {
+		return err
+	}
+	return i.ProjectInputs.Resolve(ui, i.LocalPath, false)
+}
+
+func (i *pullInputs) resolveValue(ui terminal.UI, values []realm.Value) (realm.Value, error) {
+	if i.Name == "" {
+		options := make([]string, len(values))
+		for i, value := range values {
+			options[i] = value.Name
+		}
+
+		if err := ui.AskOne(
+			&i.Name,
+			&survey.Select{
+				Message: "Which value would you like to pull?",
+				Options: options,
+			},
+		); err != nil {
+			return realm.Value{}, err
+		}
+	}
+
+	for _, value := range values {
+		if value.Name == i.Name {
+			return value, nil
+		}
+	}
+	return realm.Value{}, fmt.Errorf("unable to find value: %s", i.Name)
+}
+
+// localValue returns the value as it is stored in the app's values directory
+func localValue(value realm.Value) map[string]interface{} {
+	out := map[string]interface{}{
+		"name":        value.Name,
+		"value":       value.Value,
+		"from_secret": value.FromSecret,
+	}
+	if value.Private {
+		out["private"] = true
+	}
+	return out
+}
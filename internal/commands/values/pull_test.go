@@ -0,0 +1,78 @@
+package values
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestValuesPullHandler(t *testing.T) {
+	app := realm.App{
+		ID:          "appID",
+		GroupID:     "projectID",
+		ClientAppID: "eggcorn-abcde",
+		Name:        "eggcorn",
+	}
+
+	setupClient := func() mock.RealmClient {
+		realmClient := mock.RealmClient{}
+		realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+			return []realm.App{app}, nil
+		}
+		realmClient.ValuesFn = func(groupID, appID string) ([]realm.Value, error) {
+			return []realm.Value{{ID: "value1", Name: "linked", FromSecret: true}}, nil
+		}
+		realmClient.ValueFn = func(groupID, appID, valueID string) (realm.Value, error) {
+			return realm.Value{ID: "value1", Name: "linked", FromSecret: true, Private: true, Value: "mySecret"}, nil
+		}
+		return realmClient
+	}
+
+	t.Run("should write the deployed value into the local app", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("values_pull")
+		assert.Nil(t, err)
+		defer teardown()
+
+		out, ui := mock.NewUI()
+
+		cmd := &CommandPull{pullInputs{localInputs{LocalPath: tmpDir, Name: "linked"}}}
+
+		assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: setupClient()}))
+		assert.Equal(t, "Successfully pulled value 'linked' into "+filepath.Join(tmpDir, local.NameValues)+"\n", out.String())
+
+		data, err := ioutil.ReadFile(filepath.Join(tmpDir, local.NameValues, "linked.json"))
+		assert.Nil(t, err)
+		assert.Equal(t, `{
+    "from_secret": true,
+    "name": "linked",
+    "private": true,
+    "value": "mySecret"
+}
+`, string(data))
+	})
+
+	t.Run("should return an error when the value cannot be found", func(t *testing.T) {
+		cmd := &CommandPull{pullInputs{localInputs{Name: "missing"}}}
+
+		err := cmd.Handler(nil, nil, cli.Clients{Realm: setupClient()})
+		assert.Equal(t, errors.New("unable to find value: missing"), err)
+	})
+}
+
+func TestValuesPullInputs(t *testing.T) {
+	t.Run("should return an error when not run from inside a local app", func(t *testing.T) {
+		profile, teardown := mock.NewProfileFromTmpDir(t, "values_pull_inputs")
+		defer teardown()
+
+		inputs := pullInputs{}
+		assert.Equal(t, errProjectNotFound{}, inputs.Resolve(profile, nil))
+	})
+}
@@ -0,0 +1,112 @@
+package values
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/pflag"
+)
+
+// CommandPush is the `values push` command
+type CommandPush struct {
+	inputs pushInputs
+}
+
+type pushInputs struct {
+	localInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandPush) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+
+	fs.StringVarP(&cmd.inputs.Name, flagName, flagNameShort, "", flagNameUsagePush)
+	fs.StringVar(&cmd.inputs.LocalPath, flagLocalPath, "", flagLocalPathUsage)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandPush) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandPush) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	data, ok, err := local.FindValue(cmd.inputs.LocalPath, cmd.inputs.Name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf(
+			"failed to find value '%s' in %s",
+			cmd.inputs.Name,
+			filepath.Join(cmd.inputs.LocalPath, local.NameValues),
+		)
+	}
+
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	values, err := clients.Realm.Values(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	value := remoteValue(data)
+
+	for _, existing := range values {
+		if existing.Name != value.Name {
+			continue
+		}
+
+		value.ID = existing.ID
+		if err := clients.Realm.UpdateValue(app.GroupID, app.ID, value); err != nil {
+			return err
+		}
+
+		ui.Print(terminal.NewTextLog("Successfully updated value '%s'", value.Name))
+		return nil
+	}
+
+	created, err := clients.Realm.CreateValue(app.GroupID, app.ID, value)
+	if err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully created value '%s', id: %s", created.Name, created.ID))
+	return nil
+}
+
+func (i *pushInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if err := i.localInputs.resolve(profile); err != nil {
+		return err
+	}
+
+	if err := i.ProjectInputs.Resolve(ui, i.LocalPath, false); err != nil {
+		return err
+	}
+
+	if i.Name == "" {
+		if err := ui.AskOne(&i.Name, &survey.Input{Message: "Value Name"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remoteValue returns the value stored in the app's values directory as a Realm app value
+func remoteValue(data map[string]interface{}) realm.Value {
+	var value realm.Value
+	value.Name, _ = data["name"].(string)
+	value.Value = data["value"]
+	value.FromSecret, _ = data["from_secret"].(bool)
+	value.Private, _ = data["private"].(bool)
+	return value
+}
@@ -0,0 +1,113 @@
+package values
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/local"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestValuesPushHandler(t *testing.T) {
+	app := realm.App{
+		ID:          "appID",
+		GroupID:     "projectID",
+		ClientAppID: "eggcorn-abcde",
+		Name:        "eggcorn",
+	}
+
+	tmpDir, teardown, err := u.NewTempDir("values_push")
+	assert.Nil(t, err)
+	defer teardown()
+
+	assert.Nil(t, local.WriteValue(tmpDir, map[string]interface{}{"name": "linked", "value": "mySecret", "from_secret": true}))
+
+	for _, tc := range []struct {
+		description    string
+		deployedValues []realm.Value
+		expectedOutput string
+	}{
+		{
+			description:    "should update the deployed value of the same name",
+			deployedValues: []realm.Value{{ID: "value1", Name: "linked"}},
+			expectedOutput: "Successfully updated value 'linked'\n",
+		},
+		{
+			description:    "should create the value when it is not yet deployed",
+			expectedOutput: "Successfully created value 'linked', id: value2\n",
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			out, ui := mock.NewUI()
+
+			var pushedValue realm.Value
+
+			realmClient := mock.RealmClient{}
+			realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+				return []realm.App{app}, nil
+			}
+			realmClient.ValuesFn = func(groupID, appID string) ([]realm.Value, error) {
+				return tc.deployedValues, nil
+			}
+			realmClient.UpdateValueFn = func(groupID, appID string, value realm.Value) error {
+				pushedValue = value
+				return nil
+			}
+			realmClient.CreateValueFn = func(groupID, appID string, value realm.Value) (realm.Value, error) {
+				pushedValue = value
+				value.ID = "value2"
+				return value, nil
+			}
+
+			cmd := &CommandPush{pushInputs{localInputs{LocalPath: tmpDir, Name: "linked"}}}
+
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+			assert.Equal(t, tc.expectedOutput, out.String())
+
+			expectedValue := realm.Value{Name: "linked", FromSecret: true, Value: "mySecret"}
+			if len(tc.deployedValues) > 0 {
+				expectedValue.ID = tc.deployedValues[0].ID
+			}
+			assert.Equal(t, expectedValue, pushedValue)
+		})
+	}
+
+	t.Run("should return an error when the value cannot be found locally", func(t *testing.T) {
+		cmd := &CommandPush{pushInputs{localInputs{LocalPath: tmpDir, Name: "missing"}}}
+
+		err := cmd.Handler(nil, nil, cli.Clients{})
+		assert.Equal(t, errors.New("failed to find value 'missing' in "+filepath.Join(tmpDir, local.NameValues)), err)
+	})
+}
+
+func TestValuesPushInputs(t *testing.T) {
+	t.Run("should resolve the local app and its remote app", func(t *testing.T) {
+		profile, teardown := mock.NewProfileFromTmpDir(t, "values_push_inputs")
+		defer teardown()
+
+		assert.Nil(t, local.App{
+			RootDir: profile.WorkingDirectory,
+			Config:  local.FileRealmConfig,
+			AppData: &local.AppRealmConfigJSON{AppDataV2: local.AppDataV2{AppStructureV2: local.AppStructureV2{
+				ConfigVersion: realm.AppConfigVersion20210101,
+				ID:            "eggcorn-abcde",
+				Name:          "eggcorn",
+			}}},
+		}.WriteConfig())
+
+		nestedDir := filepath.Join(profile.WorkingDirectory, local.NameValues)
+		assert.Nil(t, os.MkdirAll(nestedDir, os.ModePerm))
+		profile.WorkingDirectory = nestedDir
+
+		inputs := pushInputs{localInputs{Name: "value"}}
+		assert.Nil(t, inputs.Resolve(profile, nil))
+		assert.Equal(t, filepath.Dir(nestedDir), inputs.LocalPath)
+		assert.Equal(t, "eggcorn-abcde", inputs.App)
+	})
+}
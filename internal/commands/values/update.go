@@ -0,0 +1,52 @@
+package values
+
+import (
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/spf13/pflag"
+)
+
+// CommandUpdate is the `values update` command
+type CommandUpdate struct {
+	inputs updateInputs
+}
+
+// Flags is the command flags
+func (cmd *CommandUpdate) Flags(fs *pflag.FlagSet) {
+	cmd.inputs.Flags(fs)
+
+	fs.StringVarP(&cmd.inputs.name, flagName, flagNameShort, "", flagNameUsageUpdate)
+	fs.StringVarP(&cmd.inputs.value, flagValue, flagValueShort, "", flagValueUsageUpdate)
+	fs.StringVarP(&cmd.inputs.secret, flagSecret, flagSecretShort, "", flagSecretUsageUpdate)
+}
+
+// Inputs is the command inputs
+func (cmd *CommandUpdate) Inputs() cli.InputResolver {
+	return &cmd.inputs
+}
+
+// Handler is the command handler
+func (cmd *CommandUpdate) Handler(profile *cli.Profile, ui terminal.UI, clients cli.Clients) error {
+	app, err := cli.ResolveApp(ui, clients.Realm, cmd.inputs.Filter())
+	if err != nil {
+		return err
+	}
+
+	values, err := clients.Realm.Values(app.GroupID, app.ID)
+	if err != nil {
+		return err
+	}
+
+	value, err := cmd.inputs.resolveValue(ui, values)
+	if err != nil {
+		return err
+	}
+
+	if err := clients.Realm.UpdateValue(app.GroupID, app.ID, value); err != nil {
+		return err
+	}
+
+	ui.Print(terminal.NewTextLog("Successfully updated value"))
+	return nil
+}
@@ -0,0 +1,73 @@
+package values
+
+import (
+	"fmt"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/terminal"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+type updateInputs struct {
+	cli.ProjectInputs
+	name   string
+	value  string
+	secret string
+}
+
+func (i *updateInputs) Resolve(profile *cli.Profile, ui terminal.UI) error {
+	if i.value != "" && i.secret != "" {
+		return errValueSecretConflict
+	}
+	return i.ProjectInputs.Resolve(ui, profile.WorkingDirectory, false)
+}
+
+// resolveValue selects the value to update and applies the new contents to it
+func (i *updateInputs) resolveValue(ui terminal.UI, values []realm.Value) (realm.Value, error) {
+	var value realm.Value
+
+	if i.name != "" {
+		found, ok := findValue(values, i.name)
+		if !ok {
+			return realm.Value{}, fmt.Errorf("unable to find value: %s", i.name)
+		}
+		value = found
+	} else {
+		selectableValues := map[string]realm.Value{}
+		selectableOptions := make([]string, len(values))
+		for i, value := range values {
+			option := displayValueOption(value)
+			selectableOptions[i] = option
+			selectableValues[option] = value
+		}
+
+		var selected string
+		if err := ui.AskOne(
+			&selected,
+			&survey.Select{
+				Message: "Which value would you like to update?",
+				Options: selectableOptions,
+			},
+		); err != nil {
+			return realm.Value{}, err
+		}
+		value = selectableValues[selected]
+	}
+
+	if i.value == "" && i.secret == "" {
+		if err := ui.AskOne(&i.value, &survey.Input{Message: "Value"}); err != nil {
+			return realm.Value{}, err
+		}
+	}
+
+	if i.secret != "" {
+		value.FromSecret = true
+		value.Value = i.secret
+	} else {
+		value.FromSecret = false
+		value.Value = parseValue(i.value)
+	}
+	return value, nil
+}
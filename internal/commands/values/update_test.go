@@ -0,0 +1,142 @@
+package values
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cli"
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+	"github.com/10gen/realm-cli/internal/utils/test/mock"
+)
+
+func TestValuesUpdateHandler(t *testing.T) {
+	app := realm.App{
+		ID:          "appID",
+		GroupID:     "projectID",
+		ClientAppID: "eggcorn-abcde",
+		Name:        "eggcorn",
+	}
+	testValues := []realm.Value{
+		{ID: "value1", Name: "string", Private: true},
+		{ID: "value2", Name: "linked", FromSecret: true},
+	}
+
+	for _, tc := range []struct {
+		description   string
+		inputs        updateInputs
+		expectedValue realm.Value
+	}{
+		{
+			description:   "should update a value found by name and keep it private",
+			inputs:        updateInputs{name: "string", value: "[1]"},
+			expectedValue: realm.Value{ID: "value1", Name: "string", Private: true, Value: []interface{}{float64(1)}},
+		},
+		{
+			description:   "should update a value found by id to be backed by a secret",
+			inputs:        updateInputs{name: "value1", secret: "mySecret"},
+			expectedValue: realm.Value{ID: "value1", Name: "string", Private: true, FromSecret: true, Value: "mySecret"},
+		},
+		{
+			description:   "should update a value backed by a secret to hold its own contents",
+			inputs:        updateInputs{name: "linked", value: "eggcorn"},
+			expectedValue: realm.Value{ID: "value2", Name: "linked", Value: "eggcorn"},
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			out, ui := mock.NewUI()
+
+			realmClient := mock.RealmClient{}
+			realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+				return []realm.App{app}, nil
+			}
+			realmClient.ValuesFn = func(groupID, appID string) ([]realm.Value, error) {
+				return testValues, nil
+			}
+
+			var capturedValue realm.Value
+			realmClient.UpdateValueFn = func(groupID, appID string, value realm.Value) error {
+				capturedValue = value
+				return nil
+			}
+
+			cmd := &CommandUpdate{tc.inputs}
+
+			assert.Nil(t, cmd.Handler(nil, ui, cli.Clients{Realm: realmClient}))
+			assert.Equal(t, "Successfully updated value\n", out.String())
+			assert.Equal(t, tc.expectedValue, capturedValue)
+		})
+	}
+
+	t.Run("should return an error", func(t *testing.T) {
+		for _, tc := range []struct {
+			description string
+			inputs      updateInputs
+			updateErr   error
+			expectedErr error
+		}{
+			{
+				description: "when the value cannot be found",
+				inputs:      updateInputs{name: "missing", value: "eggcorn"},
+				expectedErr: errors.New("unable to find value: missing"),
+			},
+			{
+				description: "when updating the value fails",
+				inputs:      updateInputs{name: "string", value: "eggcorn"},
+				updateErr:   errors.New("something bad happened"),
+				expectedErr: errors.New("something bad happened"),
+			},
+		} {
+			t.Run(tc.description, func(t *testing.T) {
+				realmClient := mock.RealmClient{}
+				realmClient.FindAppsFn = func(filter realm.AppFilter) ([]realm.App, error) {
+					return []realm.App{app}, nil
+				}
+				realmClient.ValuesFn = func(groupID, appID string) ([]realm.Value, error) {
+					return testValues, nil
+				}
+				realmClient.UpdateValueFn = func(groupID, appID string, value realm.Value) error {
+					return tc.updateErr
+				}
+
+				cmd := &CommandUpdate{tc.inputs}
+
+				err := cmd.Handler(nil, nil, cli.Clients{Realm: realmClient})
+				assert.Equal(t, tc.expectedErr, err)
+			})
+		}
+	})
+}
+
+func TestValuesUpdateInputs(t *testing.T) {
+	t.Run("should prompt for the value to update and its new contents", func(t *testing.T) {
+		_, console, _, ui, consoleErr := mock.NewVT10XConsole()
+		assert.Nil(t, consoleErr)
+		defer console.Close()
+
+		doneCh := make(chan struct{})
+		go func() {
+			defer close(doneCh)
+			console.ExpectString("Which value would you like to update?")
+			console.Send("value2")
+			console.SendLine("")
+			console.ExpectString("Value")
+			console.SendLine("true")
+			console.ExpectEOF()
+		}()
+
+		inputs := updateInputs{}
+		value, err := inputs.resolveValue(ui, []realm.Value{{ID: "value1", Name: "one"}, {ID: "value2", Name: "two"}})
+
+		console.Tty().Close() // flush the writers
+		<-doneCh              // wait for procedure to complete
+
+		assert.Nil(t, err)
+		assert.Equal(t, realm.Value{ID: "value2", Name: "two", Value: true}, value)
+	})
+
+	t.Run("should return an error when both a value and a secret are set", func(t *testing.T) {
+		inputs := updateInputs{value: "value", secret: "secret"}
+		assert.Equal(t, errValueSecretConflict, inputs.Resolve(nil, nil))
+	})
+}
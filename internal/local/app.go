@@ -203,6 +203,17 @@ func (a *App) Load() error {
 
 // LoadConfig will load the local app's config
 func (a *App) LoadConfig() error {
+	path := filepath.Join(a.RootDir, a.Config.String())
+
+	data, dataErr := ioutil.ReadFile(path)
+	if dataErr != nil {
+		return errFailedToParseAppConfig(path)
+	}
+
+	return a.parseConfig(path, data)
+}
+
+func (a *App) parseConfig(path string, data []byte) error {
 	switch a.Config {
 	case FileRealmConfig:
 		a.AppData = &AppRealmConfigJSON{}
@@ -212,13 +223,6 @@ func (a *App) LoadConfig() error {
 		a.AppData = &AppStitchJSON{}
 	}
 
-	path := filepath.Join(a.RootDir, a.Config.String())
-
-	data, dataErr := ioutil.ReadFile(path)
-	if dataErr != nil {
-		return errFailedToParseAppConfig(path)
-	}
-
 	if err := json.Unmarshal(data, a.AppData); err != nil {
 		return errFailedToParseAppConfig(path)
 	}
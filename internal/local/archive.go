@@ -6,6 +6,7 @@ import (
 	"compress/gzip"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -65,6 +66,236 @@ func errUnknownArchiveExtension(path string) error {
 	)
 }
 
+func errUnwritableArchiveExtension(path string) error {
+	return fmt.Errorf(
+		"failed to write archive file at %s: unsupported format, use one of [%s] instead",
+		path,
+		strings.Join(supportedExts, ", "),
+	)
+}
+
+// LoadAppArchive loads the app contained within the archive file at the provided path
+// by extracting it to a temporary directory, returning a teardown func which removes it
+func LoadAppArchive(path string) (App, func(), error) {
+	if filepath.Ext(path) == "" {
+		return App{}, nil, errUnknownArchiveExtension(path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return App{}, nil, err
+	}
+	defer file.Close()
+
+	archive, err := newArchiveReader(path, file)
+	if err != nil {
+		return App{}, nil, err
+	}
+
+	dir, err := ioutil.TempDir("", "realm-cli-app-")
+	if err != nil {
+		return App{}, nil, err
+	}
+	teardown := func() {
+		os.RemoveAll(dir) //nolint:errcheck
+	}
+
+	app, err := loadArchiveApp(dir, archive)
+	if err != nil {
+		teardown()
+		return App{}, nil, err
+	}
+	if app.AppData == nil {
+		teardown()
+		return App{}, nil, errMissingArchiveAppConfig(path)
+	}
+	return app, teardown, nil
+}
+
+// LoadAppArchiveConfig loads the app config contained within the archive file at the provided path,
+// reading it straight from the archive without extracting any of its contents
+func LoadAppArchiveConfig(path string) (App, error) {
+	if filepath.Ext(path) == "" {
+		return App{}, errUnknownArchiveExtension(path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return App{}, err
+	}
+	defer file.Close()
+
+	archive, err := newArchiveReader(path, file)
+	if err != nil {
+		return App{}, err
+	}
+
+	configs := map[File][]byte{}
+	for {
+		h, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return App{}, err
+		}
+
+		if h.Info.IsDir() {
+			continue
+		}
+
+		for _, config := range allConfigFiles {
+			if filepath.Clean(filepath.FromSlash(h.Path)) != config.String() {
+				continue
+			}
+			data, err := ioutil.ReadAll(archive)
+			if err != nil {
+				return App{}, err
+			}
+			configs[config] = data
+		}
+	}
+
+	for _, config := range allConfigFiles {
+		data, ok := configs[config]
+		if !ok {
+			continue
+		}
+		app := App{Config: config}
+		if err := app.parseConfig(filepath.Join(path, config.String()), data); err != nil {
+			return App{}, err
+		}
+		return app, nil
+	}
+	return App{}, errMissingArchiveAppConfig(path)
+}
+
+func errMissingArchiveAppConfig(path string) error {
+	return fmt.Errorf("failed to find app config in archive file at %s", path)
+}
+
+// loadArchiveApp extracts the archive contents to the provided directory and loads the app
+// found within, refusing any path which would be extracted outside of the directory
+func loadArchiveApp(dir string, archive ArchiveReader) (App, error) {
+	for {
+		h, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return App{}, err
+		}
+
+		if h.Info.IsDir() {
+			continue
+		}
+
+		path := filepath.Clean(filepath.FromSlash(h.Path))
+		if filepath.IsAbs(path) || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+			return App{}, fmt.Errorf("failed to read archive file: invalid path %s", h.Path)
+		}
+
+		if err := WriteFile(filepath.Join(dir, path), h.Info.Mode(), archive); err != nil {
+			return App{}, err
+		}
+	}
+	return LoadApp(dir)
+}
+
+// WriteArchive writes the zip package contents to a single archive file at the provided path,
+// packed as a zip, tar or gzipped tar file according to its extension
+func WriteArchive(path string, zipPkg *zip.Reader) error {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	var writeContents func(w io.Writer) error
+	switch {
+	case ext == extZip:
+		writeContents = func(w io.Writer) error { return writeZipArchive(w, zipPkg) }
+	case ext == extTar:
+		writeContents = func(w io.Writer) error { return writeTarArchive(w, zipPkg) }
+	case ext == extTgz || strings.HasSuffix(strings.ToLower(path), extTarGz):
+		writeContents = func(w io.Writer) error {
+			gw := gzip.NewWriter(w)
+			if err := writeTarArchive(gw, zipPkg); err != nil {
+				return err
+			}
+			return gw.Close()
+		}
+	default:
+		return errUnwritableArchiveExtension(path)
+	}
+
+	if err := mkdir(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := writeContents(file); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func writeZipArchive(w io.Writer, zipPkg *zip.Reader) error {
+	zw := zip.NewWriter(w)
+	for _, zipFile := range zipPkg.File {
+		header := zipFile.FileHeader
+		f, err := zw.CreateHeader(&header)
+		if err != nil {
+			return err
+		}
+
+		if zipFile.FileInfo().IsDir() {
+			continue
+		}
+
+		if err := copyZipFile(f, zipFile); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func writeTarArchive(w io.Writer, zipPkg *zip.Reader) error {
+	tw := tar.NewWriter(w)
+	for _, zipFile := range zipPkg.File {
+		header, err := tar.FileInfoHeader(zipFile.FileInfo(), "")
+		if err != nil {
+			return err
+		}
+		header.Name = zipFile.Name
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if zipFile.FileInfo().IsDir() {
+			continue
+		}
+
+		if err := copyZipFile(tw, zipFile); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func copyZipFile(w io.Writer, zipFile *zip.File) error {
+	r, err := zipFile.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	_, err = io.Copy(w, r)
+	return err
+}
+
 type zipReader struct {
 	*zip.Reader
 
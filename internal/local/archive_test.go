@@ -1,10 +1,15 @@
 package local
 
 import (
+	"archive/tar"
+	"errors"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
 	"github.com/10gen/realm-cli/internal/utils/test/assert"
 )
 
@@ -71,3 +76,136 @@ func TestArchiveReaderNew(t *testing.T) {
 		})
 	}
 }
+
+func TestAppArchive(t *testing.T) {
+	tmpDir, teardown, tmpDirErr := u.NewTempDir("archive")
+	assert.Nil(t, tmpDirErr)
+	defer teardown()
+
+	zipPkg := mustZip(t, map[string]string{
+		"realm_config.json": `{"config_version":20210101,"app_id":"eggcorn-abcde","name":"eggcorn","location":"US-VA","deployment_model":"GLOBAL"}`,
+		"values/value.json": `{"name":"value","value":"eggcorn"}`,
+	})
+
+	for _, name := range []string{"app.zip", "app.tar", "app.tgz", "app.tar.gz"} {
+		t.Run("should write and load an app as a "+name+" archive file", func(t *testing.T) {
+			path := filepath.Join(tmpDir, name)
+			assert.Nil(t, WriteArchive(path, zipPkg))
+
+			app, teardownApp, err := LoadAppArchive(path)
+			assert.Nil(t, err)
+			defer teardownApp()
+
+			assert.Equal(t, realm.AppConfigVersion20210101, app.ConfigVersion())
+			assert.Equal(t, "eggcorn-abcde", app.ID())
+
+			v2, ok := app.AppData.(*AppRealmConfigJSON)
+			assert.True(t, ok, "expected app data to be v2")
+			assert.Equal(t, []map[string]interface{}{{"name": "value", "value": "eggcorn"}}, v2.Values)
+		})
+	}
+
+	t.Run("should load the app config from an archive file without extracting it", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "config.tgz")
+		assert.Nil(t, WriteArchive(path, mustZip(t, map[string]string{
+			"config.json":       `{"config_version":20200603,"app_id":"eggcorn-vwxyz","name":"eggcorn"}`,
+			"realm_config.json": `{"config_version":20210101,"app_id":"eggcorn-abcde","name":"eggcorn","location":"US-VA","deployment_model":"GLOBAL"}`,
+		})))
+
+		app, err := LoadAppArchiveConfig(path)
+		assert.Nil(t, err)
+
+		assert.Equal(t, "", app.RootDir)
+		assert.Equal(t, FileRealmConfig, app.Config)
+		assert.Equal(t, "eggcorn-abcde", app.ID())
+	})
+
+	t.Run("should return an error when loading the app config from an archive file with no app", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "noconfig.tar")
+		assert.Nil(t, WriteArchive(path, mustZip(t, map[string]string{"values/value.json": `{}`})))
+
+		_, err := LoadAppArchiveConfig(path)
+		assert.Equal(t, errors.New("failed to find app config in archive file at "+path), err)
+	})
+
+	t.Run("should return an error when writing an archive file of an unsupported format", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "app.7z")
+		assert.Equal(t, errUnwritableArchiveExtension(path), WriteArchive(path, zipPkg))
+	})
+
+	t.Run("should return an error when loading an archive file of an unsupported format", func(t *testing.T) {
+		_, _, err := LoadAppArchive(tmpDir)
+		assert.Equal(t, errUnknownArchiveExtension(tmpDir), err)
+	})
+
+	t.Run("should return an error when the archive file contains no app", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "empty.zip")
+		assert.Nil(t, WriteArchive(path, mustZip(t, map[string]string{"values/value.json": `{}`})))
+
+		_, _, err := LoadAppArchive(path)
+		assert.Equal(t, errors.New("failed to find app config in archive file at "+path), err)
+	})
+
+	t.Run("should load an app from an archive file containing paths which begin with dots", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "dots.zip")
+		assert.Nil(t, WriteArchive(path, mustZip(t, map[string]string{
+			"realm_config.json":   `{"config_version":20210101,"app_id":"eggcorn-abcde","name":"eggcorn","location":"US-VA","deployment_model":"GLOBAL"}`,
+			"..config.json":       `{}`,
+			"hosting/files/...js": `console.log("eggcorn")`,
+		})))
+
+		app, teardownApp, err := LoadAppArchive(path)
+		assert.Nil(t, err)
+		defer teardownApp()
+
+		assert.Equal(t, "eggcorn-abcde", app.ID())
+		for _, name := range []string{"..config.json", filepath.Join("hosting", "files", "...js")} {
+			_, err := os.Stat(filepath.Join(app.RootDir, name))
+			assert.Nil(t, err)
+		}
+	})
+
+	t.Run("should return an error when the archive file contains a path outside of the app", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "escape.tar")
+
+		file, err := os.Create(path)
+		assert.Nil(t, err)
+
+		tw := tar.NewWriter(file)
+		assert.Nil(t, tw.WriteHeader(&tar.Header{Name: "../escape.json", Mode: 0666, Size: 2}))
+		_, err = tw.Write([]byte("{}"))
+		assert.Nil(t, err)
+		assert.Nil(t, tw.Close())
+		assert.Nil(t, file.Close())
+
+		_, _, err = LoadAppArchive(path)
+		assert.Equal(t, errors.New("failed to read archive file: invalid path ../escape.json"), err)
+	})
+
+	t.Run("should remove the extracted app once torn down or when loading the archive file fails", func(t *testing.T) {
+		extractDir := filepath.Join(tmpDir, "extract")
+		assert.Nil(t, os.Mkdir(extractDir, os.ModePerm))
+
+		tmpEnv := os.Getenv("TMPDIR")
+		assert.Nil(t, os.Setenv("TMPDIR", extractDir))
+		defer os.Setenv("TMPDIR", tmpEnv) //nolint:errcheck
+
+		assertExtractDirEmpty := func(t *testing.T) {
+			t.Helper()
+			files, err := ioutil.ReadDir(extractDir)
+			assert.Nil(t, err)
+			assert.Equal(t, 0, len(files))
+		}
+
+		for _, name := range []string{"empty.zip", "escape.tar"} {
+			_, _, err := LoadAppArchive(filepath.Join(tmpDir, name))
+			assert.NotNil(t, err)
+			assertExtractDirEmpty(t)
+		}
+
+		_, teardownApp, err := LoadAppArchive(filepath.Join(tmpDir, "app.zip"))
+		assert.Nil(t, err)
+		teardownApp()
+		assertExtractDirEmpty(t)
+	})
+}
@@ -0,0 +1,206 @@
+package local
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// NameBaseline is the name of the directory within the app which holds
+// a snapshot of the app as it was last pulled or pushed
+const NameBaseline = ".baseline"
+
+// set of conflict markers written to files changed both locally and remotely
+const (
+	conflictMarkerLocal  = "<<<<<<< local"
+	conflictMarkerSep    = "======="
+	conflictMarkerRemote = ">>>>>>> remote"
+)
+
+// MergeResult is the outcome of merging a remote app into a local app
+type MergeResult struct {
+	Updated   []string // files changed remotely only, which have been overwritten
+	Deleted   []string // files removed remotely only, which have been removed
+	Skipped   []string // files changed locally only, which have been left untouched
+	Conflicts []string // files changed both locally and remotely, which now hold conflict markers unless removed locally
+}
+
+// HasBaseline returns true if the app directory holds a baseline snapshot
+func HasBaseline(rootDir string) (bool, error) {
+	fileInfo, err := os.Stat(filepath.Join(rootDir, NameBaseline))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return fileInfo.IsDir(), nil
+}
+
+// WriteBaseline replaces the app baseline snapshot with the contents of the zip package
+func WriteBaseline(rootDir string, zipPkg *zip.Reader) error {
+	path := filepath.Join(rootDir, NameBaseline)
+	if err := os.RemoveAll(path); err != nil {
+		return err
+	}
+	return WriteZip(path, zipPkg)
+}
+
+// MergeZip performs a three-way merge of the app baseline snapshot, the local app
+// and the remote app contained in the zip package, then records the remote app
+// as the new baseline snapshot
+//
+// Files changed only remotely are updated, files changed only locally are skipped
+// and files changed both locally and remotely are written with conflict markers,
+// while files removed locally but changed remotely are reported as conflicts and left removed
+func MergeZip(rootDir string, zipPkg *zip.Reader) (MergeResult, error) {
+	baseline, err := readBaseline(rootDir)
+	if err != nil {
+		return MergeResult{}, err
+	}
+
+	var result MergeResult
+
+	remotePaths := make(map[string]struct{}, len(zipPkg.File))
+	for _, zipFile := range zipPkg.File {
+		if zipFile.FileInfo().IsDir() {
+			continue
+		}
+
+		path := filepath.FromSlash(zipFile.Name)
+		remotePaths[path] = struct{}{}
+
+		remote, err := readZipFile(zipFile)
+		if err != nil {
+			return MergeResult{}, err
+		}
+
+		base, hasBase := baseline[path]
+
+		local, hasLocal, err := readLocalFile(filepath.Join(rootDir, path))
+		if err != nil {
+			return MergeResult{}, err
+		}
+
+		switch {
+		case hasLocal && bytes.Equal(local, remote):
+			continue
+		case hasBase && bytes.Equal(remote, base):
+			// the file is unchanged remotely, so any local change (including its removal) wins
+			result.Skipped = append(result.Skipped, path)
+			continue
+		case !hasLocal && hasBase:
+			// the file was removed locally but changed remotely, so leave it removed for the conflict to be resolved
+			result.Conflicts = append(result.Conflicts, path)
+			continue
+		case hasLocal && (!hasBase || !bytes.Equal(local, base)):
+			result.Conflicts = append(result.Conflicts, path)
+			remote = conflictContents(local, remote)
+		default:
+			result.Updated = append(result.Updated, path)
+		}
+
+		if err := WriteFile(filepath.Join(rootDir, path), zipFile.Mode(), bytes.NewReader(remote)); err != nil {
+			return MergeResult{}, err
+		}
+	}
+
+	for path, base := range baseline {
+		if _, ok := remotePaths[path]; ok {
+			continue
+		}
+
+		local, hasLocal, err := readLocalFile(filepath.Join(rootDir, path))
+		if err != nil {
+			return MergeResult{}, err
+		}
+		if !hasLocal {
+			continue
+		}
+
+		if !bytes.Equal(local, base) {
+			result.Conflicts = append(result.Conflicts, path)
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(rootDir, path)); err != nil {
+			return MergeResult{}, err
+		}
+		result.Deleted = append(result.Deleted, path)
+	}
+
+	// sort to ensure determinism
+	sort.Strings(result.Updated)
+	sort.Strings(result.Deleted)
+	sort.Strings(result.Skipped)
+	sort.Strings(result.Conflicts)
+
+	if err := WriteBaseline(rootDir, zipPkg); err != nil {
+		return MergeResult{}, err
+	}
+	return result, nil
+}
+
+func readBaseline(rootDir string) (map[string][]byte, error) {
+	dir := filepath.Join(rootDir, NameBaseline)
+
+	files := map[string][]byte{}
+	if err := walk(dir, nil, func(file os.FileInfo, path string) error {
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		files[relPath] = data
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func readZipFile(zipFile *zip.File) ([]byte, error) {
+	r, err := zipFile.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
+
+func readLocalFile(path string) ([]byte, bool, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+func conflictContents(local, remote []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(conflictMarkerLocal + "\n")
+	writeLines(&buf, local)
+	buf.WriteString(conflictMarkerSep + "\n")
+	writeLines(&buf, remote)
+	buf.WriteString(conflictMarkerRemote + "\n")
+	return buf.Bytes()
+}
+
+func writeLines(buf *bytes.Buffer, data []byte) {
+	buf.Write(data)
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+}
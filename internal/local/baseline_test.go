@@ -0,0 +1,102 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestHasBaseline(t *testing.T) {
+	tmpDir, teardown, tmpDirErr := u.NewTempDir("baseline")
+	assert.Nil(t, tmpDirErr)
+	defer teardown()
+
+	t.Run("should return false when the app has no baseline", func(t *testing.T) {
+		hasBaseline, err := HasBaseline(tmpDir)
+		assert.Nil(t, err)
+		assert.False(t, hasBaseline, "expected app to have no baseline")
+	})
+
+	t.Run("should return true once a baseline has been written", func(t *testing.T) {
+		assert.Nil(t, WriteBaseline(tmpDir, mustZip(t, map[string]string{"file.json": "{}"})))
+
+		hasBaseline, err := HasBaseline(tmpDir)
+		assert.Nil(t, err)
+		assert.True(t, hasBaseline, "expected app to have a baseline")
+	})
+}
+
+func TestMergeZip(t *testing.T) {
+	tmpDir, teardown, tmpDirErr := u.NewTempDir("merge")
+	assert.Nil(t, tmpDirErr)
+	defer teardown()
+
+	assert.Nil(t, WriteBaseline(tmpDir, mustZip(t, map[string]string{
+		"unchanged.json":                    "base",
+		"local_only.json":                   "base",
+		"remote_only.json":                  "base",
+		"both.json":                         "base",
+		"removed_remote.json":               "base",
+		"removed_local.json":                "base",
+		"removed_local_changed_remote.json": "base",
+	})))
+
+	for name, contents := range map[string]string{
+		"unchanged.json":      "base",
+		"local_only.json":     "local",
+		"remote_only.json":    "base",
+		"both.json":           "local",
+		"removed_remote.json": "base",
+		"added_local.json":    "local",
+	} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, name), []byte(contents), 0666))
+	}
+
+	result, err := MergeZip(tmpDir, mustZip(t, map[string]string{
+		"unchanged.json":                    "base",
+		"local_only.json":                   "base",
+		"remote_only.json":                  "remote",
+		"both.json":                         "remote",
+		"removed_local.json":                "base",
+		"removed_local_changed_remote.json": "remote",
+		"added_remote.json":                 "remote",
+	}))
+	assert.Nil(t, err)
+
+	assert.Equal(t, MergeResult{
+		Updated:   []string{"added_remote.json", "remote_only.json"},
+		Deleted:   []string{"removed_remote.json"},
+		Skipped:   []string{"local_only.json", "removed_local.json"},
+		Conflicts: []string{"both.json", "removed_local_changed_remote.json"},
+	}, result)
+
+	for name, expected := range map[string]string{
+		"unchanged.json":    "base",
+		"local_only.json":   "local",
+		"remote_only.json":  "remote",
+		"both.json":         "<<<<<<< local\nlocal\n=======\nremote\n>>>>>>> remote\n",
+		"added_local.json":  "local",
+		"added_remote.json": "remote",
+	} {
+		data, err := ioutil.ReadFile(filepath.Join(tmpDir, name))
+		assert.Nil(t, err)
+		assert.Equal(t, expected, string(data))
+	}
+
+	for _, name := range []string{"removed_remote.json", "removed_local.json", "removed_local_changed_remote.json"} {
+		_, err := os.Stat(filepath.Join(tmpDir, name))
+		assert.True(t, os.IsNotExist(err), "expected %s to not exist", name)
+	}
+
+	t.Log("and should record the remote app as the new baseline")
+	data, err := ioutil.ReadFile(filepath.Join(tmpDir, NameBaseline, "both.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, "remote", string(data))
+
+	_, err = os.Stat(filepath.Join(tmpDir, NameBaseline, "removed_remote.json"))
+	assert.True(t, os.IsNotExist(err), "expected baseline file to be removed")
+}
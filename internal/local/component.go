@@ -0,0 +1,233 @@
+package local
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+)
+
+// set of supported app components
+const (
+	ComponentAuth          = "auth"
+	ComponentDataSources   = "data_sources"
+	ComponentEnvironments  = "environments"
+	ComponentFunctions     = "functions"
+	ComponentGraphQL       = "graphql"
+	ComponentHosting       = "hosting"
+	ComponentHTTPEndpoints = "http_endpoints"
+	ComponentServices      = "services"
+	ComponentSettings      = "settings"
+	ComponentSync          = "sync"
+	ComponentTriggers      = "triggers"
+	ComponentValues        = "values"
+)
+
+// AppComponents are the app components which can be pushed independently of one another
+var AppComponents = []string{
+	ComponentAuth,
+	ComponentDataSources,
+	ComponentEnvironments,
+	ComponentFunctions,
+	ComponentGraphQL,
+	ComponentHosting,
+	ComponentHTTPEndpoints,
+	ComponentServices,
+	ComponentSettings,
+	ComponentSync,
+	ComponentTriggers,
+	ComponentValues,
+}
+
+// set of app components which only the v2 app structure holds
+var appComponentsV2Only = map[string]struct{}{
+	ComponentDataSources:   {},
+	ComponentHTTPEndpoints: {},
+}
+
+// SupportedAppComponents returns the app components held by the app structure of the config version
+func SupportedAppComponents(configVersion realm.AppConfigVersion) []string {
+	if configVersion >= realm.AppConfigVersion20210101 {
+		return AppComponents
+	}
+
+	components := make([]string, 0, len(AppComponents))
+	for _, component := range AppComponents {
+		if _, ok := appComponentsV2Only[component]; !ok {
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+// ExportAppData exports the deployed app in the provided config version and parses its app data
+func ExportAppData(realmClient realm.Client, groupID, appID string, configVersion realm.AppConfigVersion) (AppData, error) {
+	_, zipPkg, err := realmClient.Export(groupID, appID, realm.ExportRequest{ConfigVersion: configVersion})
+	if err != nil {
+		return nil, err
+	}
+	return ParseAppZip(zipPkg)
+}
+
+// ParseAppZip parses the app data contained within the provided zip package
+func ParseAppZip(zipPkg *zip.Reader) (AppData, error) {
+	app, teardown, err := LoadAppZip(zipPkg)
+	if err != nil {
+		return nil, err
+	}
+	defer teardown()
+
+	return app.AppData, nil
+}
+
+// LoadAppZip extracts the provided zip package to a temporary directory and loads
+// the app found within, returning a teardown func which removes the directory
+func LoadAppZip(zipPkg *zip.Reader) (App, func(), error) {
+	dir, err := ioutil.TempDir("", "realm-cli-app-")
+	if err != nil {
+		return App{}, nil, err
+	}
+	teardown := func() {
+		os.RemoveAll(dir) //nolint:errcheck
+	}
+
+	if err := WriteZip(dir, zipPkg); err != nil {
+		teardown()
+		return App{}, nil, err
+	}
+
+	app, err := LoadApp(dir)
+	if err != nil {
+		teardown()
+		return App{}, nil, err
+	}
+	if app.AppData == nil {
+		teardown()
+		return App{}, nil, errors.New("failed to find app config in zip package")
+	}
+	return app, teardown, nil
+}
+
+// MergeAppComponents replaces the specified components of the destination app data
+// with those found in the source app data, leaving all other components untouched
+func MergeAppComponents(dst, src AppData, components []string) error {
+	if dst.ConfigVersion() != src.ConfigVersion() {
+		return fmt.Errorf(
+			"cannot merge app data of config version %s with config version %s",
+			src.ConfigVersion(),
+			dst.ConfigVersion(),
+		)
+	}
+
+	if dstV2, ok := dst.(*AppRealmConfigJSON); ok {
+		srcV2, ok := src.(*AppRealmConfigJSON)
+		if !ok {
+			return errUnsupportedAppData(src)
+		}
+		return mergeComponentsV2(&dstV2.AppStructureV2, srcV2.AppStructureV2, components)
+	}
+
+	dstV1, ok := appStructureV1(dst)
+	if !ok {
+		return errUnsupportedAppData(dst)
+	}
+	srcV1, ok := appStructureV1(src)
+	if !ok {
+		return errUnsupportedAppData(src)
+	}
+	return mergeComponentsV1(dstV1, *srcV1, components)
+}
+
+func appStructureV1(data AppData) (*AppStructureV1, bool) {
+	switch d := data.(type) {
+	case *AppConfigJSON:
+		return &d.AppStructureV1, true
+	case *AppStitchJSON:
+		return &d.AppStructureV1, true
+	}
+	return nil, false
+}
+
+func errUnsupportedAppData(data AppData) error {
+	return fmt.Errorf("unsupported app data type: %T", data)
+}
+
+func errUnsupportedComponent(component string, configVersion realm.AppConfigVersion) error {
+	return fmt.Errorf("app component '%s' is not supported by config version %s", component, configVersion)
+}
+
+func mergeComponentsV2(dst *AppStructureV2, src AppStructureV2, components []string) error {
+	for _, component := range components {
+		switch component {
+		case ComponentAuth:
+			dst.Auth = src.Auth
+		case ComponentDataSources:
+			dst.DataSources = src.DataSources
+		case ComponentEnvironments:
+			dst.Environment = src.Environment
+			dst.Environments = src.Environments
+		case ComponentFunctions:
+			dst.Functions = src.Functions
+		case ComponentGraphQL:
+			dst.GraphQL = src.GraphQL
+		case ComponentHosting:
+			dst.Hosting = src.Hosting
+		case ComponentHTTPEndpoints:
+			dst.HTTPEndpoints = src.HTTPEndpoints
+		case ComponentServices:
+			dst.Services = src.Services
+		case ComponentSettings:
+			dst.Name = src.Name
+			dst.Location = src.Location
+			dst.DeploymentModel = src.DeploymentModel
+			dst.AllowedRequestOrigins = src.AllowedRequestOrigins
+		case ComponentSync:
+			dst.Sync = src.Sync
+		case ComponentTriggers:
+			dst.Triggers = src.Triggers
+		case ComponentValues:
+			dst.Values = src.Values
+		default:
+			return errUnsupportedComponent(component, src.ConfigVersion)
+		}
+	}
+	return nil
+}
+
+func mergeComponentsV1(dst *AppStructureV1, src AppStructureV1, components []string) error {
+	for _, component := range components {
+		switch component {
+		case ComponentAuth:
+			dst.AuthProviders = src.AuthProviders
+			dst.CustomUserDataConfig = src.CustomUserDataConfig
+		case ComponentEnvironments:
+			dst.Environment = src.Environment
+			dst.Environments = src.Environments
+		case ComponentFunctions:
+			dst.Functions = src.Functions
+		case ComponentGraphQL:
+			dst.GraphQL = src.GraphQL
+		case ComponentHosting:
+			dst.Hosting = src.Hosting
+		case ComponentServices:
+			dst.Services = src.Services
+		case ComponentSettings:
+			dst.Name = src.Name
+			dst.Location = src.Location
+			dst.DeploymentModel = src.DeploymentModel
+			dst.Security = src.Security
+		case ComponentSync:
+			dst.Sync = src.Sync
+		case ComponentTriggers:
+			dst.Triggers = src.Triggers
+		case ComponentValues:
+			dst.Values = src.Values
+		default:
+			return errUnsupportedComponent(component, src.ConfigVersion)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package local
+
+import (
+	"archive/zip"
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestParseAppZip(t *testing.T) {
+	t.Run("should parse the app data contained in a zip package", func(t *testing.T) {
+		zipPkg := mustZip(t, map[string]string{
+			"realm_config.json": `{"config_version":20210101,"app_id":"eggcorn-abcde","name":"eggcorn","location":"US-VA","deployment_model":"GLOBAL"}`,
+			"values/value.json": `{"name":"value","value":"eggcorn"}`,
+		})
+
+		appData, err := ParseAppZip(zipPkg)
+		assert.Nil(t, err)
+
+		v2, ok := appData.(*AppRealmConfigJSON)
+		assert.True(t, ok, "expected app data to be v2")
+		assert.Equal(t, realm.AppConfigVersion20210101, v2.ConfigVersion())
+		assert.Equal(t, "eggcorn-abcde", v2.ID())
+		assert.Equal(t, []map[string]interface{}{{"name": "value", "value": "eggcorn"}}, v2.Values)
+	})
+
+	t.Run("should return an error when the zip package contains no app config", func(t *testing.T) {
+		zipPkg := mustZip(t, map[string]string{"values/value.json": `{}`})
+
+		_, err := ParseAppZip(zipPkg)
+		assert.Equal(t, errors.New("failed to find app config in zip package"), err)
+	})
+}
+
+func TestMergeAppComponents(t *testing.T) {
+	t.Run("should replace only the specified components of v2 app data", func(t *testing.T) {
+		dst := &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			Name:          "remote",
+			Values:        []map[string]interface{}{{"name": "remote"}},
+			Triggers:      []map[string]interface{}{{"name": "remote"}},
+			Functions:     &FunctionsStructure{Sources: map[string]string{"remote.js": "remote"}},
+		}}}
+		src := &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			Name:          "local",
+			Values:        []map[string]interface{}{{"name": "local"}},
+			Triggers:      []map[string]interface{}{{"name": "local"}},
+			Functions:     &FunctionsStructure{Sources: map[string]string{"local.js": "local"}},
+		}}}
+
+		assert.Nil(t, MergeAppComponents(dst, src, []string{ComponentFunctions, ComponentTriggers}))
+
+		assert.Equal(t, &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			Name:          "remote",
+			Values:        []map[string]interface{}{{"name": "remote"}},
+			Triggers:      []map[string]interface{}{{"name": "local"}},
+			Functions:     &FunctionsStructure{Sources: map[string]string{"local.js": "local"}},
+		}}}, dst)
+	})
+
+	t.Run("should replace the whole settings of v2 app data", func(t *testing.T) {
+		dst := &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion:         realm.AppConfigVersion20210101,
+			ID:                    "remote-abcde",
+			Name:                  "remote",
+			Location:              realm.LocationVirginia,
+			DeploymentModel:       realm.DeploymentModelGlobal,
+			AllowedRequestOrigins: []string{"http://remote.com"},
+			Values:                []map[string]interface{}{{"name": "remote"}},
+		}}}
+		src := &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion:         realm.AppConfigVersion20210101,
+			ID:                    "local-abcde",
+			Name:                  "local",
+			Location:              realm.LocationIreland,
+			DeploymentModel:       realm.DeploymentModelLocal,
+			AllowedRequestOrigins: []string{"http://local.com"},
+			Values:                []map[string]interface{}{{"name": "local"}},
+		}}}
+
+		assert.Nil(t, MergeAppComponents(dst, src, []string{ComponentSettings}))
+
+		assert.Equal(t, &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion:         realm.AppConfigVersion20210101,
+			ID:                    "remote-abcde",
+			Name:                  "local",
+			Location:              realm.LocationIreland,
+			DeploymentModel:       realm.DeploymentModelLocal,
+			AllowedRequestOrigins: []string{"http://local.com"},
+			Values:                []map[string]interface{}{{"name": "remote"}},
+		}}}, dst)
+	})
+
+	t.Run("should replace only the specified components of v1 app data", func(t *testing.T) {
+		dst := &AppConfigJSON{AppDataV1{AppStructureV1{
+			ConfigVersion: realm.AppConfigVersion20200603,
+			Name:          "remote",
+			Values:        []map[string]interface{}{{"name": "remote"}},
+			AuthProviders: []map[string]interface{}{{"name": "remote"}},
+		}}}
+		src := &AppConfigJSON{AppDataV1{AppStructureV1{
+			ConfigVersion: realm.AppConfigVersion20200603,
+			Name:          "local",
+			Values:        []map[string]interface{}{{"name": "local"}},
+			AuthProviders: []map[string]interface{}{{"name": "local"}},
+		}}}
+
+		assert.Nil(t, MergeAppComponents(dst, src, []string{ComponentAuth}))
+
+		assert.Equal(t, &AppConfigJSON{AppDataV1{AppStructureV1{
+			ConfigVersion: realm.AppConfigVersion20200603,
+			Name:          "remote",
+			Values:        []map[string]interface{}{{"name": "remote"}},
+			AuthProviders: []map[string]interface{}{{"name": "local"}},
+		}}}, dst)
+	})
+
+	t.Run("should merge every component supported by the config version", func(t *testing.T) {
+		for _, configVersion := range []realm.AppConfigVersion{realm.AppConfigVersion20180301, realm.AppConfigVersion20200603} {
+			dst := &AppConfigJSON{AppDataV1{AppStructureV1{ConfigVersion: configVersion}}}
+			src := &AppConfigJSON{AppDataV1{AppStructureV1{ConfigVersion: configVersion}}}
+
+			assert.Nil(t, MergeAppComponents(dst, src, SupportedAppComponents(configVersion)))
+		}
+
+		dst := &AppRealmConfigJSON{AppDataV2{AppStructureV2{ConfigVersion: realm.AppConfigVersion20210101}}}
+		src := &AppRealmConfigJSON{AppDataV2{AppStructureV2{ConfigVersion: realm.AppConfigVersion20210101}}}
+
+		assert.Nil(t, MergeAppComponents(dst, src, SupportedAppComponents(realm.AppConfigVersion20210101)))
+	})
+
+	t.Run("should return an error when a component is not supported by the config version", func(t *testing.T) {
+		dst := &AppConfigJSON{AppDataV1{AppStructureV1{ConfigVersion: realm.AppConfigVersion20200603}}}
+		src := &AppConfigJSON{AppDataV1{AppStructureV1{ConfigVersion: realm.AppConfigVersion20200603}}}
+
+		err := MergeAppComponents(dst, src, []string{ComponentDataSources})
+		assert.Equal(t, errors.New("app component 'data_sources' is not supported by config version 20200603"), err)
+	})
+
+	t.Run("should return an error when the config versions differ", func(t *testing.T) {
+		dst := &AppRealmConfigJSON{AppDataV2{AppStructureV2{ConfigVersion: realm.AppConfigVersion20210101}}}
+		src := &AppConfigJSON{AppDataV1{AppStructureV1{ConfigVersion: realm.AppConfigVersion20200603}}}
+
+		err := MergeAppComponents(dst, src, []string{ComponentFunctions})
+		assert.Equal(t, errors.New("cannot merge app data of config version 20200603 with config version 20210101"), err)
+	})
+}
+
+func mustZip(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+	zipPkg, err := u.NewZipReader(files)
+	assert.Nil(t, err)
+	return zipPkg
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/10gen/realm-cli/internal/cloud/realm"
 )
@@ -74,6 +75,9 @@ const (
 	// values
 	NameSecrets = "secrets"
 	NameValues  = "values"
+
+	// workspace
+	NameWorkspace = "realm-workspace"
 )
 
 // set of supported local files
@@ -97,6 +101,9 @@ var (
 
 	// values
 	FileSecrets = File{NameSecrets, extJSON}
+
+	// workspace
+	FileWorkspace = File{NameWorkspace, extJSON}
 )
 
 // File is a local Realm app file
@@ -215,3 +222,62 @@ func AddDataSource(appData AppData, config map[string]interface{}) {
 		ad.DataSources = append(ad.DataSources, DataSourceStructure{Config: config})
 	}
 }
+
+// DataSourceClusters returns the names of the Atlas clusters linked as data sources of the app data
+func DataSourceClusters(appData AppData) []string {
+	set := map[string]struct{}{}
+	for _, config := range clusterDataSourceConfigs(appData) {
+		if clusterName, ok := config["clusterName"].(string); ok && clusterName != "" {
+			set[clusterName] = struct{}{}
+		}
+	}
+
+	clusters := make([]string, 0, len(set))
+	for cluster := range set {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+	return clusters
+}
+
+// RelinkDataSources links the data sources of the app data to other Atlas clusters,
+// based on the provided mapping of cluster names
+func RelinkDataSources(appData AppData, clusters map[string]string) {
+	for _, config := range clusterDataSourceConfigs(appData) {
+		clusterName, ok := config["clusterName"].(string)
+		if !ok {
+			continue
+		}
+		if target, ok := clusters[clusterName]; ok {
+			config["clusterName"] = target
+		}
+	}
+}
+
+// clusterDataSourceConfigs returns the inner configs of the app data's Atlas cluster data sources
+func clusterDataSourceConfigs(appData AppData) []map[string]interface{} {
+	var configs []map[string]interface{}
+	add := func(config map[string]interface{}) {
+		if inner, ok := config["config"].(map[string]interface{}); ok {
+			configs = append(configs, inner)
+		}
+	}
+
+	switch ad := appData.(type) {
+	case *AppRealmConfigJSON:
+		for _, ds := range ad.DataSources {
+			if ds.Config["type"] != serviceTypeDataLake {
+				add(ds.Config)
+			}
+		}
+	default:
+		if v1, ok := appStructureV1(appData); ok {
+			for _, svc := range v1.Services {
+				if svc.Config["type"] == serviceTypeAtlas {
+					add(svc.Config)
+				}
+			}
+		}
+	}
+	return configs
+}
@@ -204,3 +204,34 @@ func TestAddDataSource(t *testing.T) {
 		})
 	}
 }
+
+func TestDataSourceClusters(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		appData     AppData
+	}{
+		{
+			description: "should find and relink the clusters of v2 app data",
+			appData: &AppRealmConfigJSON{AppDataV2{AppStructureV2{DataSources: []DataSourceStructure{
+				{Config: map[string]interface{}{"name": "a", "type": "mongodb-atlas", "config": map[string]interface{}{"clusterName": "Cluster1"}}},
+				{Config: map[string]interface{}{"name": "b", "type": "mongodb-atlas", "config": map[string]interface{}{"clusterName": "Cluster0"}}},
+				{Config: map[string]interface{}{"name": "c", "type": "mongodb-datalake", "config": map[string]interface{}{"dataLakeName": "lake"}}},
+			}}}},
+		},
+		{
+			description: "should find and relink the clusters of v1 app data",
+			appData: &AppConfigJSON{AppDataV1{AppStructureV1{Services: []ServiceStructure{
+				{Config: map[string]interface{}{"name": "a", "type": "mongodb-atlas", "config": map[string]interface{}{"clusterName": "Cluster1"}}},
+				{Config: map[string]interface{}{"name": "b", "type": "mongodb-atlas", "config": map[string]interface{}{"clusterName": "Cluster0"}}},
+				{Config: map[string]interface{}{"name": "c", "type": "twilio", "config": map[string]interface{}{"sid": "sid"}}},
+			}}}},
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, []string{"Cluster0", "Cluster1"}, DataSourceClusters(tc.appData))
+
+			RelinkDataSources(tc.appData, map[string]string{"Cluster1": "Sandbox"})
+			assert.Equal(t, []string{"Cluster0", "Sandbox"}, DataSourceClusters(tc.appData))
+		})
+	}
+}
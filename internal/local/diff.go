@@ -0,0 +1,210 @@
+package local
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+)
+
+const (
+	diffPathSeparator = "/"
+)
+
+// set of app data fields which belong to a component named differently than the field
+var (
+	diffComponentsByField = map[string]string{
+		"allowed_request_origins": ComponentSettings,
+		"app_id":                  ComponentSettings,
+		"auth_providers":          ComponentAuth,
+		"config_version":          ComponentSettings,
+		"custom_user_data_config": ComponentAuth,
+		"deployment_model":        ComponentSettings,
+		"environment":             ComponentEnvironments,
+		"location":                ComponentSettings,
+		"name":                    ComponentSettings,
+		"security":                ComponentSettings,
+	}
+)
+
+// DiffAppData returns the structured differences between two versions of the app data,
+// where the changes described are those which turn the before app data into the after
+func DiffAppData(before, after AppData) ([]realm.Diff, error) {
+	beforeDoc, err := appDataDocument(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterDoc, err := appDataDocument(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []realm.Diff
+	for _, field := range sortedKeys(beforeDoc, afterDoc) {
+		component, ok := diffComponentsByField[field]
+		if !ok {
+			component = field
+		}
+		diffs = appendDiffs(diffs, component, field, beforeDoc[field], afterDoc[field])
+	}
+	return diffs, nil
+}
+
+// DiffDeployedAppData returns the structured differences between the deployed app and the app data,
+// given the app diffs reported by the server. The server diffs are authoritative, so the deployed app
+// is only exported when there are any, and should the app data show none of them they are reported as is
+func DiffDeployedAppData(realmClient realm.Client, groupID, appID string, appData AppData, serverDiffs []string) ([]realm.Diff, error) {
+	if len(serverDiffs) == 0 {
+		return nil, nil
+	}
+
+	var configVersion realm.AppConfigVersion
+	if appData != nil {
+		configVersion = appData.ConfigVersion()
+	}
+
+	deployedData, err := ExportAppData(realmClient, groupID, appID, configVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs, err := DiffAppData(deployedData, appData)
+	if err != nil {
+		return nil, err
+	}
+	if len(diffs) > 0 {
+		return diffs, nil
+	}
+
+	diffs = make([]realm.Diff, len(serverDiffs))
+	for i, serverDiff := range serverDiffs {
+		diffs[i] = realm.Diff{Component: realm.DiffComponentApp, Operation: realm.DiffOperationModified, Path: serverDiff}
+	}
+	return diffs, nil
+}
+
+func appDataDocument(appData AppData) (map[string]interface{}, error) {
+	doc := map[string]interface{}{}
+	if appData == nil {
+		return doc, nil
+	}
+
+	data, err := json.Marshal(appData)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func appendDiffs(diffs []realm.Diff, component, path string, before, after interface{}) []realm.Diff {
+	// a missing list is treated as empty so that its elements are diffed individually
+	if _, ok := keyedElements(after); ok && before == nil {
+		before = []interface{}{}
+	}
+	if _, ok := keyedElements(before); ok && after == nil {
+		after = []interface{}{}
+	}
+
+	if before == nil && after == nil {
+		return diffs
+	}
+	if before == nil {
+		return append(diffs, realm.Diff{Component: component, Operation: realm.DiffOperationAdded, Path: path, After: after})
+	}
+	if after == nil {
+		return append(diffs, realm.Diff{Component: component, Operation: realm.DiffOperationRemoved, Path: path, Before: before})
+	}
+
+	if beforeMap, ok := before.(map[string]interface{}); ok {
+		if afterMap, ok := after.(map[string]interface{}); ok {
+			for _, key := range sortedKeys(beforeMap, afterMap) {
+				diffs = appendDiffs(diffs, component, path+diffPathSeparator+key, beforeMap[key], afterMap[key])
+			}
+			return diffs
+		}
+	}
+
+	if beforeElems, ok := keyedElements(before); ok {
+		if afterElems, ok := keyedElements(after); ok {
+			for _, key := range sortedKeys(beforeElems, afterElems) {
+				diffs = appendDiffs(diffs, component, path+diffPathSeparator+key, beforeElems[key], afterElems[key])
+			}
+			return diffs
+		}
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		diffs = append(diffs, realm.Diff{Component: component, Operation: realm.DiffOperationModified, Path: path, Before: before, After: after})
+	}
+	return diffs
+}
+
+// keyedElements indexes the elements of a list by their identifying key, returning false
+// if the value is not a list or if any of its elements cannot be uniquely identified
+func keyedElements(value interface{}) (map[string]interface{}, bool) {
+	elems, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	keyed := make(map[string]interface{}, len(elems))
+	for _, elem := range elems {
+		elemMap, ok := elem.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		key, ok := elementKey(elemMap)
+		if !ok {
+			return nil, false
+		}
+		if _, ok := keyed[key]; ok {
+			return nil, false
+		}
+		keyed[key] = elem
+	}
+	return keyed, true
+}
+
+func elementKey(elem map[string]interface{}) (string, bool) {
+	if name, ok := elem["name"].(string); ok && name != "" {
+		return name, true
+	}
+
+	// services, data sources and http endpoints are identified by their config
+	if config, ok := elem["config"].(map[string]interface{}); ok {
+		if name, ok := config["name"].(string); ok && name != "" {
+			return name, true
+		}
+	}
+
+	// rules are identified by their namespace
+	database, dbOK := elem["database"].(string)
+	collection, collOK := elem["collection"].(string)
+	if dbOK && collOK {
+		return database + "." + collection, true
+	}
+
+	return "", false
+}
+
+func sortedKeys(maps ...map[string]interface{}) []string {
+	set := map[string]struct{}{}
+	for _, m := range maps {
+		for key := range m {
+			set[key] = struct{}{}
+		}
+	}
+
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
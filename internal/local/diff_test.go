@@ -0,0 +1,134 @@
+package local
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestDiffAppData(t *testing.T) {
+	t.Run("should return no diffs for identical app data", func(t *testing.T) {
+		appData := &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			Name:          "eggcorn",
+			Values:        []map[string]interface{}{{"name": "value", "value": "eggcorn"}},
+		}}}
+
+		diffs, err := DiffAppData(appData, appData)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(diffs))
+	})
+
+	t.Run("should return the diffs of each component keyed by name", func(t *testing.T) {
+		before := &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			Name:          "eggcorn",
+			Location:      realm.LocationVirginia,
+			Values: []map[string]interface{}{
+				{"name": "removed", "value": "gone"},
+				{"name": "modified", "value": "before"},
+			},
+			Functions: &FunctionsStructure{
+				Configs: []map[string]interface{}{{"name": "func", "private": false}},
+				Sources: map[string]string{"func.js": "exports = () => 1"},
+			},
+			DataSources: []DataSourceStructure{{
+				Config: map[string]interface{}{"name": "mongodb-atlas", "type": "mongodb-atlas"},
+				Rules:  []map[string]interface{}{{"database": "db", "collection": "coll", "roles": []interface{}{}}},
+			}},
+		}}}
+		after := &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			Name:          "eggcorn",
+			Location:      realm.LocationOregon,
+			Values: []map[string]interface{}{
+				{"name": "modified", "value": "after"},
+				{"name": "added", "value": "new"},
+			},
+			Functions: &FunctionsStructure{
+				Configs: []map[string]interface{}{{"name": "func", "private": true}},
+				Sources: map[string]string{"func.js": "exports = () => 2"},
+			},
+			DataSources: []DataSourceStructure{{
+				Config: map[string]interface{}{"name": "mongodb-atlas", "type": "mongodb-atlas"},
+				Rules:  []map[string]interface{}{{"database": "db", "collection": "coll", "roles": []interface{}{"owner"}}},
+			}},
+		}}}
+
+		diffs, err := DiffAppData(before, after)
+		assert.Nil(t, err)
+		assert.Equal(t, []realm.Diff{
+			{
+				Component: ComponentDataSources,
+				Operation: realm.DiffOperationModified,
+				Path:      "data_sources/mongodb-atlas/rules/db.coll/roles",
+				Before:    []interface{}{},
+				After:     []interface{}{"owner"},
+			},
+			{
+				Component: ComponentFunctions,
+				Operation: realm.DiffOperationModified,
+				Path:      "functions/config/func/private",
+				Before:    false,
+				After:     true,
+			},
+			{
+				Component: ComponentFunctions,
+				Operation: realm.DiffOperationModified,
+				Path:      "functions/sources/func.js",
+				Before:    "exports = () => 1",
+				After:     "exports = () => 2",
+			},
+			{
+				Component: ComponentSettings,
+				Operation: realm.DiffOperationModified,
+				Path:      "location",
+				Before:    "US-VA",
+				After:     "US-OR",
+			},
+			{
+				Component: ComponentValues,
+				Operation: realm.DiffOperationAdded,
+				Path:      "values/added",
+				After:     map[string]interface{}{"name": "added", "value": "new"},
+			},
+			{
+				Component: ComponentValues,
+				Operation: realm.DiffOperationModified,
+				Path:      "values/modified/value",
+				Before:    "before",
+				After:     "after",
+			},
+			{
+				Component: ComponentValues,
+				Operation: realm.DiffOperationRemoved,
+				Path:      "values/removed",
+				Before:    map[string]interface{}{"name": "removed", "value": "gone"},
+			},
+		}, diffs)
+	})
+
+	t.Run("should diff the elements of a list missing from one side individually", func(t *testing.T) {
+		before := &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			Name:          "eggcorn",
+			Values:        []map[string]interface{}{{"name": "removed", "value": "gone"}},
+		}}}
+		after := &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			Name:          "eggcorn",
+		}}}
+
+		diffs, err := DiffAppData(before, after)
+		assert.Nil(t, err)
+		assert.Equal(t, []realm.Diff{
+			{
+				Component: ComponentValues,
+				Operation: realm.DiffOperationRemoved,
+				Path:      "values/removed",
+				Before:    map[string]interface{}{"name": "removed", "value": "gone"},
+			},
+		}, diffs)
+	})
+}
@@ -0,0 +1,119 @@
+package local
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// set of patterns which reference the values of an app environment
+var (
+	environmentReferencePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`%%environment\.values\.([A-Za-z0-9_-]+)`),
+		regexp.MustCompile(`context\.environment\.values\.([A-Za-z0-9_$]+)`),
+	}
+)
+
+// EnvironmentValues returns the values defined for the named environment of the app data
+func EnvironmentValues(appData AppData, environment string) (map[string]interface{}, error) {
+	environments, ok := appEnvironments(appData)
+	if !ok {
+		return nil, errUnsupportedAppData(appData)
+	}
+
+	env, ok := environments[environment+extJSON]
+	if !ok {
+		return nil, fmt.Errorf("failed to find environment '%s' in the app's %s directory", environment, NameEnvironments)
+	}
+
+	values, _ := env[NameValues].(map[string]interface{})
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	return values, nil
+}
+
+// EnvironmentReferences returns the names of the environment values referenced
+// throughout the app data, including its configs, rules and function sources
+func EnvironmentReferences(appData AppData) ([]string, error) {
+	data, err := json.Marshal(appData)
+	if err != nil {
+		return nil, err
+	}
+
+	set := map[string]struct{}{}
+	for _, pattern := range environmentReferencePatterns {
+		for _, match := range pattern.FindAllSubmatch(data, -1) {
+			set[string(match[1])] = struct{}{}
+		}
+	}
+
+	references := make([]string, 0, len(set))
+	for reference := range set {
+		references = append(references, reference)
+	}
+	sort.Strings(references)
+	return references, nil
+}
+
+// ResolveEnvironment checks that every environment value referenced by the app data is
+// defined for the named environment, returning the referenced values if so
+func ResolveEnvironment(appData AppData, environment string) (map[string]interface{}, error) {
+	values, err := EnvironmentValues(appData, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	references, err := EnvironmentReferences(appData)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make(map[string]interface{}, len(references))
+
+	var missing []string
+	for _, reference := range references {
+		value, ok := values[reference]
+		if !ok {
+			missing = append(missing, reference)
+			continue
+		}
+		resolved[reference] = value
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf(
+			"environment '%s' is missing values referenced by the app: %s",
+			environment,
+			strings.Join(missing, ", "),
+		)
+	}
+	return resolved, nil
+}
+
+// SetEnvironment sets the environment the app data is deployed with
+func SetEnvironment(appData AppData, environment string) error {
+	switch ad := appData.(type) {
+	case *AppRealmConfigJSON:
+		ad.Environment = environment
+	case *AppConfigJSON:
+		ad.Environment = environment
+	case *AppStitchJSON:
+		ad.Environment = environment
+	default:
+		return errUnsupportedAppData(appData)
+	}
+	return nil
+}
+
+func appEnvironments(appData AppData) (map[string]map[string]interface{}, bool) {
+	if v2, ok := appData.(*AppRealmConfigJSON); ok {
+		return v2.Environments, true
+	}
+	if v1, ok := appStructureV1(appData); ok {
+		return v1.Environments, true
+	}
+	return nil, false
+}
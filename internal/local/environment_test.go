@@ -0,0 +1,61 @@
+package local
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestResolveEnvironment(t *testing.T) {
+	newAppData := func() *AppRealmConfigJSON {
+		return &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			Environments: map[string]map[string]interface{}{
+				"development.json": {"values": map[string]interface{}{"clusterName": "dev", "apiKey": "abc"}},
+				"production.json":  {"values": map[string]interface{}{"clusterName": "prod"}},
+				"qa.json":          {},
+			},
+			DataSources: []DataSourceStructure{{
+				Config: map[string]interface{}{"name": "mongodb-atlas", "config": map[string]interface{}{"clusterName": "%(%%environment.values.clusterName)"}},
+			}},
+			Functions: &FunctionsStructure{
+				Sources: map[string]string{"func.js": "exports = () => context.environment.values.apiKey"},
+			},
+		}}}
+	}
+
+	t.Run("should return the values referenced by the app from the environment", func(t *testing.T) {
+		resolved, err := ResolveEnvironment(newAppData(), "development")
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"clusterName": "dev", "apiKey": "abc"}, resolved)
+	})
+
+	t.Run("should return an error when the environment is missing referenced values", func(t *testing.T) {
+		_, err := ResolveEnvironment(newAppData(), "production")
+		assert.Equal(t, errors.New("environment 'production' is missing values referenced by the app: apiKey"), err)
+
+		_, err = ResolveEnvironment(newAppData(), "qa")
+		assert.Equal(t, errors.New("environment 'qa' is missing values referenced by the app: apiKey, clusterName"), err)
+	})
+
+	t.Run("should return an error when the environment does not exist", func(t *testing.T) {
+		_, err := ResolveEnvironment(newAppData(), "staging")
+		assert.Equal(t, errors.New("failed to find environment 'staging' in the app's environments directory"), err)
+	})
+}
+
+func TestSetEnvironment(t *testing.T) {
+	t.Run("should set the environment of v2 app data", func(t *testing.T) {
+		appData := &AppRealmConfigJSON{}
+		assert.Nil(t, SetEnvironment(appData, "production"))
+		assert.Equal(t, "production", appData.Environment)
+	})
+
+	t.Run("should set the environment of v1 app data", func(t *testing.T) {
+		appData := &AppConfigJSON{}
+		assert.Nil(t, SetEnvironment(appData, "qa"))
+		assert.Equal(t, "qa", appData.Environment)
+	})
+}
@@ -0,0 +1,99 @@
+package local
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+var (
+	fingerprintIgnorePaths = map[string]struct{}{
+		nameNodeModules: {},
+		".git":          {},
+		NameBaseline:    {},
+	}
+)
+
+// Fingerprint returns a checksum of the path, size and modification time
+// of every file in the app directory, which will change whenever
+// a file is added to, removed from or modified within the app
+func Fingerprint(rootDir string) (string, error) {
+	hash := md5.New()
+	if err := walk(rootDir, fingerprintIgnorePaths, func(file os.FileInfo, path string) error {
+		relPath, err := filepath.Rel(rootDir, path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(hash, "%s:%d:%d\n", relPath, file.Size(), file.ModTime().UnixNano())
+		return nil
+	}); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// AppDataHash returns a checksum of the contents of the app data
+func AppDataHash(appData AppData) (string, error) {
+	data, err := MarshalJSON(appData)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// BundleHash returns a checksum of the contents of the local app which are pushed,
+// being its app data along with its dependencies archive and hosting files if included
+func BundleHash(app App, includeDependencies, includeHosting bool) (string, error) {
+	hash := sha256.New()
+
+	appDataHash, err := AppDataHash(app.AppData)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(hash, "%s:%s\n", NameRealmConfig, appDataHash)
+
+	if includeDependencies {
+		dependencies, err := FindAppDependencies(app.RootDir)
+		if err != nil {
+			return "", err
+		}
+		if err := hashFile(hash, app.RootDir, dependencies.ArchivePath); err != nil {
+			return "", err
+		}
+	}
+
+	if includeHosting {
+		hostingDir := filepath.Join(app.RootDir, NameHosting)
+		if err := walk(hostingDir, nil, func(file os.FileInfo, path string) error {
+			return hashFile(hash, app.RootDir, path)
+		}); err != nil {
+			return "", err
+		}
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+func hashFile(hash io.Writer, rootDir, path string) error {
+	relPath, err := filepath.Rel(rootDir, path)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	fileHash := sha256.New()
+	if _, err := io.Copy(fileHash, file); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(hash, "%s:%x\n", filepath.ToSlash(relPath), fileHash.Sum(nil))
+	return nil
+}
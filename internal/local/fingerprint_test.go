@@ -0,0 +1,120 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	tmpDir, teardown, err := u.NewTempDir("fingerprint")
+	assert.Nil(t, err)
+	defer teardown()
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, FileRealmConfig.String()), []byte("{}"), 0666))
+	assert.Nil(t, os.MkdirAll(filepath.Join(tmpDir, NameFunctions, nameNodeModules), os.ModePerm))
+
+	initial, err := Fingerprint(tmpDir)
+	assert.Nil(t, err)
+
+	t.Run("should not change when nothing has changed", func(t *testing.T) {
+		fingerprint, err := Fingerprint(tmpDir)
+		assert.Nil(t, err)
+		assert.Equal(t, initial, fingerprint)
+	})
+
+	t.Run("should not change when an ignored file is added", func(t *testing.T) {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, NameFunctions, nameNodeModules, "index.js"), []byte("module.exports = {}"), 0666))
+
+		fingerprint, err := Fingerprint(tmpDir)
+		assert.Nil(t, err)
+		assert.Equal(t, initial, fingerprint)
+	})
+
+	t.Run("should change when a file is added", func(t *testing.T) {
+		path := filepath.Join(tmpDir, NameFunctions, "test.js")
+		assert.Nil(t, ioutil.WriteFile(path, []byte("exports = () => 1"), 0666))
+
+		added, err := Fingerprint(tmpDir)
+		assert.Nil(t, err)
+		assert.NotEqual(t, initial, added, "fingerprint should change when a file is added")
+
+		t.Run("and change again when the file is modified", func(t *testing.T) {
+			modTime := time.Now().Add(time.Minute)
+			assert.Nil(t, ioutil.WriteFile(path, []byte("exports = () => 2"), 0666))
+			assert.Nil(t, os.Chtimes(path, modTime, modTime))
+
+			modified, err := Fingerprint(tmpDir)
+			assert.Nil(t, err)
+			assert.NotEqual(t, added, modified, "fingerprint should change when a file is modified")
+		})
+
+		t.Run("and change back when the file is removed", func(t *testing.T) {
+			assert.Nil(t, os.Remove(path))
+
+			removed, err := Fingerprint(tmpDir)
+			assert.Nil(t, err)
+			assert.Equal(t, initial, removed)
+		})
+	})
+}
+
+func TestBundleHash(t *testing.T) {
+	tmpDir, teardown, err := u.NewTempDir("bundle_hash")
+	assert.Nil(t, err)
+	defer teardown()
+
+	app := App{RootDir: tmpDir, Config: FileRealmConfig, AppData: &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+		ConfigVersion: realm.AppConfigVersion20210101,
+		Name:          "eggcorn",
+	}}}}
+
+	hostingFile := filepath.Join(tmpDir, NameHosting, NameFiles, "index.html")
+	assert.Nil(t, os.MkdirAll(filepath.Dir(hostingFile), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(hostingFile, []byte("<html></html>"), 0666))
+
+	initial, err := BundleHash(app, false, true)
+	assert.Nil(t, err)
+
+	t.Run("should not change when nothing has changed", func(t *testing.T) {
+		hash, err := BundleHash(app, false, true)
+		assert.Nil(t, err)
+		assert.Equal(t, initial, hash)
+	})
+
+	t.Run("should not change when only a file's modification time changes", func(t *testing.T) {
+		modTime := time.Now().Add(time.Minute)
+		assert.Nil(t, os.Chtimes(hostingFile, modTime, modTime))
+
+		hash, err := BundleHash(app, false, true)
+		assert.Nil(t, err)
+		assert.Equal(t, initial, hash)
+	})
+
+	t.Run("should change when a hosting file is modified", func(t *testing.T) {
+		assert.Nil(t, ioutil.WriteFile(hostingFile, []byte("<html><body></body></html>"), 0666))
+		defer ioutil.WriteFile(hostingFile, []byte("<html></html>"), 0666) //nolint:errcheck
+
+		hash, err := BundleHash(app, false, true)
+		assert.Nil(t, err)
+		assert.NotEqual(t, initial, hash, "hash should change when a hosting file is modified")
+	})
+
+	t.Run("should change when the app data is modified", func(t *testing.T) {
+		modified := app
+		modified.AppData = &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			Name:          "eggcorn-renamed",
+		}}}
+
+		hash, err := BundleHash(modified, false, true)
+		assert.Nil(t, err)
+		assert.NotEqual(t, initial, hash, "hash should change when the app data is modified")
+	})
+}
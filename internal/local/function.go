@@ -0,0 +1,374 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	defaultFunctionRunnerCommand = "node"
+
+	// environmentNone is the environment an app runs with when none is set
+	environmentNone = "no-environment"
+)
+
+// FunctionRunner runs the functions of a local app
+type FunctionRunner interface {
+	Run(ctx context.Context, run FunctionRun) (FunctionResult, error)
+}
+
+// NewFunctionRunner returns a function runner which runs functions in a local Node subprocess
+func NewFunctionRunner() (FunctionRunner, error) {
+	return newFunctionRunner(defaultFunctionRunnerCommand)
+}
+
+func newFunctionRunner(cmd string) (FunctionRunner, error) {
+	if _, err := exec.LookPath(cmd); err != nil {
+		return nil, fmt.Errorf("failed to find %s, which is required to run functions locally: %s", cmd, err)
+	}
+	return &externalFunctionRunner{cmd}, nil
+}
+
+// FunctionRun is a single run of a local function along with the context it runs with
+type FunctionRun struct {
+	Name              string                 `json:"name"`
+	Args              []interface{}          `json:"args"`
+	Functions         map[string]string      `json:"functions"`
+	FunctionsDir      string                 `json:"functions_dir"`
+	Values            map[string]interface{} `json:"values"`
+	Environment       string                 `json:"environment"`
+	EnvironmentValues map[string]interface{} `json:"environment_values"`
+	ServicesPath      string                 `json:"services_path,omitempty"`
+}
+
+// FunctionResult is the outcome of a local function run
+type FunctionResult struct {
+	Result interface{} `json:"result"`
+	Logs   []string    `json:"logs"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// NewFunctionRun prepares a run of the named function of the local app, which runs with the
+// app's values and the values of the provided environment, or the app's environment if none is provided
+func NewFunctionRun(app App, name string, args []interface{}, environment string) (FunctionRun, error) {
+	functions, err := FunctionSources(app.AppData)
+	if err != nil {
+		return FunctionRun{}, err
+	}
+	if _, ok := functions[name]; !ok {
+		return FunctionRun{}, fmt.Errorf("failed to find function '%s' in the local app", name)
+	}
+
+	values, err := functionValues(app.AppData)
+	if err != nil {
+		return FunctionRun{}, err
+	}
+
+	environmentValues := map[string]interface{}{}
+	if environment != "" {
+		if environmentValues, err = EnvironmentValues(app.AppData, environment); err != nil {
+			return FunctionRun{}, err
+		}
+	} else {
+		environment = appEnvironment(app.AppData)
+		if envValues, err := EnvironmentValues(app.AppData, environment); err == nil {
+			environmentValues = envValues
+		}
+	}
+
+	if args == nil {
+		args = []interface{}{}
+	}
+
+	return FunctionRun{
+		Name:              name,
+		Args:              args,
+		Functions:         functions,
+		FunctionsDir:      filepath.Join(app.RootDir, NameFunctions),
+		Values:            values,
+		Environment:       environment,
+		EnvironmentValues: environmentValues,
+	}, nil
+}
+
+// FunctionSources returns the sources of the app's functions keyed by function name
+func FunctionSources(appData AppData) (map[string]string, error) {
+	sources := map[string]string{}
+
+	if v2, ok := appData.(*AppRealmConfigJSON); ok {
+		if v2.Functions == nil {
+			return sources, nil
+		}
+		for path, src := range v2.Functions.Sources {
+			name := strings.TrimSuffix(filepath.ToSlash(path), extJS)
+			sources[name] = src
+		}
+		return sources, nil
+	}
+
+	v1, ok := appStructureV1(appData)
+	if !ok {
+		return nil, errUnsupportedAppData(appData)
+	}
+	for _, function := range v1.Functions {
+		config, _ := function[NameConfig].(map[string]interface{})
+		name, _ := config["name"].(string)
+		src, ok := function[NameSource].(string)
+		if name == "" || !ok {
+			continue
+		}
+		sources[name] = src
+	}
+	return sources, nil
+}
+
+// WriteFunction writes a new function to the local app in the layout of the app's config version
+// and returns the path of its source file. For config version 2 and above, the function config is
+// appended to the functions config file, otherwise the function is written to its own directory
+func WriteFunction(app App, config map[string]interface{}, source string) (string, error) {
+	name, _ := config["name"].(string)
+	if name == "" {
+		return "", errors.New("failed to write function: missing name")
+	}
+
+	dir := filepath.Join(app.RootDir, NameFunctions)
+
+	if _, ok := app.AppData.(*AppRealmConfigJSON); ok {
+		if err := ValidateFunctionName(name, true); err != nil {
+			return "", err
+		}
+
+		configPath := filepath.Join(dir, FileConfig.String())
+
+		configs, err := parseJSONArray(configPath)
+		if err != nil {
+			return "", err
+		}
+		for _, c := range configs {
+			if c["name"] == name {
+				return "", fmt.Errorf("function '%s' already exists in the local app", name)
+			}
+		}
+
+		sourcePath := filepath.Join(dir, filepath.FromSlash(name)+extJS)
+		if _, err := os.Stat(sourcePath); err == nil {
+			return "", fmt.Errorf("function source already exists at %s", sourcePath)
+		}
+
+		data, err := MarshalJSON(append(configs, config))
+		if err != nil {
+			return "", err
+		}
+		if err := WriteFile(sourcePath, 0666, strings.NewReader(source)); err != nil {
+			return "", err
+		}
+		if err := WriteFile(configPath, 0666, bytes.NewReader(data)); err != nil {
+			os.Remove(sourcePath) //nolint:errcheck
+			return "", err
+		}
+		return sourcePath, nil
+	}
+
+	if _, ok := appStructureV1(app.AppData); !ok {
+		return "", errUnsupportedAppData(app.AppData)
+	}
+	if err := ValidateFunctionName(name, false); err != nil {
+		return "", err
+	}
+
+	functionDir := filepath.Join(dir, name)
+	if _, err := os.Stat(functionDir); err == nil {
+		return "", fmt.Errorf("function '%s' already exists in the local app", name)
+	}
+
+	data, err := MarshalJSON(config)
+	if err != nil {
+		return "", err
+	}
+	if err := WriteFile(filepath.Join(functionDir, FileConfig.String()), 0666, bytes.NewReader(data)); err != nil {
+		return "", err
+	}
+
+	sourcePath := filepath.Join(functionDir, FileSource.String())
+	return sourcePath, WriteFile(sourcePath, 0666, strings.NewReader(source))
+}
+
+// ValidateFunctionName checks the function name refers to a path within the functions directory,
+// which may only be nested when functions are written by path as they are for config version 2 and above
+func ValidateFunctionName(name string, nested bool) error {
+	if filepath.IsAbs(name) || strings.HasPrefix(name, "/") || strings.HasPrefix(name, "\\") {
+		return fmt.Errorf("invalid function name '%s': must be a relative path", name)
+	}
+	if !nested && strings.ContainsAny(name, "/\\") {
+		return fmt.Errorf("invalid function name '%s': cannot contain path separators for apps of config version 1", name)
+	}
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if part == "." || part == ".." {
+			return fmt.Errorf("invalid function name '%s': cannot contain '.' or '..' path elements", name)
+		}
+	}
+	return nil
+}
+
+// functionValues returns the app's values keyed by name, leaving out any
+// value which refers to a secret since secrets cannot be read back locally
+func functionValues(appData AppData) (map[string]interface{}, error) {
+	var values []map[string]interface{}
+	if v2, ok := appData.(*AppRealmConfigJSON); ok {
+		values = v2.Values
+	} else if v1, ok := appStructureV1(appData); ok {
+		values = v1.Values
+	} else {
+		return nil, errUnsupportedAppData(appData)
+	}
+
+	out := make(map[string]interface{}, len(values))
+	for _, value := range values {
+		name, _ := value["name"].(string)
+		if name == "" {
+			continue
+		}
+		if fromSecret, _ := value["from_secret"].(bool); fromSecret {
+			continue
+		}
+		out[name] = value["value"]
+	}
+	return out, nil
+}
+
+func appEnvironment(appData AppData) string {
+	var environment string
+	switch ad := appData.(type) {
+	case *AppRealmConfigJSON:
+		environment = ad.Environment
+	case *AppConfigJSON:
+		environment = ad.Environment
+	case *AppStitchJSON:
+		environment = ad.Environment
+	}
+	if environment == "" {
+		return environmentNone
+	}
+	return environment
+}
+
+type externalFunctionRunner struct {
+	cmd string
+}
+
+func (r *externalFunctionRunner) Run(ctx context.Context, run FunctionRun) (FunctionResult, error) {
+	cmd := exec.CommandContext(ctx, r.cmd, "-e", functionRunnerScript)
+
+	in, inErr := json.Marshal(run)
+	if inErr != nil {
+		return FunctionResult{}, inErr
+	}
+	cmd.Stdin = bytes.NewReader(in)
+
+	out := new(bytes.Buffer)
+	cmd.Stdout = out
+
+	stderr := new(bytes.Buffer)
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return FunctionResult{}, errors.New(msg)
+		}
+		return FunctionResult{}, err
+	}
+
+	var res FunctionResult
+	if err := json.NewDecoder(out).Decode(&res); err != nil {
+		return FunctionResult{}, fmt.Errorf("failed to read function result: %s", err)
+	}
+	return res, nil
+}
+
+// functionRunnerScript runs a function with a stubbed context in a Node process. It reads the
+// function run from stdin and writes the function result to stdout. Functions are evaluated in
+// their own sandbox, where they set `exports` as they do when deployed
+const functionRunnerScript = `
+const fs = require('fs');
+const path = require('path');
+const vm = require('vm');
+const Module = require('module');
+
+const run = JSON.parse(fs.readFileSync(0, 'utf8'));
+
+const logs = [];
+const log = (...args) => logs.push(args.map((arg) => typeof arg === 'string' ? arg : JSON.stringify(arg)).join(' '));
+const functionConsole = { log, info: log, warn: log, error: log, debug: log };
+
+const functionRequire = Module.createRequire(path.join(path.resolve(run.functions_dir), 'index.js'));
+
+let services = {
+  get: (name) => {
+    throw new Error("cannot use service '" + name + "' when running locally without a services mock");
+  },
+};
+if (run.services_path) {
+  const mock = require(path.resolve(run.services_path));
+  services = {
+    get: (name) => {
+      if (typeof mock.get === 'function') {
+        return mock.get(name);
+      }
+      if (!(name in mock)) {
+        throw new Error("service '" + name + "' is not provided by the services mock");
+      }
+      return mock[name];
+    },
+  };
+}
+
+const context = {
+  values: { get: (name) => run.values[name] },
+  environment: { tag: run.environment, values: run.environment_values },
+  services,
+  functions: { execute: (name, ...args) => execute(name, args) },
+};
+
+function execute(name, args) {
+  const source = run.functions[name];
+  if (source === undefined) {
+    throw new Error("failed to find function '" + name + "' in the local app");
+  }
+
+  const sandbox = {
+    exports: undefined,
+    module: { exports: undefined },
+    context,
+    console: functionConsole,
+    require: functionRequire,
+    Buffer,
+    setTimeout,
+    clearTimeout,
+    setInterval,
+    clearInterval,
+  };
+  vm.runInNewContext(source, sandbox, { filename: name });
+
+  const fn = sandbox.exports || sandbox.module.exports;
+  if (typeof fn !== 'function') {
+    throw new Error("function '" + name + "' does not export a function");
+  }
+  return fn(...args);
+}
+
+const write = (res) => process.stdout.write(JSON.stringify(res));
+
+Promise.resolve()
+  .then(() => execute(run.name, run.args))
+  .then(
+    (result) => write({ result: result === undefined ? null : result, logs }),
+    (err) => write({ result: null, logs, error: err && err.message ? err.message : String(err) })
+  );
+`
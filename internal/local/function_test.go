@@ -0,0 +1,236 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestFunctionSources(t *testing.T) {
+	t.Run("should key the function sources of a v2 app by their path without extension", func(t *testing.T) {
+		sources, err := FunctionSources(&AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			Functions: &FunctionsStructure{Sources: map[string]string{
+				"eggcorn.js": "exports = function() { return 1 };",
+				"foo/bar.js": "exports = function() { return 2 };",
+			}},
+		}}})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{
+			"eggcorn": "exports = function() { return 1 };",
+			"foo/bar": "exports = function() { return 2 };",
+		}, sources)
+	})
+
+	t.Run("should key the function sources of a v1 app by their configured name", func(t *testing.T) {
+		sources, err := FunctionSources(&AppConfigJSON{AppDataV1{AppStructureV1{
+			Functions: []map[string]interface{}{
+				{NameConfig: map[string]interface{}{"name": "eggcorn"}, NameSource: "exports = function() { return 1 };"},
+			},
+		}}})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"eggcorn": "exports = function() { return 1 };"}, sources)
+	})
+}
+
+func TestWriteFunction(t *testing.T) {
+	t.Run("should append the function config of a v2 app and write its source", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("function")
+		assert.Nil(t, err)
+		defer teardown()
+
+		assert.Nil(t, WriteFile(filepath.Join(tmpDir, FileRealmConfig.String()), 0666, strings.NewReader(`{"config_version":20210101,"name":"eggcorn"}`)))
+		assert.Nil(t, WriteFile(filepath.Join(tmpDir, NameFunctions, FileConfig.String()), 0666, strings.NewReader(`[{"name":"bar","private":true}]`)))
+		assert.Nil(t, WriteFile(filepath.Join(tmpDir, NameFunctions, "bar.js"), 0666, strings.NewReader("exports = function() { return 1 };")))
+
+		app, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		path, err := WriteFunction(app, map[string]interface{}{"name": "foo/baz", "private": false}, "exports = function() { return 2 };")
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(tmpDir, NameFunctions, "foo", "baz.js"), path)
+
+		app, err = LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		v2, ok := app.AppData.(*AppRealmConfigJSON)
+		assert.True(t, ok, "expected a v2 app")
+		assert.Equal(t, []map[string]interface{}{
+			{"name": "bar", "private": true},
+			{"name": "foo/baz", "private": false},
+		}, v2.Functions.Configs)
+
+		sources, err := FunctionSources(app.AppData)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{
+			"bar":     "exports = function() { return 1 };",
+			"foo/baz": "exports = function() { return 2 };",
+		}, sources)
+
+		_, err = WriteFunction(app, map[string]interface{}{"name": "bar"}, "")
+		assert.Equal(t, errors.New("function 'bar' already exists in the local app"), err)
+	})
+
+	t.Run("should write the function config and source of a v1 app to their own directory", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("function")
+		assert.Nil(t, err)
+		defer teardown()
+
+		assert.Nil(t, WriteFile(filepath.Join(tmpDir, FileConfig.String()), 0666, strings.NewReader(`{"config_version":20200603,"name":"eggcorn"}`)))
+
+		app, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		path, err := WriteFunction(app, map[string]interface{}{"name": "foo", "private": true}, "exports = function() { return 2 };")
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(tmpDir, NameFunctions, "foo", FileSource.String()), path)
+
+		app, err = LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		sources, err := FunctionSources(app.AppData)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"foo": "exports = function() { return 2 };"}, sources)
+
+		_, err = WriteFunction(app, map[string]interface{}{"name": "foo"}, "")
+		assert.Equal(t, errors.New("function 'foo' already exists in the local app"), err)
+	})
+
+	t.Run("should return an error when writing a function without a name", func(t *testing.T) {
+		_, err := WriteFunction(App{}, map[string]interface{}{}, "")
+		assert.Equal(t, errors.New("failed to write function: missing name"), err)
+	})
+
+	t.Run("should return an error when writing a function of a v1 app with a nested name", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("function")
+		assert.Nil(t, err)
+		defer teardown()
+
+		app := App{RootDir: tmpDir, AppData: &AppConfigJSON{}}
+
+		_, err = WriteFunction(app, map[string]interface{}{"name": "foo/bar"}, "")
+		assert.Equal(t, errors.New("invalid function name 'foo/bar': cannot contain path separators for apps of config version 1"), err)
+	})
+
+	t.Run("should return an error when writing a function of a v2 app outside of the functions directory", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("function")
+		assert.Nil(t, err)
+		defer teardown()
+
+		app := App{RootDir: tmpDir, AppData: &AppRealmConfigJSON{}}
+
+		_, err = WriteFunction(app, map[string]interface{}{"name": "foo/../../bar"}, "")
+		assert.Equal(t, errors.New("invalid function name 'foo/../../bar': cannot contain '.' or '..' path elements"), err)
+	})
+}
+
+func TestValidateFunctionName(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		nested      bool
+		expectedErr error
+	}{
+		{name: "foo", nested: false},
+		{name: "foo/bar", nested: true},
+		{name: "foo/bar", nested: false, expectedErr: errors.New("invalid function name 'foo/bar': cannot contain path separators for apps of config version 1")},
+		{name: `foo\bar`, nested: false, expectedErr: errors.New(`invalid function name 'foo\bar': cannot contain path separators for apps of config version 1`)},
+		{name: "/foo", nested: true, expectedErr: errors.New("invalid function name '/foo': must be a relative path")},
+		{name: "..", nested: false, expectedErr: errors.New("invalid function name '..': cannot contain '.' or '..' path elements")},
+		{name: "foo/./bar", nested: true, expectedErr: errors.New("invalid function name 'foo/./bar': cannot contain '.' or '..' path elements")},
+		{name: `foo\..\bar`, nested: true, expectedErr: errors.New(`invalid function name 'foo\..\bar': cannot contain '.' or '..' path elements`)},
+	} {
+		t.Run(fmt.Sprintf("should validate the function name '%s' when nested is %t", tc.name, tc.nested), func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, ValidateFunctionName(tc.name, tc.nested))
+		})
+	}
+}
+
+func TestNewFunctionRun(t *testing.T) {
+	app := App{
+		RootDir: "/path/to/app",
+		AppData: &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			Environment: "development",
+			Environments: map[string]map[string]interface{}{
+				"development.json": {"values": map[string]interface{}{"url": "http://localhost"}},
+				"production.json":  {"values": map[string]interface{}{"url": "https://example.com"}},
+			},
+			Values: []map[string]interface{}{
+				{"name": "greeting", "value": "hello", "from_secret": false},
+				{"name": "token", "value": "token_secret", "from_secret": true},
+			},
+			Functions: &FunctionsStructure{Sources: map[string]string{
+				"eggcorn.js": "exports = function() { return 1 };",
+			}},
+		}}},
+	}
+
+	t.Run("should prepare the run with the app values and environment", func(t *testing.T) {
+		run, err := NewFunctionRun(app, "eggcorn", nil, "")
+		assert.Nil(t, err)
+		assert.Equal(t, FunctionRun{
+			Name:              "eggcorn",
+			Args:              []interface{}{},
+			Functions:         map[string]string{"eggcorn": "exports = function() { return 1 };"},
+			FunctionsDir:      filepath.Join("/path/to/app", NameFunctions),
+			Values:            map[string]interface{}{"greeting": "hello"},
+			Environment:       "development",
+			EnvironmentValues: map[string]interface{}{"url": "http://localhost"},
+		}, run)
+	})
+
+	t.Run("should prepare the run with the specified environment", func(t *testing.T) {
+		run, err := NewFunctionRun(app, "eggcorn", []interface{}{1}, "production")
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{1}, run.Args)
+		assert.Equal(t, "production", run.Environment)
+		assert.Equal(t, map[string]interface{}{"url": "https://example.com"}, run.EnvironmentValues)
+	})
+
+	t.Run("should return an error when the function does not exist", func(t *testing.T) {
+		_, err := NewFunctionRun(app, "missing", nil, "")
+		assert.Equal(t, errors.New("failed to find function 'missing' in the local app"), err)
+	})
+
+	t.Run("should return an error when the specified environment does not exist", func(t *testing.T) {
+		_, err := NewFunctionRun(app, "eggcorn", nil, "qa")
+		assert.Equal(t, errors.New("failed to find environment 'qa' in the app's environments directory"), err)
+	})
+}
+
+func TestFunctionRunner(t *testing.T) {
+	if _, err := exec.LookPath(defaultFunctionRunnerCommand); err != nil {
+		t.Skip("node is required to run functions locally")
+	}
+
+	runner, err := NewFunctionRunner()
+	assert.Nil(t, err)
+
+	t.Run("should run the function and capture its logs", func(t *testing.T) {
+		res, err := runner.Run(context.Background(), FunctionRun{
+			Name: "eggcorn",
+			Args: []interface{}{2},
+			Functions: map[string]string{
+				"eggcorn": `exports = async function(n) { console.log("n is", n); return context.functions.execute("double", n); };`,
+				"double":  `exports = function(n) { return n * 2; };`,
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, FunctionResult{Result: 4.0, Logs: []string{"n is 2"}}, res)
+	})
+
+	t.Run("should report an error thrown by the function", func(t *testing.T) {
+		res, err := runner.Run(context.Background(), FunctionRun{
+			Name:      "eggcorn",
+			Args:      []interface{}{},
+			Functions: map[string]string{"eggcorn": `exports = function() { throw new Error("something bad happened"); };`},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, FunctionResult{Logs: []string{}, Error: "something bad happened"}, res)
+	})
+}
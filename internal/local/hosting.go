@@ -103,9 +103,51 @@ func (d HostingDiffs) Strings() []string {
 	return diffs
 }
 
+// Diffs returns the hosting diffs as a list of structured diffs
+func (d HostingDiffs) Diffs() []realm.Diff {
+	diffs := make([]realm.Diff, 0, d.Size())
+	for _, added := range d.Added {
+		diffs = append(diffs, realm.Diff{
+			Component: realm.DiffComponentHosting,
+			Operation: realm.DiffOperationAdded,
+			Path:      added.FilePath,
+			After:     newHostingDiffValue(added),
+		})
+	}
+	for _, deleted := range d.Deleted {
+		diffs = append(diffs, realm.Diff{
+			Component: realm.DiffComponentHosting,
+			Operation: realm.DiffOperationRemoved,
+			Path:      deleted.FilePath,
+			Before:    newHostingDiffValue(deleted),
+		})
+	}
+	for _, modified := range d.Modified {
+		diffs = append(diffs, realm.Diff{
+			Component: realm.DiffComponentHosting,
+			Operation: realm.DiffOperationModified,
+			Path:      modified.FilePath,
+			Before:    newHostingDiffValue(modified.Previous),
+			After:     newHostingDiffValue(modified.HostingAsset),
+		})
+	}
+	return diffs
+}
+
+// hostingDiffValue is the state of a hosting asset as represented in a structured diff
+type hostingDiffValue struct {
+	Hash  string                       `json:"hash,omitempty"`
+	Attrs realm.HostingAssetAttributes `json:"attrs,omitempty"`
+}
+
+func newHostingDiffValue(asset realm.HostingAsset) hostingDiffValue {
+	return hostingDiffValue{asset.FileHash, asset.Attrs}
+}
+
 // ModifiedHostingAsset is a Realm hosting asset with information about its local file changes
 type ModifiedHostingAsset struct {
 	realm.HostingAsset
+	Previous      realm.HostingAsset
 	BodyModified  bool
 	AttrsModified bool
 }
@@ -128,11 +170,52 @@ func (h Hosting) Diffs(cachePath, appID string, appAssets []realm.HostingAsset)
 	}
 
 	if assetCache.dirty {
-		if err := assetCache.save(); err != nil {
+		if err := assetCache.saveApp(appID); err != nil {
 			return HostingDiffs{}, err
 		}
 	}
 
+	return diffHostingAssets(localAssets, appAssets), nil
+}
+
+// DiffsWith returns the local Realm app's hosting asset differences
+// with the hosting assets of another local Realm app
+func (h Hosting) DiffsWith(other Hosting) (HostingDiffs, error) {
+	localAssets, err := h.assets()
+	if err != nil {
+		return HostingDiffs{}, err
+	}
+
+	otherAssets, err := other.assets()
+	if err != nil {
+		return HostingDiffs{}, err
+	}
+
+	return diffHostingAssets(localAssets, otherAssets), nil
+}
+
+// assets returns the hosting assets found on disk without the use of an asset cache
+func (h Hosting) assets() ([]realm.HostingAsset, error) {
+	if h.RootDir == "" {
+		return nil, nil
+	}
+
+	if _, err := os.Stat(filepath.Join(h.RootDir, NameFiles)); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	assets, err := readMetadata(h.RootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return walkFiles(h.RootDir, "", assets, &hostingAssetCache{entries: map[string]map[string]realm.HostingAssetData{}})
+}
+
+func diffHostingAssets(localAssets, appAssets []realm.HostingAsset) HostingDiffs {
 	var added, deleted []realm.HostingAsset
 	var modified []ModifiedHostingAsset
 
@@ -152,6 +235,7 @@ func (h Hosting) Diffs(cachePath, appID string, appAssets []realm.HostingAsset)
 			if bodyModified || attrsModified {
 				modified = append(modified, ModifiedHostingAsset{
 					HostingAsset:  localAsset,
+					Previous:      appAsset,
 					BodyModified:  bodyModified,
 					AttrsModified: attrsModified,
 				})
@@ -165,7 +249,7 @@ func (h Hosting) Diffs(cachePath, appID string, appAssets []realm.HostingAsset)
 		deleted = append(deleted, appAsset)
 	}
 
-	return HostingDiffs{added, deleted, modified}, nil
+	return HostingDiffs{added, deleted, modified}
 }
 
 // UploadHostingAssets uploads the hosting assets based on the diff of that file
@@ -514,7 +598,17 @@ type hostingAssetCache struct {
 	entries map[string]map[string]realm.HostingAssetData
 }
 
+// hostingAssetCacheMu guards the hosting asset cache file, which is shared by the apps of a workspace
+var hostingAssetCacheMu sync.Mutex
+
 func loadHostingAssetCache(cachePath string) (*hostingAssetCache, error) {
+	hostingAssetCacheMu.Lock()
+	defer hostingAssetCacheMu.Unlock()
+
+	return readHostingAssetCache(cachePath)
+}
+
+func readHostingAssetCache(cachePath string) (*hostingAssetCache, error) {
 	cache := hostingAssetCache{path: cachePath, entries: map[string]map[string]realm.HostingAssetData{}}
 
 	file, err := os.Open(cachePath)
@@ -532,6 +626,24 @@ func loadHostingAssetCache(cachePath string) (*hostingAssetCache, error) {
 	return &cache, nil
 }
 
+// saveApp saves the entries of the app to the cache file, keeping those of any other app
+// which were saved since the cache was loaded
+func (cache hostingAssetCache) saveApp(appID string) error {
+	hostingAssetCacheMu.Lock()
+	defer hostingAssetCacheMu.Unlock()
+
+	latest, err := readHostingAssetCache(cache.path)
+	if err != nil {
+		return err
+	}
+	if latest.entries == nil {
+		latest.entries = map[string]map[string]realm.HostingAssetData{}
+	}
+	latest.entries[appID] = cache.entries[appID]
+
+	return latest.save()
+}
+
 func (cache hostingAssetCache) save() error {
 	dir := filepath.Dir(cache.path)
 	if err := mkdir(dir); err != nil {
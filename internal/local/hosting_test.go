@@ -3,6 +3,7 @@ package local
 import (
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/10gen/realm-cli/internal/cloud/realm"
@@ -85,6 +86,89 @@ func TestHostingFind(t *testing.T) {
 			assert.Equal(t, expectedModified[0].Attrs, hostingDiffs.Modified[0].Attrs)
 			assert.Equal(t, expectedModified[0].AttrsModified, hostingDiffs.Modified[0].AttrsModified)
 			assert.Equal(t, expectedModified[0].BodyModified, hostingDiffs.Modified[0].BodyModified)
+			assert.Equal(t, realm.HostingAsset{
+				HostingAssetData: realm.HostingAssetData{FilePath: "/404.html", FileHash: "7785338f982ac81219ef449f4943ec89"},
+				Attrs:            realm.HostingAssetAttributes{{api.HeaderContentLanguage, "en-US"}},
+			}, hostingDiffs.Modified[0].Previous)
 		})
 	})
 }
+
+func TestHostingDiffsAssetCache(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+
+	hosting, err := FindAppHosting(filepath.Join(wd, "testdata", "hosting"))
+	assert.Nil(t, err)
+
+	t.Run("should keep the cached assets of every app when diffed at once", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("hosting")
+		assert.Nil(t, err)
+		defer teardown()
+
+		cachePath := filepath.Join(tmpDir, NameAssetCache, "test.json")
+
+		appIDs := []string{"app1", "app2", "app3", "app4"}
+
+		var wg sync.WaitGroup
+		errs := make([]error, len(appIDs))
+		for i, appID := range appIDs {
+			wg.Add(1)
+			go func(i int, appID string) {
+				defer wg.Done()
+				_, errs[i] = hosting.Diffs(cachePath, appID, nil)
+			}(i, appID)
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			assert.Nil(t, err)
+		}
+
+		cache, err := loadHostingAssetCache(cachePath)
+		assert.Nil(t, err)
+		for _, appID := range appIDs {
+			assert.True(t, len(cache.entries[appID]) > 0, "expected the assets of %s to be cached", appID)
+		}
+	})
+}
+
+func TestHostingDiffsDiffs(t *testing.T) {
+	hostingDiffs := HostingDiffs{
+		Added:   []realm.HostingAsset{{HostingAssetData: realm.HostingAssetData{FilePath: "/new.html", FileHash: "new"}}},
+		Deleted: []realm.HostingAsset{{HostingAssetData: realm.HostingAssetData{FilePath: "/old.html", FileHash: "old"}}},
+		Modified: []ModifiedHostingAsset{{
+			HostingAsset: realm.HostingAsset{
+				HostingAssetData: realm.HostingAssetData{FilePath: "/index.html", FileHash: "after"},
+				Attrs:            realm.HostingAssetAttributes{{api.HeaderContentType, "text/html"}},
+			},
+			Previous: realm.HostingAsset{
+				HostingAssetData: realm.HostingAssetData{FilePath: "/index.html", FileHash: "before"},
+			},
+			BodyModified:  true,
+			AttrsModified: true,
+		}},
+	}
+
+	assert.Equal(t, []realm.Diff{
+		{
+			Component: realm.DiffComponentHosting,
+			Operation: realm.DiffOperationAdded,
+			Path:      "/new.html",
+			After:     hostingDiffValue{Hash: "new"},
+		},
+		{
+			Component: realm.DiffComponentHosting,
+			Operation: realm.DiffOperationRemoved,
+			Path:      "/old.html",
+			Before:    hostingDiffValue{Hash: "old"},
+		},
+		{
+			Component: realm.DiffComponentHosting,
+			Operation: realm.DiffOperationModified,
+			Path:      "/index.html",
+			Before:    hostingDiffValue{Hash: "before"},
+			After:     hostingDiffValue{Hash: "after", Attrs: realm.HostingAssetAttributes{{Name: api.HeaderContentType, Value: "text/html"}}},
+		},
+	}, hostingDiffs.Diffs())
+}
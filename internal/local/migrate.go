@@ -0,0 +1,275 @@
+package local
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+)
+
+// set of service types which are represented differently in the v2 Realm app structure
+const (
+	serviceTypeAtlas    = "mongodb-atlas"
+	serviceTypeDataLake = "mongodb-datalake"
+	serviceTypeHTTP     = "http"
+)
+
+// NameUnmigrated is the directory which a migration moves the v1 app sources
+// holding anything that could not be carried over to
+const NameUnmigrated = "unmigrated"
+
+// CheckMigration returns an error if the local Realm app cannot be migrated to the config version
+func CheckMigration(app App, configVersion realm.AppConfigVersion) error {
+	if _, ok := appStructureV1(app.AppData); !ok || configVersion != realm.AppConfigVersion20210101 {
+		return fmt.Errorf(
+			"cannot migrate app from config version %s to config version %s",
+			app.ConfigVersion(),
+			configVersion,
+		)
+	}
+	return nil
+}
+
+// MigrateApp rewrites the v1 local Realm app in the v2 app structure, returning
+// descriptions of anything from the app which could not be carried over.
+// The v2 app is written before any v1 app source is removed, and the v1 app sources
+// holding anything which could not be carried over are moved to NameUnmigrated
+func MigrateApp(app App, configVersion realm.AppConfigVersion) (App, []string, error) {
+	if err := CheckMigration(app, configVersion); err != nil {
+		return App{}, nil, err
+	}
+	appV1, _ := appStructureV1(app.AppData)
+
+	data, unmapped := MigrateAppDataV1(*appV1)
+
+	migrated := App{app.RootDir, FileRealmConfig, &AppRealmConfigJSON{AppDataV2{data}}}
+
+	// the app config is written last, so the app is still loaded as a v1 app if this fails
+	if err := migrated.Write(); err != nil {
+		return App{}, nil, fmt.Errorf("failed to write migrated app, the v1 app was left in place: %w", err)
+	}
+
+	replaced, kept, err := obsoleteSourcesV1(app, *appV1, data)
+	if err != nil {
+		return App{}, nil, err
+	}
+
+	for _, path := range replaced {
+		if err := os.RemoveAll(filepath.Join(app.RootDir, path)); err != nil {
+			return App{}, nil, err
+		}
+	}
+
+	for _, path := range kept {
+		dst := filepath.Join(app.RootDir, NameUnmigrated, path)
+		if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+			return App{}, nil, err
+		}
+		if err := os.Rename(filepath.Join(app.RootDir, path), dst); err != nil {
+			return App{}, nil, err
+		}
+	}
+
+	return migrated, unmapped, nil
+}
+
+// obsoleteSourcesV1 returns the paths of the v1 app sources which are no longer read by the v2 app,
+// split between those which were fully carried over and those which hold anything that was not
+func obsoleteSourcesV1(app App, v1 AppStructureV1, v2 AppStructureV2) ([]string, []string, error) {
+	var replaced, kept []string
+
+	if _, unmapped := migrateSecurity(v1.Security); len(unmapped) > 0 {
+		kept = append(kept, app.Config.String())
+	} else {
+		replaced = append(replaced, app.Config.String())
+	}
+
+	if len(v1.AuthProviders) > 0 {
+		if v2.Auth != nil && len(v2.Auth.Providers) == len(v1.AuthProviders) {
+			replaced = append(replaced, NameAuthProviders)
+		} else {
+			kept = append(kept, NameAuthProviders)
+		}
+	}
+
+	migratedFunctions := map[string]struct{}{}
+	if v2.Functions != nil {
+		for _, config := range v2.Functions.Configs {
+			name, _ := config["name"].(string)
+			migratedFunctions[name] = struct{}{}
+		}
+	}
+
+	functions := directoryWalker{path: filepath.Join(app.RootDir, NameFunctions), onlyDirs: true}
+	if err := functions.walk(func(file os.FileInfo, path string) error {
+		if file.Name() == nameNodeModules {
+			return nil
+		}
+		config, err := parseJSON(filepath.Join(path, FileConfig.String()))
+		if err != nil {
+			return err
+		}
+		name, _ := config["name"].(string)
+
+		dir := filepath.Join(NameFunctions, file.Name())
+		if _, ok := migratedFunctions[name]; ok {
+			replaced = append(replaced, dir)
+		} else {
+			kept = append(kept, dir)
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	services := directoryWalker{path: filepath.Join(app.RootDir, NameServices), onlyDirs: true}
+	if err := services.walk(func(file os.FileInfo, path string) error {
+		config, err := parseJSON(filepath.Join(path, FileConfig.String()))
+		if err != nil {
+			return err
+		}
+		name, _ := config["name"].(string)
+		svcType, _ := config["type"].(string)
+
+		dir := filepath.Join(NameServices, file.Name())
+		switch svcType {
+		case serviceTypeAtlas, serviceTypeDataLake:
+			webhooks, err := ioutil.ReadDir(filepath.Join(path, NameIncomingWebhooks))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if len(webhooks) > 0 {
+				kept = append(kept, dir)
+			} else {
+				replaced = append(replaced, dir)
+			}
+		case serviceTypeHTTP:
+			replaced = append(replaced, dir)
+		default:
+			// services are written to the same place in the v2 app structure,
+			// so only a directory which is not named after its service is replaced
+			if file.Name() != name {
+				replaced = append(replaced, dir)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return replaced, kept, nil
+}
+
+// MigrateAppDataV1 converts the v1 Realm app structure into its v2 equivalent, returning
+// descriptions of anything from the v1 app structure which could not be carried over
+func MigrateAppDataV1(v1 AppStructureV1) (AppStructureV2, []string) {
+	v2 := AppStructureV2{
+		ConfigVersion:   realm.AppConfigVersion20210101,
+		ID:              v1.ID,
+		Name:            v1.Name,
+		Location:        v1.Location,
+		DeploymentModel: v1.DeploymentModel,
+		Environment:     v1.Environment,
+		Environments:    v1.Environments,
+		Values:          v1.Values,
+		Triggers:        v1.Triggers,
+		Secrets:         v1.Secrets,
+		Hosting:         v1.Hosting,
+	}
+
+	var unmapped []string
+
+	origins, unmappedSecurity := migrateSecurity(v1.Security)
+	v2.AllowedRequestOrigins = origins
+	unmapped = append(unmapped, unmappedSecurity...)
+
+	if v1.CustomUserDataConfig != nil || len(v1.AuthProviders) > 0 {
+		v2.Auth = &AuthStructure{
+			CustomUserData: v1.CustomUserDataConfig,
+			Providers:      map[string]interface{}{},
+		}
+		for i, provider := range v1.AuthProviders {
+			name, ok := provider["name"].(string)
+			if !ok || name == "" {
+				unmapped = append(unmapped, fmt.Sprintf("auth provider at position %d has no name", i+1))
+				continue
+			}
+			v2.Auth.Providers[name] = provider
+		}
+	}
+
+	if v1.Sync != nil {
+		v2.Sync = &SyncStructure{Config: v1.Sync}
+	}
+
+	if len(v1.Functions) > 0 {
+		v2.Functions = &FunctionsStructure{
+			Configs: make([]map[string]interface{}, 0, len(v1.Functions)),
+			Sources: make(map[string]string, len(v1.Functions)),
+		}
+		for i, function := range v1.Functions {
+			config, _ := function[NameConfig].(map[string]interface{})
+			name, _ := config["name"].(string)
+			src, ok := function[NameSource].(string)
+			if name == "" || !ok {
+				unmapped = append(unmapped, fmt.Sprintf("function at position %d is missing its config or source", i+1))
+				continue
+			}
+			v2.Functions.Configs = append(v2.Functions.Configs, config)
+			v2.Functions.Sources[name+extJS] = src
+		}
+	}
+
+	if v1.GraphQL.Config != nil || v1.GraphQL.CustomResolvers != nil {
+		graphQL := v1.GraphQL
+		v2.GraphQL = &graphQL
+	}
+
+	for _, svc := range v1.Services {
+		svcType, _ := svc.Config["type"].(string)
+		switch svcType {
+		case serviceTypeAtlas, serviceTypeDataLake:
+			if len(svc.IncomingWebhooks) > 0 {
+				unmapped = append(unmapped, fmt.Sprintf("incoming webhooks of data source '%s'", svc.Config["name"]))
+			}
+			v2.DataSources = append(v2.DataSources, DataSourceStructure{svc.Config, svc.Rules})
+		case serviceTypeHTTP:
+			v2.HTTPEndpoints = append(v2.HTTPEndpoints, HTTPEndpointStructure(svc))
+		default:
+			v2.Services = append(v2.Services, svc)
+		}
+	}
+
+	// sort to ensure determinism
+	sort.Strings(unmapped)
+
+	return v2, unmapped
+}
+
+// migrateSecurity returns the allowed request origins of the v1 security settings,
+// along with descriptions of the security settings which have no v2 equivalent
+func migrateSecurity(security map[string]interface{}) ([]string, []string) {
+	var origins, unmapped []string
+	for key, value := range security {
+		if key != "allowed_request_origins" {
+			unmapped = append(unmapped, fmt.Sprintf("security setting '%s'", key))
+			continue
+		}
+		values, ok := value.([]interface{})
+		if !ok {
+			unmapped = append(unmapped, fmt.Sprintf("security setting '%s'", key))
+			continue
+		}
+		for _, origin := range values {
+			if o, ok := origin.(string); ok {
+				origins = append(origins, o)
+			} else {
+				unmapped = append(unmapped, fmt.Sprintf("allowed request origin '%v'", origin))
+			}
+		}
+	}
+	return origins, unmapped
+}
@@ -0,0 +1,241 @@
+package local
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestMigrateAppDataV1(t *testing.T) {
+	t.Run("should map the v1 app structure to its v2 equivalent", func(t *testing.T) {
+		v2, unmapped := MigrateAppDataV1(AppStructureV1{
+			ConfigVersion:        realm.AppConfigVersion20200603,
+			ID:                   "eggcorn-abcde",
+			Name:                 "eggcorn",
+			Location:             realm.LocationVirginia,
+			DeploymentModel:      realm.DeploymentModelGlobal,
+			Security:             map[string]interface{}{"allowed_request_origins": []interface{}{"http://localhost:8080"}},
+			Hosting:              map[string]interface{}{"enabled": true},
+			CustomUserDataConfig: map[string]interface{}{"enabled": false},
+			Sync:                 map[string]interface{}{"development_mode_enabled": false},
+			AuthProviders:        []map[string]interface{}{{"name": "api-key", "type": "api-key", "disabled": true}},
+			Functions: []map[string]interface{}{{
+				NameConfig: map[string]interface{}{"name": "test", "private": true},
+				NameSource: "exports = () => 1",
+			}},
+			Values: []map[string]interface{}{{"name": "value", "value": "eggcorn"}},
+			Services: []ServiceStructure{
+				{
+					Config: map[string]interface{}{"name": "mongodb-atlas", "type": "mongodb-atlas"},
+					Rules:  []map[string]interface{}{{"database": "db", "collection": "coll"}},
+				},
+				{
+					Config:           map[string]interface{}{"name": "api", "type": "http"},
+					IncomingWebhooks: []map[string]interface{}{{"config": map[string]interface{}{"name": "hook"}}},
+					Rules:            []map[string]interface{}{{"name": "rule"}},
+				},
+				{Config: map[string]interface{}{"name": "twilio", "type": "twilio"}},
+			},
+		})
+
+		assert.Equal(t, AppStructureV2{
+			ConfigVersion:         realm.AppConfigVersion20210101,
+			ID:                    "eggcorn-abcde",
+			Name:                  "eggcorn",
+			Location:              realm.LocationVirginia,
+			DeploymentModel:       realm.DeploymentModelGlobal,
+			AllowedRequestOrigins: []string{"http://localhost:8080"},
+			Hosting:               map[string]interface{}{"enabled": true},
+			Auth: &AuthStructure{
+				CustomUserData: map[string]interface{}{"enabled": false},
+				Providers: map[string]interface{}{
+					"api-key": map[string]interface{}{"name": "api-key", "type": "api-key", "disabled": true},
+				},
+			},
+			Sync: &SyncStructure{Config: map[string]interface{}{"development_mode_enabled": false}},
+			Functions: &FunctionsStructure{
+				Configs: []map[string]interface{}{{"name": "test", "private": true}},
+				Sources: map[string]string{"test.js": "exports = () => 1"},
+			},
+			Values: []map[string]interface{}{{"name": "value", "value": "eggcorn"}},
+			DataSources: []DataSourceStructure{{
+				Config: map[string]interface{}{"name": "mongodb-atlas", "type": "mongodb-atlas"},
+				Rules:  []map[string]interface{}{{"database": "db", "collection": "coll"}},
+			}},
+			HTTPEndpoints: []HTTPEndpointStructure{{
+				Config:           map[string]interface{}{"name": "api", "type": "http"},
+				IncomingWebhooks: []map[string]interface{}{{"config": map[string]interface{}{"name": "hook"}}},
+				Rules:            []map[string]interface{}{{"name": "rule"}},
+			}},
+			Services: []ServiceStructure{{Config: map[string]interface{}{"name": "twilio", "type": "twilio"}}},
+		}, v2)
+		assert.Equal(t, 0, len(unmapped))
+	})
+
+	t.Run("should report what could not be mapped to the v2 app structure", func(t *testing.T) {
+		_, unmapped := MigrateAppDataV1(AppStructureV1{
+			ConfigVersion: realm.AppConfigVersion20200603,
+			Security:      map[string]interface{}{"sessions": map[string]interface{}{}},
+			AuthProviders: []map[string]interface{}{{"type": "anon-user"}},
+			Functions:     []map[string]interface{}{{NameSource: "exports = () => 1"}},
+			Services: []ServiceStructure{{
+				Config:           map[string]interface{}{"name": "mongodb-atlas", "type": "mongodb-atlas"},
+				IncomingWebhooks: []map[string]interface{}{{"config": map[string]interface{}{"name": "hook"}}},
+			}},
+		})
+
+		assert.Equal(t, []string{
+			"auth provider at position 1 has no name",
+			"function at position 1 is missing its config or source",
+			"incoming webhooks of data source 'mongodb-atlas'",
+			"security setting 'sessions'",
+		}, unmapped)
+	})
+}
+
+func TestMigrateApp(t *testing.T) {
+	t.Run("should rewrite a v1 app on disk in the v2 app structure", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("migrate")
+		assert.Nil(t, err)
+		defer teardown()
+
+		assert.Nil(t, App{tmpDir, FileConfig, &AppConfigJSON{AppDataV1{AppStructureV1{
+			ConfigVersion:   realm.AppConfigVersion20200603,
+			Name:            "eggcorn",
+			Location:        realm.LocationVirginia,
+			DeploymentModel: realm.DeploymentModelGlobal,
+			AuthProviders:   []map[string]interface{}{{"name": "api-key", "type": "api-key", "disabled": true}},
+			Functions: []map[string]interface{}{{
+				NameConfig: map[string]interface{}{"name": "test", "private": true},
+				NameSource: "exports = () => 1",
+			}},
+		}}}}.Write())
+
+		app, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		migrated, unmapped, err := MigrateApp(app, realm.AppConfigVersion20210101)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(unmapped))
+		assert.Equal(t, FileRealmConfig, migrated.Config)
+
+		for _, name := range []string{FileConfig.String(), NameAuthProviders, filepath.Join(NameFunctions, "test")} {
+			_, err := os.Stat(filepath.Join(tmpDir, name))
+			assert.True(t, os.IsNotExist(err), "expected %s to be removed", name)
+		}
+
+		reloaded, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		v2, ok := reloaded.AppData.(*AppRealmConfigJSON)
+		assert.True(t, ok, "expected app data to be v2")
+		assert.Equal(t, realm.AppConfigVersion20210101, v2.ConfigVersion())
+		assert.Equal(t, &AuthStructure{
+			Providers: map[string]interface{}{
+				"api-key": map[string]interface{}{"name": "api-key", "type": "api-key", "disabled": true},
+			},
+		}, v2.Auth)
+		assert.Equal(t, &FunctionsStructure{
+			Configs: []map[string]interface{}{{"name": "test", "private": true}},
+			Sources: map[string]string{"test.js": "exports = () => 1"},
+		}, v2.Functions)
+	})
+
+	t.Run("should move the v1 app sources which could not be migrated rather than remove them", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("migrate")
+		assert.Nil(t, err)
+		defer teardown()
+
+		assert.Nil(t, App{tmpDir, FileConfig, &AppConfigJSON{AppDataV1{AppStructureV1{
+			ConfigVersion: realm.AppConfigVersion20200603,
+			Name:          "eggcorn",
+			Hosting:       map[string]interface{}{"enabled": true},
+			Security:      map[string]interface{}{"sessions": map[string]interface{}{"max_age": 60}},
+			Services: []ServiceStructure{
+				{
+					Config:           map[string]interface{}{"name": "mongodb-atlas", "type": "mongodb-atlas"},
+					IncomingWebhooks: []map[string]interface{}{{"name": "hook", NameSource: "exports = () => 1"}},
+				},
+				{Config: map[string]interface{}{"name": "api", "type": "http"}},
+				{Config: map[string]interface{}{"name": "twilio", "type": "twilio"}},
+			},
+		}}}}.Write())
+
+		app, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		_, unmapped, err := MigrateApp(app, realm.AppConfigVersion20210101)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"incoming webhooks of data source 'mongodb-atlas'",
+			"security setting 'sessions'",
+		}, unmapped)
+
+		for _, name := range []string{FileConfig.String(), filepath.Join(NameServices, "mongodb-atlas"), filepath.Join(NameServices, "api")} {
+			_, err := os.Stat(filepath.Join(tmpDir, name))
+			assert.True(t, os.IsNotExist(err), "expected %s to be moved or removed", name)
+		}
+
+		for _, name := range []string{
+			FileConfig.String(),
+			filepath.Join(NameServices, "mongodb-atlas", NameIncomingWebhooks, "hook", FileSource.String()),
+		} {
+			_, err := os.Stat(filepath.Join(tmpDir, NameUnmigrated, name))
+			assert.Nil(t, err)
+		}
+		_, err = os.Stat(filepath.Join(tmpDir, NameUnmigrated, NameServices, "api"))
+		assert.True(t, os.IsNotExist(err), "expected the migrated http service to be removed")
+
+		reloaded, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		v2, ok := reloaded.AppData.(*AppRealmConfigJSON)
+		assert.True(t, ok, "expected app data to be v2")
+		assert.Equal(t, map[string]interface{}{"enabled": true}, v2.Hosting)
+		assert.Equal(t, []ServiceStructure{{Config: map[string]interface{}{"name": "twilio", "type": "twilio"}}}, v2.Services)
+		assert.Equal(t, 1, len(v2.DataSources))
+		assert.Equal(t, 1, len(v2.HTTPEndpoints))
+	})
+
+	t.Run("should leave the v1 app in place when the migrated app fails to be written", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("migrate")
+		assert.Nil(t, err)
+		defer teardown()
+
+		assert.Nil(t, App{tmpDir, FileConfig, &AppConfigJSON{AppDataV1{AppStructureV1{
+			ConfigVersion: realm.AppConfigVersion20200603,
+			Name:          "eggcorn",
+			AuthProviders: []map[string]interface{}{{"name": "api-key", "type": "api-key"}},
+		}}}}.Write())
+
+		// a file in place of the auth directory fails the write of the migrated app
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, NameAuth), nil, 0666))
+
+		app, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+
+		_, _, err = MigrateApp(app, realm.AppConfigVersion20210101)
+		assert.NotNil(t, err)
+
+		reloaded, err := LoadApp(tmpDir)
+		assert.Nil(t, err)
+		assert.Equal(t, FileConfig, reloaded.Config)
+
+		v1, ok := appStructureV1(reloaded.AppData)
+		assert.True(t, ok, "expected app data to be v1")
+		assert.Equal(t, []map[string]interface{}{{"name": "api-key", "type": "api-key"}}, v1.AuthProviders)
+	})
+
+	t.Run("should return an error when the app is already in the v2 app structure", func(t *testing.T) {
+		app := App{AppData: &AppRealmConfigJSON{AppDataV2{AppStructureV2{ConfigVersion: realm.AppConfigVersion20210101}}}}
+
+		_, _, err := MigrateApp(app, realm.AppConfigVersion20210101)
+		assert.Equal(t, errors.New("cannot migrate app from config version 20210101 to config version 20210101"), err)
+	})
+}
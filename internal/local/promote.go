@@ -0,0 +1,78 @@
+package local
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+)
+
+// AppPromotion is the set of target specific pieces which are swapped into
+// the app data of one Realm app in order to promote it to another. The environment
+// replaces that of the app data, even when empty, unless KeepEnvironment is set
+type AppPromotion struct {
+	Target          realm.App
+	Environment     string
+	KeepEnvironment bool
+	Clusters        map[string]string
+	Values          map[string]interface{}
+}
+
+// PromoteAppData swaps the target specific pieces of the promotion into the app data
+func PromoteAppData(appData AppData, promotion AppPromotion) error {
+	var values []map[string]interface{}
+
+	switch ad := appData.(type) {
+	case *AppRealmConfigJSON:
+		v2 := &ad.AppStructureV2
+		v2.ID = promotion.Target.ClientAppID
+		v2.Name = promotion.Target.Name
+		v2.Location = promotion.Target.Location
+		v2.DeploymentModel = promotion.Target.DeploymentModel
+		if !promotion.KeepEnvironment {
+			v2.Environment = promotion.Environment
+		}
+		values = v2.Values
+	default:
+		v1, ok := appStructureV1(appData)
+		if !ok {
+			return errUnsupportedAppData(appData)
+		}
+		v1.ID = promotion.Target.ClientAppID
+		v1.Name = promotion.Target.Name
+		v1.Location = promotion.Target.Location
+		v1.DeploymentModel = promotion.Target.DeploymentModel
+		if !promotion.KeepEnvironment {
+			v1.Environment = promotion.Environment
+		}
+		values = v1.Values
+	}
+
+	RelinkDataSources(appData, promotion.Clusters)
+
+	return promoteValues(values, promotion.Values)
+}
+
+func promoteValues(values []map[string]interface{}, promoted map[string]interface{}) error {
+	found := make(map[string]struct{}, len(promoted))
+	for _, value := range values {
+		name, _ := value["name"].(string)
+		if target, ok := promoted[name]; ok {
+			value["value"] = target
+			found[name] = struct{}{}
+		}
+	}
+
+	var missing []string
+	for name := range promoted {
+		if _, ok := found[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("failed to find values in app to promote: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
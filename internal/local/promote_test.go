@@ -0,0 +1,105 @@
+package local
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestPromoteAppData(t *testing.T) {
+	target := realm.App{
+		ClientAppID: "prod-abcde",
+		Name:        "prod",
+		AppMeta:     realm.AppMeta{Location: realm.LocationIreland, DeploymentModel: realm.DeploymentModelLocal},
+	}
+
+	t.Run("should swap the target specific pieces into v2 app data", func(t *testing.T) {
+		appData := &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion:   realm.AppConfigVersion20210101,
+			ID:              "dev-abcde",
+			Name:            "dev",
+			Location:        realm.LocationVirginia,
+			DeploymentModel: realm.DeploymentModelGlobal,
+			Environment:     "development",
+			DataSources: []DataSourceStructure{
+				{Config: map[string]interface{}{"name": "mongodb-atlas", "config": map[string]interface{}{"clusterName": "dev-cluster"}}},
+				{Config: map[string]interface{}{"name": "other", "config": map[string]interface{}{"clusterName": "other-cluster"}}},
+			},
+			Values: []map[string]interface{}{
+				{"name": "apiUrl", "value": "https://dev"},
+				{"name": "untouched", "value": "eggcorn"},
+			},
+		}}}
+
+		assert.Nil(t, PromoteAppData(appData, AppPromotion{
+			Target:      target,
+			Environment: "production",
+			Clusters:    map[string]string{"dev-cluster": "prod-cluster"},
+			Values:      map[string]interface{}{"apiUrl": "https://prod"},
+		}))
+
+		assert.Equal(t, &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion:   realm.AppConfigVersion20210101,
+			ID:              "prod-abcde",
+			Name:            "prod",
+			Location:        realm.LocationIreland,
+			DeploymentModel: realm.DeploymentModelLocal,
+			Environment:     "production",
+			DataSources: []DataSourceStructure{
+				{Config: map[string]interface{}{"name": "mongodb-atlas", "config": map[string]interface{}{"clusterName": "prod-cluster"}}},
+				{Config: map[string]interface{}{"name": "other", "config": map[string]interface{}{"clusterName": "other-cluster"}}},
+			},
+			Values: []map[string]interface{}{
+				{"name": "apiUrl", "value": "https://prod"},
+				{"name": "untouched", "value": "eggcorn"},
+			},
+		}}}, appData)
+	})
+
+	t.Run("should swap the target specific pieces into v1 app data", func(t *testing.T) {
+		appData := &AppConfigJSON{AppDataV1{AppStructureV1{
+			ConfigVersion: realm.AppConfigVersion20200603,
+			ID:            "dev-abcde",
+			Name:          "dev",
+			Environment:   "development",
+			Services: []ServiceStructure{
+				{Config: map[string]interface{}{"name": "mongodb-atlas", "type": "mongodb-atlas", "config": map[string]interface{}{"clusterName": "dev-cluster"}}},
+			},
+		}}}
+
+		assert.Nil(t, PromoteAppData(appData, AppPromotion{
+			Target:          target,
+			KeepEnvironment: true,
+			Clusters:        map[string]string{"dev-cluster": "prod-cluster"},
+		}))
+
+		assert.Equal(t, "prod-abcde", appData.ID())
+		assert.Equal(t, "prod", appData.Name())
+		assert.Equal(t, "development", appData.Environment)
+		assert.Equal(t, map[string]interface{}{"clusterName": "prod-cluster"}, appData.Services[0].Config["config"])
+	})
+
+	t.Run("should clear the environment when the target has none", func(t *testing.T) {
+		appData := &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			ID:            "dev-abcde",
+			Name:          "dev",
+			Environment:   "development",
+		}}}
+
+		assert.Nil(t, PromoteAppData(appData, AppPromotion{Target: target}))
+		assert.Equal(t, "", appData.Environment)
+	})
+
+	t.Run("should return an error when a mapped value does not exist in the app", func(t *testing.T) {
+		appData := &AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			Values:        []map[string]interface{}{{"name": "apiUrl", "value": "https://dev"}},
+		}}}
+
+		err := PromoteAppData(appData, AppPromotion{Target: target, Values: map[string]interface{}{"apiUrl": "", "b": 1, "a": 2}})
+		assert.Equal(t, errors.New("failed to find values in app to promote: a, b"), err)
+	})
+}
@@ -0,0 +1,90 @@
+package local
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+const (
+	keySecrets      = "secrets"
+	keySecretConfig = "secret_config"
+	keyFromSecret   = "from_secret"
+	keyValue        = "value"
+)
+
+// SecretReferences returns the names of the secrets referenced throughout the app data,
+// by the secret configs of auth providers and services, by the secrets file and by values backed by secrets
+func SecretReferences(appData AppData) ([]string, error) {
+	data, err := json.Marshal(appData)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	set := map[string]struct{}{}
+	collectSecretReferences(doc, set)
+	if root, ok := doc.(map[string]interface{}); ok {
+		collectSecretsFileReferences(root[keySecrets], set)
+	}
+
+	references := make([]string, 0, len(set))
+	for reference := range set {
+		references = append(references, reference)
+	}
+	sort.Strings(references)
+	return references, nil
+}
+
+func collectSecretReferences(doc interface{}, set map[string]struct{}) {
+	switch d := doc.(type) {
+	case map[string]interface{}:
+		if secretConfig, ok := d[keySecretConfig].(map[string]interface{}); ok {
+			for _, v := range secretConfig {
+				addSecretReference(v, set)
+			}
+		}
+		if fromSecret, _ := d[keyFromSecret].(bool); fromSecret {
+			addSecretReference(d[keyValue], set)
+		}
+		for _, v := range d {
+			collectSecretReferences(v, set)
+		}
+	case []interface{}:
+		for _, v := range d {
+			collectSecretReferences(v, set)
+		}
+	}
+}
+
+// collectSecretsFileReferences adds the secret names held by the secrets file,
+// which maps the auth providers and services to the secret of each of their fields
+func collectSecretsFileReferences(secrets interface{}, set map[string]struct{}) {
+	s, _ := secrets.(map[string]interface{})
+	for _, byName := range s {
+		b, _ := byName.(map[string]interface{})
+		for _, byField := range b {
+			f, _ := byField.(map[string]interface{})
+			for _, name := range f {
+				addSecretReference(name, set)
+			}
+		}
+	}
+}
+
+// addSecretReference adds the secret name, or list of secret names, to the set
+func addSecretReference(v interface{}, set map[string]struct{}) {
+	switch name := v.(type) {
+	case string:
+		if name != "" {
+			set[name] = struct{}{}
+		}
+	case []interface{}:
+		for _, n := range name {
+			addSecretReference(n, set)
+		}
+	}
+}
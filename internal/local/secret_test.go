@@ -0,0 +1,64 @@
+package local
+
+import (
+	"testing"
+
+	"github.com/10gen/realm-cli/internal/cloud/realm"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestSecretReferences(t *testing.T) {
+	t.Run("should find the secrets referenced by the v2 app data", func(t *testing.T) {
+		references, err := SecretReferences(&AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			Auth: &AuthStructure{Providers: map[string]interface{}{
+				"oauth2-google": map[string]interface{}{
+					"name":          "oauth2-google",
+					"secret_config": map[string]interface{}{"clientSecret": "googleSecret"},
+				},
+			}},
+			DataSources: []DataSourceStructure{{
+				Config: map[string]interface{}{"name": "lake", "secret_config": map[string]interface{}{"password": "lakePassword"}},
+			}},
+			Values: []map[string]interface{}{
+				{"name": "linked", "value": "valueSecret", "from_secret": true},
+				{"name": "plain", "value": "eggcorn", "from_secret": false},
+				{"name": "again", "value": "googleSecret", "from_secret": true},
+			},
+		}}})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"googleSecret", "lakePassword", "valueSecret"}, references)
+	})
+
+	t.Run("should find the secrets referenced by the v1 app data", func(t *testing.T) {
+		references, err := SecretReferences(&AppConfigJSON{AppDataV1{AppStructureV1{
+			ConfigVersion: realm.AppConfigVersion20200603,
+			AuthProviders: []map[string]interface{}{
+				{"name": "custom-token", "secret_config": map[string]interface{}{"signingKeys": []interface{}{"signingKey1", "signingKey2"}}},
+			},
+			Services: []ServiceStructure{{
+				Config: map[string]interface{}{"name": "twilio", "secret_config": map[string]interface{}{"auth_token": "twilioToken"}},
+			}},
+		}}})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"signingKey1", "signingKey2", "twilioToken"}, references)
+	})
+
+	t.Run("should find the secrets referenced by the secrets file", func(t *testing.T) {
+		references, err := SecretReferences(&AppRealmConfigJSON{AppDataV2{AppStructureV2{
+			ConfigVersion: realm.AppConfigVersion20210101,
+			Secrets: &SecretsStructure{
+				AuthProviders: map[string]map[string]string{"oauth2-apple": {"clientSecret": "appleSecret"}},
+				Services:      map[string]map[string]string{"github": {"clientSecret": "githubSecret"}},
+			},
+		}}})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"appleSecret", "githubSecret"}, references)
+	})
+
+	t.Run("should find no secrets when none are referenced", func(t *testing.T) {
+		references, err := SecretReferences(&AppRealmConfigJSON{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{}, references)
+	})
+}
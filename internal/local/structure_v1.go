@@ -136,6 +136,7 @@ func (a AppDataV1) ConfigData() ([]byte, error) {
 		DeploymentModel:      a.DeploymentModel(),
 		Environment:          a.Environment,
 		Security:             a.Security,
+		Hosting:              a.Hosting,
 		CustomUserDataConfig: a.CustomUserDataConfig,
 		Sync:                 a.Sync,
 	}
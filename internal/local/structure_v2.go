@@ -163,6 +163,12 @@ func (a *AppDataV2) LoadData(rootDir string) error {
 	}
 	a.HTTPEndpoints = httpEndpoints
 
+	hosting, err := parseJSON(filepath.Join(rootDir, NameHosting, FileConfig.String()))
+	if err != nil {
+		return err
+	}
+	a.Hosting = hosting
+
 	return nil
 }
 
@@ -412,9 +418,27 @@ func (a AppDataV2) WriteData(rootDir string) error {
 	if err := writeTriggers(rootDir, a.Triggers); err != nil {
 		return err
 	}
+	if err := writeHostingV2(rootDir, a.Hosting); err != nil {
+		return err
+	}
 	return nil
 }
 
+func writeHostingV2(rootDir string, hosting map[string]interface{}) error {
+	if len(hosting) == 0 {
+		return nil
+	}
+	data, err := MarshalJSON(hosting)
+	if err != nil {
+		return err
+	}
+	return WriteFile(
+		filepath.Join(rootDir, NameHosting, FileConfig.String()),
+		0666,
+		bytes.NewReader(data),
+	)
+}
+
 func writeFunctionsV2(rootDir string, functions *FunctionsStructure) error {
 	var sources map[string]string
 	configs := []map[string]interface{}{}
@@ -0,0 +1,72 @@
+package local
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// FindValue returns the value with the given name from the app's values directory
+func FindValue(rootDir, name string) (map[string]interface{}, bool, error) {
+	_, value, err := findValueFile(rootDir, name)
+	if err != nil {
+		return nil, false, err
+	}
+	return value, value != nil, nil
+}
+
+// WriteValue writes the value to the app's values directory,
+// replacing the file of any existing value with the same name
+func WriteValue(rootDir string, value map[string]interface{}) error {
+	name, _ := value["name"].(string)
+	if name == "" {
+		return errors.New("failed to write value: missing name")
+	}
+
+	path, _, err := findValueFile(rootDir, name)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		path = filepath.Join(rootDir, NameValues, name+extJSON)
+	}
+
+	data, err := MarshalJSON(value)
+	if err != nil {
+		return err
+	}
+	return WriteFile(path, 0666, bytes.NewReader(data))
+}
+
+func findValueFile(rootDir, name string) (string, map[string]interface{}, error) {
+	dir := filepath.Join(rootDir, NameValues)
+	if _, err := os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+
+	var valuePath string
+	var value map[string]interface{}
+
+	dw := directoryWalker{path: dir, onlyFiles: true}
+	if err := dw.walk(func(file os.FileInfo, path string) error {
+		if value != nil {
+			return nil
+		}
+		v, err := parseJSON(path)
+		if err != nil {
+			return err
+		}
+		if v["name"] == name {
+			valuePath = path
+			value = v
+		}
+		return nil
+	}); err != nil {
+		return "", nil, err
+	}
+	return valuePath, value, nil
+}
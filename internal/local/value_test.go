@@ -0,0 +1,58 @@
+package local
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestValue(t *testing.T) {
+	t.Run("should find a value by its name", func(t *testing.T) {
+		value, ok, err := FindValue("testdata/full_project", "VALUE")
+		assert.Nil(t, err)
+		assert.True(t, ok, "expected value to be found")
+		assert.Equal(t, map[string]interface{}{"name": "VALUE", "value": "eggcorn", "from_secret": false}, value)
+	})
+
+	t.Run("should not find a value that does not exist", func(t *testing.T) {
+		_, ok, err := FindValue("testdata/full_project", "MISSING")
+		assert.Nil(t, err)
+		assert.True(t, !ok, "expected value to not be found")
+
+		_, ok, err = FindValue("testdata/functions", "VALUE")
+		assert.Nil(t, err)
+		assert.True(t, !ok, "expected value to not be found")
+	})
+
+	t.Run("should write a value in place of the existing file of the same name", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("value")
+		assert.Nil(t, err)
+		defer teardown()
+
+		assert.Nil(t, WriteFile(filepath.Join(tmpDir, NameValues, "other.json"), 0666, strings.NewReader(`{"name":"eggcorn","value":1}`)))
+
+		assert.Nil(t, WriteValue(tmpDir, map[string]interface{}{"name": "eggcorn", "value": 2}))
+		assert.Nil(t, WriteValue(tmpDir, map[string]interface{}{"name": "acorn", "value": 3}))
+
+		files, err := ioutil.ReadDir(filepath.Join(tmpDir, NameValues))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(files))
+
+		value, ok, err := FindValue(tmpDir, "eggcorn")
+		assert.Nil(t, err)
+		assert.True(t, ok, "expected value to be found")
+		assert.Equal(t, map[string]interface{}{"name": "eggcorn", "value": float64(2)}, value)
+
+		_, err = ioutil.ReadFile(filepath.Join(tmpDir, NameValues, "acorn.json"))
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return an error when writing a value without a name", func(t *testing.T) {
+		err := WriteValue("", map[string]interface{}{"value": 1})
+		assert.Equal(t, "failed to write value: missing name", err.Error())
+	})
+}
@@ -0,0 +1,97 @@
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// DefaultWorkspaceConcurrency is the number of workspace apps run at once
+// when the workspace manifest does not specify otherwise
+const DefaultWorkspaceConcurrency = 4
+
+// Workspace is a set of local Realm apps described by a workspace manifest
+type Workspace struct {
+	RootDir     string         `json:"-"`
+	Concurrency int            `json:"concurrency,omitempty"`
+	Apps        []WorkspaceApp `json:"apps"`
+}
+
+// WorkspaceApp is a local Realm app of a workspace along with the remote app it is linked to
+type WorkspaceApp struct {
+	Path    string `json:"path"`
+	App     string `json:"app,omitempty"`
+	Project string `json:"project,omitempty"`
+}
+
+// AppDir returns the directory of the workspace app
+func (w Workspace) AppDir(app WorkspaceApp) string {
+	if filepath.IsAbs(app.Path) {
+		return app.Path
+	}
+	return filepath.Join(w.RootDir, app.Path)
+}
+
+// MaxConcurrency returns the number of workspace apps which may be run at once
+func (w Workspace) MaxConcurrency() int {
+	if w.Concurrency > 0 {
+		return w.Concurrency
+	}
+	return DefaultWorkspaceConcurrency
+}
+
+// FindWorkspace searches upwards for a workspace manifest and returns the workspace it
+// describes, a boolean indicating if one was found, and any error that occurs
+func FindWorkspace(path string) (Workspace, bool, error) {
+	wd, wdErr := filepath.Abs(path)
+	if wdErr != nil {
+		return Workspace{}, false, wdErr
+	}
+
+	for i := 0; i < maxDirectoryContainSearchDepth; i++ {
+		data, err := ioutil.ReadFile(filepath.Join(wd, FileWorkspace.String()))
+		if err == nil {
+			workspace, err := parseWorkspace(wd, data)
+			return workspace, err == nil, err
+		}
+		if !os.IsNotExist(err) {
+			return Workspace{}, false, err
+		}
+		if wd == "/" {
+			break
+		}
+		wd = filepath.Clean(filepath.Join(wd, ".."))
+	}
+
+	return Workspace{}, false, nil
+}
+
+func parseWorkspace(rootDir string, data []byte) (Workspace, error) {
+	path := filepath.Join(rootDir, FileWorkspace.String())
+
+	var workspace Workspace
+	if err := json.Unmarshal(data, &workspace); err != nil {
+		return Workspace{}, fmt.Errorf("failed to parse workspace manifest at %s: %s", path, err)
+	}
+	workspace.RootDir = rootDir
+
+	if len(workspace.Apps) == 0 {
+		return Workspace{}, errors.New("workspace manifest lists no apps")
+	}
+
+	paths := make(map[string]struct{}, len(workspace.Apps))
+	for i, app := range workspace.Apps {
+		if app.Path == "" {
+			return Workspace{}, fmt.Errorf("workspace app at position %d has no path", i+1)
+		}
+		dir := workspace.AppDir(app)
+		if _, ok := paths[dir]; ok {
+			return Workspace{}, fmt.Errorf("workspace app '%s' is listed more than once", app.Path)
+		}
+		paths[dir] = struct{}{}
+	}
+	return workspace, nil
+}
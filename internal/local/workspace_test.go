@@ -0,0 +1,88 @@
+package local
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	u "github.com/10gen/realm-cli/internal/utils/test"
+	"github.com/10gen/realm-cli/internal/utils/test/assert"
+)
+
+func TestFindWorkspace(t *testing.T) {
+	t.Run("should find the workspace manifest from within a workspace app", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("workspace")
+		assert.Nil(t, err)
+		defer teardown()
+
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, FileWorkspace.String()), []byte(`{
+			"concurrency": 2,
+			"apps": [
+				{"path": "apps/auth", "app": "auth-abcde", "project": "groupID"},
+				{"path": "apps/sync"}
+			]
+		}`), 0666))
+
+		appDir := filepath.Join(tmpDir, "apps", "auth")
+		assert.Nil(t, os.MkdirAll(appDir, os.ModePerm))
+
+		workspace, ok, err := FindWorkspace(appDir)
+		assert.Nil(t, err)
+		assert.True(t, ok, "expected to find the workspace")
+		assert.Equal(t, Workspace{
+			RootDir:     tmpDir,
+			Concurrency: 2,
+			Apps: []WorkspaceApp{
+				{Path: "apps/auth", App: "auth-abcde", Project: "groupID"},
+				{Path: "apps/sync"},
+			},
+		}, workspace)
+		assert.Equal(t, appDir, workspace.AppDir(workspace.Apps[0]))
+		assert.Equal(t, 2, workspace.MaxConcurrency())
+	})
+
+	t.Run("should not find a workspace where there is no manifest", func(t *testing.T) {
+		tmpDir, teardown, err := u.NewTempDir("workspace")
+		assert.Nil(t, err)
+		defer teardown()
+
+		_, ok, err := FindWorkspace(tmpDir)
+		assert.Nil(t, err)
+		assert.True(t, !ok, "expected to not find a workspace")
+	})
+
+	for _, tc := range []struct {
+		description string
+		manifest    string
+		expectedErr string
+	}{
+		{
+			description: "should return an error when the manifest has no apps",
+			manifest:    `{"apps": []}`,
+			expectedErr: "workspace manifest lists no apps",
+		},
+		{
+			description: "should return an error when an app has no path",
+			manifest:    `{"apps": [{"path": "auth"}, {"app": "sync-abcde"}]}`,
+			expectedErr: "workspace app at position 2 has no path",
+		},
+		{
+			description: "should return an error when an app is listed more than once",
+			manifest:    `{"apps": [{"path": "auth"}, {"path": "./auth"}]}`,
+			expectedErr: "workspace app './auth' is listed more than once",
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			tmpDir, teardown, err := u.NewTempDir("workspace")
+			assert.Nil(t, err)
+			defer teardown()
+
+			assert.Nil(t, ioutil.WriteFile(filepath.Join(tmpDir, FileWorkspace.String()), []byte(tc.manifest), 0666))
+
+			_, _, err = FindWorkspace(tmpDir)
+			assert.Equal(t, errors.New(tc.expectedErr), err)
+		})
+	}
+}
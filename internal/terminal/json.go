@@ -32,3 +32,30 @@ func (j jsonDocument) Payload() ([]string, map[string]interface{}, error) {
 		logFieldDoc:     j.data,
 	}, nil
 }
+
+type textDocument struct {
+	jsonDocument
+	text string
+}
+
+func (t textDocument) Message() (string, error) {
+	return fmt.Sprintf("%s\n%s", t.message, t.text), nil
+}
+
+type lazyDocument struct {
+	message string
+	text    string
+	data    func() (interface{}, error)
+}
+
+func (l lazyDocument) Message() (string, error) {
+	return fmt.Sprintf("%s\n%s", l.message, l.text), nil
+}
+
+func (l lazyDocument) Payload() ([]string, map[string]interface{}, error) {
+	data, err := l.data()
+	if err != nil {
+		return nil, nil, err
+	}
+	return jsonDocument{l.message, data}.Payload()
+}
@@ -47,6 +47,18 @@ func NewJSONLog(message string, data interface{}) Log {
 	return newLog(LogLevelInfo, jsonDocument{message, data})
 }
 
+// NewDocumentLog creates a new log with a JSON document, which is displayed
+// as the provided text rather than its JSON representation when printed as text
+func NewDocumentLog(message, text string, data interface{}) Log {
+	return newLog(LogLevelInfo, textDocument{jsonDocument{message, data}, text})
+}
+
+// NewLazyDocumentLog creates a new log with a JSON document like NewDocumentLog,
+// except the document is only produced once the log is printed as JSON
+func NewLazyDocumentLog(message, text string, data func() (interface{}, error)) Log {
+	return newLog(LogLevelInfo, lazyDocument{message, text, data})
+}
+
 // NewTableLog creates a new log with a table
 func NewTableLog(message string, headers []string, data ...map[string]interface{}) Log {
 	return newLog(LogLevelInfo, newTable(message, headers, data))
@@ -13,6 +13,7 @@ import (
 
 func TestLogConstructor(t *testing.T) {
 	assert.RegisterOpts(reflect.TypeOf(jsonDocument{}), cmp.AllowUnexported(jsonDocument{}))
+	assert.RegisterOpts(reflect.TypeOf(textDocument{}), cmp.AllowUnexported(textDocument{}, jsonDocument{}))
 	assert.RegisterOpts(reflect.TypeOf(table{}), cmp.AllowUnexported(table{}))
 	assert.RegisterOpts(reflect.TypeOf(list{}), cmp.AllowUnexported(list{}))
 
@@ -34,6 +35,12 @@ func TestLogConstructor(t *testing.T) {
 			expectedLevel: LogLevelInfo,
 			expectedData:  jsonDocument{"a json message", map[string]interface{}{"a": "ayyy"}},
 		},
+		{
+			ctor:          "NewDocumentLog",
+			log:           NewDocumentLog("a document message", "ayyy", map[string]interface{}{"a": "ayyy"}),
+			expectedLevel: LogLevelInfo,
+			expectedData:  textDocument{jsonDocument{"a document message", map[string]interface{}{"a": "ayyy"}}, "ayyy"},
+		},
 		{
 			ctor:          "NewTableLog",
 			log:           NewTableLog("a table message", []string{"a"}, map[string]interface{}{"a": "ayyy"}),
@@ -89,6 +96,26 @@ func TestLogMessage(t *testing.T) {
 				OutputFormatJSON: `{"time":"1989-06-22T07:54:00Z","level":"info","message":"a json document","doc":{"a":true,"b":1,"c":"sea"}}`,
 			},
 		},
+		{
+			level: LogLevelInfo,
+			data:  textDocument{jsonDocument{"a text document", []string{"a", "b"}}, "+ a\n+ b"},
+			expectedOutputs: map[OutputFormat]string{
+				OutputFormatText: `a text document
++ a
++ b`,
+				OutputFormatJSON: `{"time":"1989-06-22T07:54:00Z","level":"info","message":"a text document","doc":["a","b"]}`,
+			},
+		},
+		{
+			level: LogLevelInfo,
+			data:  lazyDocument{"a lazy document", "+ a\n+ b", func() (interface{}, error) { return []string{"a", "b"}, nil }},
+			expectedOutputs: map[OutputFormat]string{
+				OutputFormatText: `a lazy document
++ a
++ b`,
+				OutputFormatJSON: `{"time":"1989-06-22T07:54:00Z","level":"info","message":"a lazy document","doc":["a","b"]}`,
+			},
+		},
 		{
 			level: LogLevelError,
 			data:  errorMessage{errors.New("something bad happened")},
@@ -113,6 +140,19 @@ func TestLogMessage(t *testing.T) {
 		}
 	}
 
+	t.Run("Should only produce a lazy document when printed as json", func(t *testing.T) {
+		log := NewLazyDocumentLog("a lazy document", "+ a", func() (interface{}, error) {
+			return nil, errors.New("something bad happened")
+		})
+
+		output, err := log.Print(OutputFormatText)
+		assert.Nil(t, err)
+		assert.Equal(t, "a lazy document\n+ a", output)
+
+		_, err = log.Print(OutputFormatJSON)
+		assert.Equal(t, errors.New("something bad happened"), err)
+	})
+
 	t.Run("Should return an error with an unknown output format", func(t *testing.T) {
 		log := Log{
 			LogLevelInfo,
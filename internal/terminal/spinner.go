@@ -1,7 +1,30 @@
 package terminal
 
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/briandowns/spinner"
+)
+
 // set of supported spinners
 var (
 	SpinnerCircles = []string{"㊂", "㊀", "㊁"}
 	SpinnerDots    = []string{".  ", ".. ", "...", "   "}
 )
+
+// bufferedUI is a UI which holds onto its output rather than writing it to the terminal
+type bufferedUI interface {
+	Buffered() bool
+}
+
+// NewSpinner creates a new circles spinner with the provided message,
+// which is silenced when the UI holds onto its output
+func NewSpinner(ui UI, message string) *spinner.Spinner {
+	s := spinner.New(SpinnerCircles, 250*time.Millisecond)
+	s.Suffix = " " + message
+	if b, ok := ui.(bufferedUI); ok && b.Buffered() {
+		s.Writer = ioutil.Discard
+	}
+	return s
+}
@@ -1,6 +1,8 @@
 package testutils
 
 import (
+	"archive/zip"
+	"bytes"
 	"io/ioutil"
 	"os"
 
@@ -34,3 +36,25 @@ func SetupHomeDir(newHome string) (string, func()) {
 		_ = os.Setenv("HOME", origHome)
 	}
 }
+
+// NewZipReader constructs a new in-memory zip package holding the provided files,
+// which are keyed by their path within the package
+func NewZipReader(files map[string]string) (*zip.Reader, error) {
+	buf := new(bytes.Buffer)
+
+	w := zip.NewWriter(buf)
+	for name, contents := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Write([]byte(contents)); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+}
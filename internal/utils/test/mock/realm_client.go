@@ -32,14 +32,22 @@ type RealmClient struct {
 	DiscardDraftFn func(groupID, appID, draftID string) error
 	DraftFn        func(groupID, appID string) (realm.AppDraft, error)
 
-	DeployDraftFn func(groupID, appID, draftID string) (realm.AppDeployment, error)
-	DeploymentFn  func(groupID, appID, deploymentID string) (realm.AppDeployment, error)
+	DeployDraftFn        func(groupID, appID, draftID string) (realm.AppDeployment, error)
+	DeploymentFn         func(groupID, appID, deploymentID string) (realm.AppDeployment, error)
+	DeploymentsFn        func(groupID, appID string) ([]realm.AppDeployment, error)
+	RedeployDeploymentFn func(groupID, appID, deploymentID string) error
 
 	SecretsFn      func(groupID, appID string) ([]realm.Secret, error)
 	CreateSecretFn func(groupID, appID, name, value string) (realm.Secret, error)
 	DeleteSecretFn func(groupID, appID, secretID string) error
 	UpdateSecretFn func(groupID, appID, secretID, name, value string) error
 
+	ValuesFn      func(groupID, appID string) ([]realm.Value, error)
+	ValueFn       func(groupID, appID, valueID string) (realm.Value, error)
+	CreateValueFn func(groupID, appID string, value realm.Value) (realm.Value, error)
+	UpdateValueFn func(groupID, appID string, value realm.Value) error
+	DeleteValueFn func(groupID, appID, valueID string) error
+
 	CreateAPIKeyFn      func(groupID, appID, apiKeyName string) (realm.APIKey, error)
 	CreateUserFn        func(groupID, appID, email, password string) (realm.User, error)
 	DeleteUserFn        func(groupID, appID, userID string) error
@@ -54,8 +62,9 @@ type RealmClient struct {
 	HostingAssetAttributesUpdateFn func(groupID, appID, path string, attrs ...realm.HostingAssetAttribute) error
 	HostingCacheInvalidateFn       func(groupID, appID, path string) error
 
-	FunctionsFn               func(groupID, appID string) ([]realm.Function, error)
-	AppDebugExecuteFunctionFn func(groupID, appID, userID, name string, args []interface{}) (realm.ExecutionResults, error)
+	FunctionsFn                     func(groupID, appID string) ([]realm.Function, error)
+	AppDebugExecuteFunctionFn       func(groupID, appID, userID, name string, args []interface{}) (realm.ExecutionResults, error)
+	AppDebugExecuteFunctionSourceFn func(groupID, appID, userID, source, evalSource string) (realm.ExecutionResults, error)
 
 	StatusFn func() error
 }
@@ -210,6 +219,26 @@ func (rc RealmClient) Deployment(groupID, appID, deploymentID string) (realm.App
 	return rc.Client.Deployment(groupID, appID, deploymentID)
 }
 
+// Deployments calls the mocked Deployments implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) Deployments(groupID, appID string) ([]realm.AppDeployment, error) {
+	if rc.DeploymentsFn != nil {
+		return rc.DeploymentsFn(groupID, appID)
+	}
+	return rc.Client.Deployments(groupID, appID)
+}
+
+// RedeployDeployment calls the mocked RedeployDeployment implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) RedeployDeployment(groupID, appID, deploymentID string) error {
+	if rc.RedeployDeploymentFn != nil {
+		return rc.RedeployDeploymentFn(groupID, appID, deploymentID)
+	}
+	return rc.Client.RedeployDeployment(groupID, appID, deploymentID)
+}
+
 // CreateAPIKey calls the mocked CreateAPIKey implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -260,6 +289,56 @@ func (rc RealmClient) UpdateSecret(groupID, appID, secretID, name, value string)
 	return rc.Client.UpdateSecret(groupID, appID, secretID, name, value)
 }
 
+// Values calls the mocked Values implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) Values(groupID, appID string) ([]realm.Value, error) {
+	if rc.ValuesFn != nil {
+		return rc.ValuesFn(groupID, appID)
+	}
+	return rc.Client.Values(groupID, appID)
+}
+
+// Value calls the mocked Value implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) Value(groupID, appID, valueID string) (realm.Value, error) {
+	if rc.ValueFn != nil {
+		return rc.ValueFn(groupID, appID, valueID)
+	}
+	return rc.Client.Value(groupID, appID, valueID)
+}
+
+// CreateValue calls the mocked CreateValue implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) CreateValue(groupID, appID string, value realm.Value) (realm.Value, error) {
+	if rc.CreateValueFn != nil {
+		return rc.CreateValueFn(groupID, appID, value)
+	}
+	return rc.Client.CreateValue(groupID, appID, value)
+}
+
+// UpdateValue calls the mocked UpdateValue implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) UpdateValue(groupID, appID string, value realm.Value) error {
+	if rc.UpdateValueFn != nil {
+		return rc.UpdateValueFn(groupID, appID, value)
+	}
+	return rc.Client.UpdateValue(groupID, appID, value)
+}
+
+// DeleteValue calls the mocked DeleteValue implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) DeleteValue(groupID, appID, valueID string) error {
+	if rc.DeleteValueFn != nil {
+		return rc.DeleteValueFn(groupID, appID, valueID)
+	}
+	return rc.Client.DeleteValue(groupID, appID, valueID)
+}
+
 // CreateUser calls the mocked CreateUser implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined
@@ -420,6 +499,16 @@ func (rc RealmClient) AppDebugExecuteFunction(groupID, appID, userID, name strin
 	return rc.Client.AppDebugExecuteFunction(groupID, appID, userID, name, args)
 }
 
+// AppDebugExecuteFunctionSource calls the mocked AppDebugExecuteFunctionSource implementation if provided,
+// otherwise the call falls back to the underlying realm.Client implementation.
+// NOTE: this may panic if the underlying realm.Client is left undefined
+func (rc RealmClient) AppDebugExecuteFunctionSource(groupID, appID, userID, source, evalSource string) (realm.ExecutionResults, error) {
+	if rc.AppDebugExecuteFunctionSourceFn != nil {
+		return rc.AppDebugExecuteFunctionSourceFn(groupID, appID, userID, source, evalSource)
+	}
+	return rc.Client.AppDebugExecuteFunctionSource(groupID, appID, userID, source, evalSource)
+}
+
 // Status calls the mocked Status implementation if provided,
 // otherwise the call falls back to the underlying realm.Client implementation.
 // NOTE: this may panic if the underlying realm.Client is left undefined